@@ -43,6 +43,10 @@ func runApp() (exitCode int) {
 		return 1
 	}
 
+	if cmd.Type == cli.CommandConfig {
+		return cli.RunConfig(cmd, os.Stdout, os.Stderr)
+	}
+
 	cfg, topology, err := loadConfig(cmd.ConfigFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -51,7 +55,7 @@ func runApp() (exitCode int) {
 	}
 
 	switch cmd.Type {
-	case cli.CommandRun, cli.CommandStop:
+	case cli.CommandRun, cli.CommandStop, cli.CommandUp:
 		if len(cfg.Services) == 0 {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", errors.ErrNoServicesDefined)
 
@@ -88,10 +92,15 @@ func createAppWithoutConfig(cmd *cli.Options) *cli.CLI {
 func createApp(cfg *config.Config, topology *config.Topology, cmd *cli.Options) *fx.App {
 	isDark := lipgloss.HasDarkBackground(os.Stdin, os.Stdout)
 	log := render.NewLog(isDark)
-	writer := render.NewWriter(cfg, log, os.Stdout)
+	// lipgloss.Writer drops colors when stdout is not a terminal, such as the log file of a detached fuku
+	writer := render.NewWriter(cfg, log, lipgloss.Writer)
 
-	if cmd.NoUI || cmd.Type == cli.CommandLogs {
+	switch cmd.Type {
+	case cli.CommandLogs, cli.CommandAttach, cli.CommandUp, cli.CommandDown, cli.CommandStatus,
+		cli.CommandStart, cli.CommandStopService, cli.CommandRestart, cli.CommandPs:
 		writer.SetEnabled(true)
+	default:
+		writer.SetEnabled(cmd.NoUI)
 	}
 
 	return fx.New(
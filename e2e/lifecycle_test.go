@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/relay"
 )
 
 func Test_Lifecycle_RepeatedStartStop(t *testing.T) {
@@ -92,14 +94,23 @@ func Test_Lifecycle_PreflightCleansUpOrphans(t *testing.T) {
 }
 
 func Test_Lifecycle_SocketCleanup(t *testing.T) {
-	socketPath := filepath.Join("/tmp", "fuku-default.sock")
+	dir, err := filepath.Abs("testdata/default-tier")
+	require.NoError(t, err)
+
+	// the socket directory is derived from the project the command runs in
+	t.Chdir(dir)
+
+	socketDir, err := relay.SocketDir()
+	require.NoError(t, err)
+
+	socketPath := relay.SocketPathForProfile(socketDir, "default")
 
 	os.Remove(socketPath)
 	defer os.Remove(socketPath)
 
-	first := NewRunner(t, "testdata/default-tier")
+	first := NewRunner(t, dir)
 
-	err := first.Start("default")
+	err = first.Start("default")
 	require.NoError(t, err)
 
 	err = first.WaitForRunning(15 * time.Second)
@@ -114,7 +125,7 @@ func Test_Lifecycle_SocketCleanup(t *testing.T) {
 	require.FileExists(t, socketPath)
 
 	// Second run should clean up stale socket and start normally
-	second := NewRunner(t, "testdata/default-tier")
+	second := NewRunner(t, dir)
 	defer second.Stop()
 
 	err = second.Start("default")
@@ -124,7 +135,7 @@ func Test_Lifecycle_SocketCleanup(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify log streaming works through the new socket
-	logsRunner := NewLogsRunner(t, "testdata/default-tier")
+	logsRunner := NewLogsRunner(t, dir)
 	defer logsRunner.Stop()
 
 	err = logsRunner.Start("default")
@@ -1,19 +1,22 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
 
 	"fuku/internal/app/bus"
 	"fuku/internal/app/errors"
+	"fuku/internal/app/monitor"
 	"fuku/internal/app/registry"
 	"fuku/internal/config"
 )
 
 type handler struct {
-	bus   bus.Bus
-	store registry.Store
+	bus     bus.Bus
+	store   registry.Store
+	monitor monitor.Monitor
 }
 
 // StatusSerializer serializes the fuku instance status
@@ -27,27 +30,36 @@ type StatusSerializer struct {
 
 // ServiceCountSerializer serializes service counts by status
 type ServiceCountSerializer struct {
-	Total      int `json:"total"`
-	Starting   int `json:"starting"`
-	Running    int `json:"running"`
-	Stopping   int `json:"stopping"`
-	Restarting int `json:"restarting"`
-	Stopped    int `json:"stopped"`
-	Failed     int `json:"failed"`
+	Total       int `json:"total"`
+	Starting    int `json:"starting"`
+	Running     int `json:"running"`
+	Stopping    int `json:"stopping"`
+	Restarting  int `json:"restarting"`
+	Stopped     int `json:"stopped"`
+	Failed      int `json:"failed"`
+	CrashLoop   int `json:"crash_loop"`
+	Completed   int `json:"completed"`
+	BuildFailed int `json:"build_failed"`
 }
 
 // ServiceSerializer serializes a single service
 type ServiceSerializer struct {
-	ID       string          `json:"id"`
-	Name     string          `json:"name"`
-	Tier     string          `json:"tier"`
-	Status   registry.Status `json:"status"`
-	Watching bool            `json:"watching"`
-	Error    string          `json:"error,omitempty"`
-	PID      int             `json:"pid"`
-	CPU      float64         `json:"cpu"`
-	Memory   uint64          `json:"memory"`
-	Uptime   int64           `json:"uptime"`
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Tier        string          `json:"tier"`
+	Status      registry.Status `json:"status"`
+	Watching    bool            `json:"watching"`
+	Error       string          `json:"error,omitempty"`
+	PID         int             `json:"pid"`
+	CPU         float64         `json:"cpu"`
+	Memory      uint64          `json:"memory"`
+	Processes   int             `json:"processes"`
+	ListenerPID int             `json:"listener_pid"`
+	Uptime      int64           `json:"uptime"`
+	Attempt     int             `json:"attempt,omitempty"`
+	Restarts    int             `json:"restarts"`
+	ExitCode    *int            `json:"exit_code,omitempty"`
+	BuildOutput string          `json:"build_output,omitempty"`
 }
 
 // ServiceListSerializer serializes a list of services
@@ -112,23 +124,27 @@ func (h *handler) handleStatus(w http.ResponseWriter, _ *http.Request) {
 		Phase:   h.store.Phase(),
 		Uptime:  int64(h.store.Uptime().Seconds()),
 		Services: ServiceCountSerializer{
-			Total:      c.Total,
-			Starting:   c.Starting,
-			Running:    c.Running,
-			Stopping:   c.Stopping,
-			Restarting: c.Restarting,
-			Stopped:    c.Stopped,
-			Failed:     c.Failed,
+			Total:       c.Total,
+			Starting:    c.Starting,
+			Running:     c.Running,
+			Stopping:    c.Stopping,
+			Restarting:  c.Restarting,
+			Stopped:     c.Stopped,
+			Failed:      c.Failed,
+			CrashLoop:   c.CrashLoop,
+			Completed:   c.Completed,
+			BuildFailed: c.BuildFailed,
 		},
 	})
 }
 
-func (h *handler) handleListServices(w http.ResponseWriter, _ *http.Request) {
+func (h *handler) handleListServices(w http.ResponseWriter, r *http.Request) {
 	snapshots := h.store.Services()
 	services := make([]ServiceSerializer, len(snapshots))
 
 	for i, s := range snapshots {
 		services[i] = toServiceSerializer(s)
+		services[i].ListenerPID = h.listenerPID(r.Context(), s)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -152,11 +168,14 @@ func (h *handler) handleGetService(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	result := toServiceSerializer(svc)
+	result.ListenerPID = h.listenerPID(r.Context(), svc)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
 	//nolint:errcheck // best-effort JSON encoding
-	json.NewEncoder(w).Encode(toServiceSerializer(svc))
+	json.NewEncoder(w).Encode(result)
 }
 
 //nolint:dupl // start, stop and restart handlers share validation but differ in command and response
@@ -318,23 +337,38 @@ func (h *handler) handleRestartService(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// listenerPID looks up the process of a running service that listens on a TCP port. It is resolved
+// per request rather than on every sampling tick, because it may need a command per process
+func (h *handler) listenerPID(ctx context.Context, s registry.ServiceSnapshot) int {
+	if h.monitor == nil || !s.HasProcess() {
+		return 0
+	}
+
+	return h.monitor.ListenerPID(ctx, s.PIDs)
+}
+
 func toServiceSerializer(s registry.ServiceSnapshot) ServiceSerializer {
 	result := ServiceSerializer{
-		ID:       s.ID,
-		Name:     s.Name,
-		Tier:     s.Tier,
-		Status:   s.Status,
-		Watching: s.Watching,
-		Error:    s.Error,
+		ID:          s.ID,
+		Name:        s.Name,
+		Tier:        s.Tier,
+		Status:      s.Status,
+		Watching:    s.Watching,
+		Error:       s.Error,
+		Attempt:     s.Attempt,
+		Restarts:    s.Restarts,
+		ExitCode:    s.ExitCode,
+		BuildOutput: s.BuildOutput,
 	}
 
-	if !s.Status.IsRunning() {
+	if !s.HasProcess() {
 		return result
 	}
 
 	result.PID = s.PID
 	result.CPU = s.CPU
 	result.Memory = s.Memory
+	result.Processes = s.Processes
 
 	if !s.StartTime.IsZero() {
 		result.Uptime = int64(time.Since(s.StartTime).Seconds())
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"fuku/internal/app/bus"
+	"fuku/internal/app/monitor"
 	"fuku/internal/app/registry"
 )
 
@@ -83,10 +84,13 @@ func Test_HandleStatus(t *testing.T) {
 	h := &handler{store: mockStore, bus: bus.NewMockBus(ctrl)}
 
 	mockStore.EXPECT().Counts().Return(registry.StatusCounts{
-		Total:   4,
-		Running: 2,
-		Stopped: 1,
-		Failed:  1,
+		Total:       5,
+		Running:     2,
+		Stopped:     1,
+		Failed:      1,
+		CrashLoop:   1,
+		Completed:   1,
+		BuildFailed: 1,
 	})
 	mockStore.EXPECT().Profile().Return("default")
 	mockStore.EXPECT().Phase().Return(string(bus.PhaseRunning))
@@ -104,22 +108,30 @@ func Test_HandleStatus(t *testing.T) {
 	assert.Equal(t, "default", body.Profile)
 	assert.Equal(t, string(bus.PhaseRunning), body.Phase)
 	assert.Equal(t, int64(3600), body.Uptime)
-	assert.Equal(t, 4, body.Services.Total)
+	assert.Equal(t, 5, body.Services.Total)
 	assert.Equal(t, 2, body.Services.Running)
 	assert.Equal(t, 1, body.Services.Stopped)
 	assert.Equal(t, 1, body.Services.Failed)
+	assert.Equal(t, 1, body.Services.CrashLoop)
+	assert.Equal(t, 1, body.Services.Completed)
+	assert.Equal(t, 1, body.Services.BuildFailed)
 }
 
 func Test_HandleListServices(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockStore := registry.NewMockStore(ctrl)
-	h := &handler{store: mockStore, bus: bus.NewMockBus(ctrl)}
+	mockMonitor := monitor.NewMockMonitor(ctrl)
+	h := &handler{store: mockStore, monitor: mockMonitor, bus: bus.NewMockBus(ctrl)}
 
 	now := time.Now()
+	exitCode := 1
+	mockMonitor.EXPECT().ListenerPID(gomock.Any(), []int{100, 101, 102}).Return(102)
+	mockMonitor.EXPECT().ListenerPID(gomock.Any(), []int{300}).Return(0)
 	mockStore.EXPECT().Services().Return([]registry.ServiceSnapshot{
-		{ID: "id-1", Name: "db", Tier: "foundation", Status: registry.StatusRunning, PID: 100, CPU: 1.5, Memory: 1024, StartTime: now},
-		{ID: "id-2", Name: "api", Tier: "application", Status: registry.StatusStopped},
-		{ID: "id-3", Name: "worker", Tier: "application", Status: registry.StatusStarting, PID: 200, CPU: 0.5, Memory: 512, StartTime: now},
+		{ID: "id-1", Name: "db", Tier: "foundation", Status: registry.StatusRunning, PID: 100, CPU: 1.5, Memory: 1024, Processes: 3, PIDs: []int{100, 101, 102}, StartTime: now},
+		{ID: "id-2", Name: "api", Tier: "application", Status: registry.StatusCrashLoop, Restarts: 5, ExitCode: &exitCode},
+		{ID: "id-3", Name: "worker", Tier: "application", Status: registry.StatusStarting, PID: 200, CPU: 0.5, Memory: 512, Attempt: 2, StartTime: now},
+		{ID: "id-4", Name: "auth", Tier: "application", Status: registry.StatusBuildFailed, Error: "build failed", PID: 300, CPU: 2.0, Memory: 2048, Processes: 1, PIDs: []int{300}, StartTime: now},
 	})
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/services", nil)
@@ -131,17 +143,22 @@ func Test_HandleListServices(t *testing.T) {
 
 	var body ServiceListSerializer
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
-	require.Len(t, body.Services, 3)
+	require.Len(t, body.Services, 4)
 	assert.Equal(t, "db", body.Services[0].Name)
 	assert.Equal(t, registry.StatusRunning, body.Services[0].Status)
 	assert.Equal(t, 100, body.Services[0].PID)
 	assert.InDelta(t, 1.5, body.Services[0].CPU, 0.01)
 	assert.Equal(t, uint64(1024), body.Services[0].Memory)
+	assert.Equal(t, 3, body.Services[0].Processes)
+	assert.Equal(t, 102, body.Services[0].ListenerPID)
 
 	assert.Equal(t, "api", body.Services[1].Name)
-	assert.Equal(t, registry.StatusStopped, body.Services[1].Status)
+	assert.Equal(t, registry.StatusCrashLoop, body.Services[1].Status)
 	assert.Equal(t, 0, body.Services[1].PID)
 	assert.Equal(t, int64(0), body.Services[1].Uptime)
+	assert.Equal(t, 5, body.Services[1].Restarts)
+	require.NotNil(t, body.Services[1].ExitCode)
+	assert.Equal(t, 1, *body.Services[1].ExitCode)
 
 	assert.Equal(t, "worker", body.Services[2].Name)
 	assert.Equal(t, registry.StatusStarting, body.Services[2].Status)
@@ -149,6 +166,15 @@ func Test_HandleListServices(t *testing.T) {
 	assert.InDelta(t, 0, body.Services[2].CPU, 0.01)
 	assert.Equal(t, uint64(0), body.Services[2].Memory)
 	assert.Equal(t, int64(0), body.Services[2].Uptime)
+	assert.Equal(t, 2, body.Services[2].Attempt)
+	assert.Nil(t, body.Services[2].ExitCode)
+
+	assert.Equal(t, "auth", body.Services[3].Name)
+	assert.Equal(t, registry.StatusBuildFailed, body.Services[3].Status)
+	assert.Equal(t, "build failed", body.Services[3].Error)
+	assert.Equal(t, 300, body.Services[3].PID)
+	assert.InDelta(t, 2.0, body.Services[3].CPU, 0.01)
+	assert.Equal(t, uint64(2048), body.Services[3].Memory)
 }
 
 func Test_HandleGetService(t *testing.T) {
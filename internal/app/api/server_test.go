@@ -28,7 +28,7 @@ func Test_NewServer(t *testing.T) {
 	cfg.Server.Listen = "127.0.0.1:9876"
 	cfg.Server.Auth.Token = "test"
 
-	s := NewServer(cfg, nil, nil, mockLog)
+	s := NewServer(cfg, nil, nil, nil, mockLog)
 
 	assert.NotNil(t, s)
 	assert.Equal(t, cfg, s.cfg)
@@ -51,7 +51,7 @@ func Test_Server_StartAndShutdown(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Server.Listen = "127.0.0.1:0"
 
-	s := NewServer(cfg, mockStore, mockBus, mockLog)
+	s := NewServer(cfg, mockStore, nil, mockBus, mockLog)
 	s.Start()
 
 	require.NotNil(t, s.httpServer)
@@ -77,7 +77,7 @@ func Test_Server_Shutdown_NilServer(t *testing.T) {
 	mockLog := logger.NewMockLogger(ctrl)
 	mockLog.EXPECT().WithComponent("API").Return(mockLog)
 
-	s := NewServer(config.DefaultConfig(), nil, nil, mockLog)
+	s := NewServer(config.DefaultConfig(), nil, nil, nil, mockLog)
 
 	s.Shutdown(context.Background())
 }
@@ -96,7 +96,7 @@ func Test_Server_Start_PortBusy(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Server.Listen = "127.0.0.1:1"
 
-	s := NewServer(cfg, mockStore, mockBus, mockLog)
+	s := NewServer(cfg, mockStore, nil, mockBus, mockLog)
 	s.Start()
 
 	assert.Nil(t, s.httpServer)
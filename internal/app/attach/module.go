@@ -0,0 +1,10 @@
+package attach
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the attach package dependencies
+var Module = fx.Options(
+	fx.Provide(NewScreen),
+)
@@ -0,0 +1,305 @@
+package attach
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/term"
+
+	"fuku/internal/app/relay"
+	"fuku/internal/config/logger"
+)
+
+// Detach key sequence, Ctrl-P followed by Ctrl-Q
+const (
+	keyCtrlP = 0x10
+	keyCtrlQ = 0x11
+)
+
+// Keys the line editor handles for services without a terminal
+const (
+	keyCtrlC     = 0x03
+	keyCtrlD     = 0x04
+	keyBackspace = 0x08
+	keyCtrlU     = 0x15
+	keyDelete    = 0x7f
+)
+
+// inputBufferSize is the most keyboard input sent in one message
+const inputBufferSize = 4096
+
+// Screen handles the fuku attach command
+type Screen interface {
+	Run(ctx context.Context, profile, service string) int
+}
+
+// screen implements the Screen interface
+type screen struct {
+	client relay.Client
+	log    logger.Logger
+	in     io.Reader
+	out    io.Writer
+	errOut io.Writer
+}
+
+// NewScreen creates a new attach screen
+func NewScreen(client relay.Client, log logger.Logger) Screen {
+	return &screen{
+		client: client,
+		log:    log.WithComponent("ATTACH"),
+		in:     os.Stdin,
+		out:    os.Stdout,
+		errOut: os.Stderr,
+	}
+}
+
+// Run handles the attach command to connect the terminal to a service of a running instance
+func (s *screen) Run(ctx context.Context, profile, service string) int {
+	socketDir, err := relay.SocketDir()
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to find socket")
+		return 1
+	}
+
+	socketPath, err := relay.FindSocket(socketDir, profile)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to find socket")
+		return 1
+	}
+
+	return s.attach(ctx, socketPath, service)
+}
+
+// attach connects to a running fuku instance, forwarding keyboard input to the service and its output back
+func (s *screen) attach(ctx context.Context, socketPath, service string) int {
+	if err := s.client.Connect(socketPath); err != nil {
+		s.log.Error().Err(err).Msg("Failed to connect to socket")
+		return 1
+	}
+
+	defer s.client.Close()
+
+	attached, err := s.client.Attach(service)
+	if err != nil {
+		s.log.Error().Err(err).Msgf("Failed to attach to service '%s'", service)
+		return 1
+	}
+
+	fmt.Fprintf(s.errOut, "Attached to %s, press Ctrl-P Ctrl-Q to detach\n", service)
+
+	raw, restore := s.makeRaw()
+	defer restore()
+
+	// A service without a terminal has no line discipline of its own, so the raw keys are edited into lines here
+	var lines *lineEditor
+	if raw && !attached.TTY {
+		lines = &lineEditor{echo: s.out}
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var detached atomic.Bool
+
+	go func() {
+		if s.forwardInput(lines) {
+			detached.Store(true)
+			cancel()
+		}
+	}()
+
+	if err := s.client.Stream(ctx, &screenHandler{out: s.out, crlf: lines != nil}); err != nil {
+		s.log.Error().Err(err).Msg("Failed to stream output")
+		return 1
+	}
+
+	if ctx.Err() == nil && !detached.Load() {
+		fmt.Fprintf(s.errOut, "\r\nService %s exited\r\n", service)
+
+		return 0
+	}
+
+	fmt.Fprintf(s.errOut, "\r\nDetached from %s\r\n", service)
+
+	return 0
+}
+
+// forwardInput sends keyboard input to the service until the detach sequence is typed, returning
+// true in that case and false once input ends. Input passes through the line editor when one is given
+func (s *screen) forwardInput(lines *lineEditor) bool {
+	var d detector
+
+	buf := make([]byte, inputBufferSize)
+
+	for {
+		n, err := s.in.Read(buf)
+		if n > 0 {
+			data, detach := d.feed(buf[:n])
+
+			if lines != nil {
+				var interrupt bool
+
+				data, interrupt = lines.feed(data)
+				detach = detach || interrupt
+			}
+
+			if len(data) > 0 {
+				if err := s.client.Input(data); err != nil {
+					return false
+				}
+			}
+
+			if detach {
+				return true
+			}
+		}
+
+		if err != nil {
+			return false
+		}
+	}
+}
+
+// makeRaw puts the terminal into raw mode so every key, including the detach sequence, arrives as it is typed.
+// It reports whether the terminal is in raw mode and returns a function restoring it
+func (s *screen) makeRaw() (bool, func()) {
+	f, ok := s.in.(*os.File)
+	if !ok || !term.IsTerminal(f.Fd()) {
+		return false, func() {}
+	}
+
+	state, err := term.MakeRaw(f.Fd())
+	if err != nil {
+		s.log.Warn().Err(err).Msg("Failed to switch terminal to raw mode")
+
+		return false, func() {}
+	}
+
+	return true, func() {
+		//nolint:errcheck // best-effort terminal restore at exit
+		term.Restore(f.Fd(), state)
+	}
+}
+
+// detector finds the detach key sequence in keyboard input
+type detector struct {
+	pending bool
+}
+
+// feed returns the input to forward and whether the detach sequence was typed. A trailing Ctrl-P is
+// held back until the next key shows whether it starts the sequence
+func (d *detector) feed(p []byte) ([]byte, bool) {
+	out := make([]byte, 0, len(p)+1)
+
+	for _, b := range p {
+		if d.pending {
+			d.pending = false
+
+			if b == keyCtrlQ {
+				return out, true
+			}
+
+			out = append(out, keyCtrlP)
+		}
+
+		if b == keyCtrlP {
+			d.pending = true
+
+			continue
+		}
+
+		out = append(out, b)
+	}
+
+	return out, false
+}
+
+// lineEditor does the line editing a terminal in cooked mode would do, for services reading a plain pipe.
+// Typed keys are echoed and held until Enter, Backspace and Ctrl-U edit the pending line, Ctrl-D sends it
+// without a newline and Ctrl-C ends the attach
+type lineEditor struct {
+	echo io.Writer
+	line []byte
+	cr   bool
+}
+
+// feed returns the completed input to forward and whether Ctrl-C was typed
+func (e *lineEditor) feed(p []byte) ([]byte, bool) {
+	var out []byte
+
+	for _, b := range p {
+		cr := e.cr
+		e.cr = b == '\r'
+
+		switch b {
+		case '\r', '\n':
+			if b == '\n' && cr {
+				continue
+			}
+
+			out = append(append(out, e.line...), '\n')
+			e.line = e.line[:0]
+			e.write([]byte("\r\n"))
+		case keyBackspace, keyDelete:
+			e.erase(1)
+		case keyCtrlU:
+			e.erase(utf8.RuneCount(e.line))
+		case keyCtrlD:
+			out = append(out, e.line...)
+			e.line = e.line[:0]
+		case keyCtrlC:
+			return out, true
+		default:
+			e.line = append(e.line, b)
+			e.write([]byte{b})
+		}
+	}
+
+	return out, false
+}
+
+// erase removes runes from the end of the pending line and from the screen
+func (e *lineEditor) erase(runes int) {
+	for ; runes > 0 && len(e.line) > 0; runes-- {
+		_, size := utf8.DecodeLastRune(e.line)
+		e.line = e.line[:len(e.line)-size]
+		e.write([]byte("\b \b"))
+	}
+}
+
+// write echoes to the terminal
+func (e *lineEditor) write(p []byte) {
+	//nolint:errcheck // best-effort echo to the terminal
+	e.echo.Write(p)
+}
+
+// screenHandler implements relay.Handler for the attach screen
+type screenHandler struct {
+	out  io.Writer
+	crlf bool
+}
+
+// HandleStatus ignores status messages, which are only sent to log subscribers
+func (h *screenHandler) HandleStatus(relay.StatusMessage) {}
+
+// HandleLog ignores log lines, since attached clients receive raw output instead
+func (h *screenHandler) HandleLog(relay.LogMessage) {}
+
+// HandleOutput writes raw service output to the terminal
+func (h *screenHandler) HandleOutput(msg relay.OutputMessage) {
+	data := msg.Data
+	if h.crlf {
+		// a terminal in raw mode does not return the cursor on a newline
+		data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+	}
+
+	//nolint:errcheck // best-effort write to output
+	h.out.Write(data)
+}
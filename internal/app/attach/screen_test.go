@@ -0,0 +1,224 @@
+package attach
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/relay"
+	"fuku/internal/config/logger"
+)
+
+func Test_NewScreen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := relay.NewMockClient(ctrl)
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().WithComponent("ATTACH").Return(mockLog)
+
+	s := NewScreen(mockClient, mockLog)
+
+	require.NotNil(t, s)
+}
+
+func Test_screen_attach(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		before func(client *relay.MockClient)
+		expect int
+		output string
+		status string
+	}{
+		{
+			name:  "forwards input until detached",
+			input: "next\r\x10\x11ignored",
+			before: func(client *relay.MockClient) {
+				client.EXPECT().Connect("/tmp/test.sock").Return(nil)
+				client.EXPECT().Attach("api").Return(relay.AttachedMessage{Type: relay.MessageAttached, Service: "api"}, nil)
+				client.EXPECT().Input([]byte("next\r")).Return(nil)
+				client.EXPECT().Stream(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler relay.Handler) error {
+					handler.HandleOutput(relay.OutputMessage{Type: relay.MessageOutput, Data: []byte("> main.go:12\n")})
+					<-ctx.Done()
+
+					return nil
+				})
+				client.EXPECT().Close().Return(nil)
+			},
+			expect: 0,
+			output: "> main.go:12\n",
+			status: "Detached from api",
+		},
+		{
+			name:  "service exits",
+			input: "",
+			before: func(client *relay.MockClient) {
+				client.EXPECT().Connect("/tmp/test.sock").Return(nil)
+				client.EXPECT().Attach("api").Return(relay.AttachedMessage{Type: relay.MessageAttached, Service: "api"}, nil)
+				client.EXPECT().Stream(gomock.Any(), gomock.Any()).Return(nil)
+				client.EXPECT().Close().Return(nil)
+			},
+			expect: 0,
+			status: "Service api exited",
+		},
+		{
+			name: "connect error",
+			before: func(client *relay.MockClient) {
+				client.EXPECT().Connect("/tmp/test.sock").Return(errors.New("connection refused"))
+			},
+			expect: 1,
+		},
+		{
+			name: "attach error",
+			before: func(client *relay.MockClient) {
+				client.EXPECT().Connect("/tmp/test.sock").Return(nil)
+				client.EXPECT().Attach("api").Return(relay.AttachedMessage{}, errors.New("service is not running"))
+				client.EXPECT().Close().Return(nil)
+			},
+			expect: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := relay.NewMockClient(ctrl)
+			tt.before(mockClient)
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			var out, errOut bytes.Buffer
+
+			// a pipe that stays open keeps the input goroutine from ending the session early
+			in, inWriter := io.Pipe()
+			defer inWriter.Close()
+
+			if tt.input != "" {
+				go func() {
+					//nolint:errcheck // the reader is closed once the test ends
+					inWriter.Write([]byte(tt.input))
+				}()
+			}
+
+			s := &screen{client: mockClient, log: mockLog, in: in, out: &out, errOut: &errOut}
+
+			assert.Equal(t, tt.expect, s.attach(t.Context(), "/tmp/test.sock", "api"))
+			assert.Equal(t, tt.output, out.String())
+			assert.True(t, strings.Contains(errOut.String(), tt.status))
+		})
+	}
+}
+
+func Test_detector_feed(t *testing.T) {
+	tests := []struct {
+		name     string
+		chunks   []string
+		expected string
+		detach   bool
+	}{
+		{name: "plain input", chunks: []string{"continue\r"}, expected: "continue\r"},
+		{name: "detach sequence", chunks: []string{"ab\x10\x11cd"}, expected: "ab", detach: true},
+		{name: "detach split across reads", chunks: []string{"ab\x10", "\x11"}, expected: "ab", detach: true},
+		{name: "ctrl-p alone is forwarded", chunks: []string{"\x10", "x"}, expected: "\x10x"},
+		{name: "double ctrl-p", chunks: []string{"\x10\x10\x11"}, expected: "\x10", detach: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				d      detector
+				out    []byte
+				detach bool
+			)
+
+			for _, chunk := range tt.chunks {
+				data, done := d.feed([]byte(chunk))
+				out = append(out, data...)
+
+				if done {
+					detach = true
+
+					break
+				}
+			}
+
+			assert.Equal(t, tt.expected, string(out))
+			assert.Equal(t, tt.detach, detach)
+		})
+	}
+}
+
+func Test_lineEditor_feed(t *testing.T) {
+	tests := []struct {
+		name      string
+		chunks    []string
+		expected  string
+		echo      string
+		interrupt bool
+	}{
+		{name: "line is sent on enter", chunks: []string{"ab", "c\r"}, expected: "abc\n", echo: "abc\r\n"},
+		{name: "pasted line feeds", chunks: []string{"a\r\nb\n"}, expected: "a\nb\n", echo: "a\r\nb\r\n"},
+		{name: "backspace", chunks: []string{"ab\x7fc\r"}, expected: "ac\n", echo: "ab\b \bc\r\n"},
+		{name: "backspace removes a whole rune", chunks: []string{"é\x7f\r"}, expected: "\n", echo: "é\b \b\r\n"},
+		{name: "ctrl-u clears the line", chunks: []string{"ab\x15c\r"}, expected: "c\n", echo: "ab\b \b\b \bc\r\n"},
+		{name: "ctrl-d sends without newline", chunks: []string{"ab\x04"}, expected: "ab", echo: "ab"},
+		{name: "ctrl-c interrupts", chunks: []string{"a\r", "b\x03c"}, expected: "a\n", echo: "a\r\nb", interrupt: true},
+		{name: "pending line is held", chunks: []string{"abc"}, echo: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var echo bytes.Buffer
+
+			e := &lineEditor{echo: &echo}
+
+			var (
+				out       []byte
+				interrupt bool
+			)
+
+			for _, chunk := range tt.chunks {
+				data, done := e.feed([]byte(chunk))
+				out = append(out, data...)
+
+				if done {
+					interrupt = true
+
+					break
+				}
+			}
+
+			assert.Equal(t, tt.expected, string(out))
+			assert.Equal(t, tt.echo, echo.String())
+			assert.Equal(t, tt.interrupt, interrupt)
+		})
+	}
+}
+
+func Test_screenHandler_HandleOutput(t *testing.T) {
+	for _, tt := range []struct {
+		crlf     bool
+		expected string
+	}{
+		{crlf: false, expected: "one\ntwo\n"},
+		{crlf: true, expected: "one\r\ntwo\r\n"},
+	} {
+		var out bytes.Buffer
+
+		h := &screenHandler{out: &out, crlf: tt.crlf}
+		h.HandleOutput(relay.OutputMessage{Type: relay.MessageOutput, Data: []byte("one\ntwo\n")})
+
+		assert.Equal(t, tt.expected, out.String())
+	}
+}
@@ -15,29 +15,37 @@ type MessageType string
 
 // Event types
 const (
-	EventCommandStarted    MessageType = "command_started"
-	EventPhaseChanged      MessageType = "phase_changed"
-	EventProfileResolved   MessageType = "profile_resolved"
-	EventPreflightStarted  MessageType = "preflight_started"
-	EventPreflightKill     MessageType = "preflight_kill"
-	EventPreflightComplete MessageType = "preflight_complete"
-	EventTierStarting      MessageType = "tier_starting"
-	EventTierReady         MessageType = "tier_ready"
-	EventServiceStarting   MessageType = "service_starting"
-	EventReadinessComplete MessageType = "readiness_complete"
-	EventServiceReady      MessageType = "service_ready"
-	EventServiceFailed     MessageType = "service_failed"
-	EventServiceStopping   MessageType = "service_stopping"
-	EventServiceStopped    MessageType = "service_stopped"
-	EventServiceRestarting MessageType = "service_restarting"
-	EventSignal            MessageType = "signal"
-	EventWatchTriggered    MessageType = "watch_triggered"
-	EventWatchStarted      MessageType = "watch_started"
-	EventWatchStopped      MessageType = "watch_stopped"
-	EventResourceSample    MessageType = "resource_sample"
-	EventAPIStarted        MessageType = "api_started"
-	EventAPIStopped        MessageType = "api_stopped"
-	EventAPIRequest        MessageType = "api_request"
+	EventCommandStarted     MessageType = "command_started"
+	EventPhaseChanged       MessageType = "phase_changed"
+	EventProfileResolved    MessageType = "profile_resolved"
+	EventPreflightStarted   MessageType = "preflight_started"
+	EventPreflightKill      MessageType = "preflight_kill"
+	EventPreflightComplete  MessageType = "preflight_complete"
+	EventTierStarting       MessageType = "tier_starting"
+	EventTierReady          MessageType = "tier_ready"
+	EventServiceStarting    MessageType = "service_starting"
+	EventReadinessComplete  MessageType = "readiness_complete"
+	EventServiceReady       MessageType = "service_ready"
+	EventServiceCompleted   MessageType = "service_completed"
+	EventServiceFailed      MessageType = "service_failed"
+	EventServiceStopping    MessageType = "service_stopping"
+	EventServiceStopped     MessageType = "service_stopped"
+	EventServiceRestarting  MessageType = "service_restarting"
+	EventServiceBackoff     MessageType = "service_backoff"
+	EventServiceCrashLoop   MessageType = "service_crash_loop"
+	EventServiceBuilding    MessageType = "service_building"
+	EventServiceBuildFailed MessageType = "service_build_failed"
+	EventHookStarted        MessageType = "hook_started"
+	EventHookFinished       MessageType = "hook_finished"
+	EventSignal             MessageType = "signal"
+	EventWatchTriggered     MessageType = "watch_triggered"
+	EventWatchStarted       MessageType = "watch_started"
+	EventWatchStopped       MessageType = "watch_stopped"
+	EventResourceSample     MessageType = "resource_sample"
+	EventAPIStarted         MessageType = "api_started"
+	EventAPIStopped         MessageType = "api_stopped"
+	EventAPIRequest         MessageType = "api_request"
+	EventRelayStarted       MessageType = "relay_started"
 )
 
 // Command types
@@ -150,9 +158,10 @@ func (e ServiceEvent) ServiceID() string {
 // ServiceStarting indicates a service is starting with attempt and process info
 type ServiceStarting struct {
 	ServiceEvent
-	Attempt   int
-	PID       int
-	StartedAt time.Time
+	Attempt     int
+	MaxAttempts int
+	PID         int
+	StartedAt   time.Time
 }
 
 // ReadinessComplete indicates a readiness check has finished successfully
@@ -170,10 +179,32 @@ type ServiceReady struct {
 	Duration  time.Duration
 }
 
+// ServiceCompleted indicates a job service exited successfully
+type ServiceCompleted struct {
+	ServiceEvent
+	StartedAt time.Time
+	Duration  time.Duration
+}
+
+// ServiceBuilding indicates a service's build command has started
+type ServiceBuilding struct {
+	ServiceEvent
+	Command string
+}
+
+// ServiceBuildFailed indicates a service's build command failed; any previous process keeps running
+type ServiceBuildFailed struct {
+	ServiceEvent
+	Output   string
+	Duration time.Duration
+	Error    error
+}
+
 // ServiceFailed indicates a service failed to start or crashed
 type ServiceFailed struct {
 	ServiceEvent
-	Error error
+	Error    error
+	ExitCode int
 }
 
 // ServiceStopping indicates a service is being stopped
@@ -185,6 +216,7 @@ type ServiceStopping struct {
 type ServiceStopped struct {
 	ServiceEvent
 	Unexpected bool
+	ExitCode   int
 }
 
 // ServiceRestarting indicates a service is being restarted
@@ -192,6 +224,36 @@ type ServiceRestarting struct {
 	ServiceEvent
 }
 
+// ServiceBackoff indicates a crashed service will be restarted automatically after a delay
+type ServiceBackoff struct {
+	ServiceEvent
+	ExitCode int
+	Restarts int
+	Delay    time.Duration
+}
+
+// ServiceCrashLoop indicates a service kept crashing and will not be restarted automatically
+type ServiceCrashLoop struct {
+	ServiceEvent
+	ExitCode int
+	Restarts int
+}
+
+// HookStarted indicates a lifecycle hook of a service has started running
+type HookStarted struct {
+	ServiceEvent
+	Hook    string
+	Command string
+}
+
+// HookFinished indicates a lifecycle hook of a service has finished, with its error if it failed
+type HookFinished struct {
+	ServiceEvent
+	Hook     string
+	Duration time.Duration
+	Error    error
+}
+
 // Signal contains information about a received OS signal
 type Signal struct {
 	Name string
@@ -225,6 +287,11 @@ type APIRequest struct {
 	Duration time.Duration
 }
 
+// RelayStarted indicates the logs and control socket is listening
+type RelayStarted struct {
+	Socket string
+}
+
 // Bus handles pub/sub messaging
 type Bus interface {
 	Subscribe(ctx context.Context) <-chan Message
@@ -46,11 +46,19 @@ func (f *Formatter) Format(msgType MessageType, data any) string {
 	case TierReady:
 		e.Str("tier", d.Name).Str("duration", d.Duration.String()).Int("services", d.ServiceCount)
 	case ServiceStarting:
-		e.Str("id", d.Service.ID).Str("service", d.Service.Name).Str("tier", d.Tier).Int("pid", d.PID)
+		e.Str("id", d.Service.ID).Str("service", d.Service.Name).Str("tier", d.Tier).Int("pid", d.PID).Int("attempt", d.Attempt)
 	case ReadinessComplete:
 		e.Str("id", d.Service.ID).Str("service", d.Service.Name).Str("type", d.Type).Str("duration", d.Duration.String())
 	case ServiceReady:
 		e.Str("id", d.Service.ID).Str("service", d.Service.Name).Str("tier", d.Tier)
+	case ServiceCompleted:
+		e.Str("id", d.Service.ID).Str("service", d.Service.Name).Str("tier", d.Tier).Str("duration", d.Duration.String())
+	case ServiceBuildFailed:
+		e.Str("id", d.Service.ID).Str("service", d.Service.Name).Str("tier", d.Tier).Str("duration", d.Duration.String())
+
+		if d.Error != nil {
+			e.Str("error", d.Error.Error())
+		}
 	case ServiceFailed:
 		e.Str("id", d.Service.ID).Str("service", d.Service.Name).Str("tier", d.Tier)
 
@@ -74,6 +82,8 @@ func (f *Formatter) Format(msgType MessageType, data any) string {
 	case APIStopped:
 	case APIRequest:
 		e.Str("method", d.Method).Str("path", d.Path).Int("status", d.Status).Str("duration", d.Duration.String())
+	case RelayStarted:
+		e.Str("socket", d.Socket)
 	default:
 		e.Interface("data", data)
 	}
@@ -89,6 +89,18 @@ func Test_FormatEvent(t *testing.T) {
 			data:     ServiceReady{ServiceEvent: ServiceEvent{Service: Service{ID: "test-id-api", Name: "api"}, Tier: "platform"}},
 			contains: []string{"service_ready", "service=api", "tier=platform"},
 		},
+		{
+			name:     "ServiceCompleted",
+			msgType:  EventServiceCompleted,
+			data:     ServiceCompleted{ServiceEvent: ServiceEvent{Service: Service{ID: "test-id-migrate", Name: "migrate"}, Tier: "foundation"}, Duration: time.Second},
+			contains: []string{"service_completed", "service=migrate", "tier=foundation", "duration=1s"},
+		},
+		{
+			name:     "ServiceBuildFailed",
+			msgType:  EventServiceBuildFailed,
+			data:     ServiceBuildFailed{ServiceEvent: ServiceEvent{Service: Service{ID: "test-id-api", Name: "api"}, Tier: "platform"}, Duration: time.Second, Error: errors.New("build failed")},
+			contains: []string{"service_build_failed", "service=api", "tier=platform", "duration=1s", "error=\"build failed\""},
+		},
 		{
 			name:     "ServiceFailed",
 			msgType:  EventServiceFailed,
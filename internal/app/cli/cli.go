@@ -21,14 +21,37 @@ const (
   fuku --run <profile>            Same as above (--run, -r, run, r)
   fuku run <profile> --no-ui      Run services without TUI
 
+  fuku up <profile>               Same as run
+  fuku up -d <profile>            Run services in the background, logging to a file (--detach, -d)
+  fuku down [profile]             Stop a background fuku and its services
+  fuku status [profile]           Show the services of a running fuku
+  fuku ui [profile]               Attach the TUI to a running fuku (detach with q)
+
   fuku stop                       Stop services with default profile
   fuku stop <profile>             Stop services with specified profile
   fuku --stop <profile>           Same as above (--stop, -s, stop, s)
+  fuku stop --dry-run             List the processes that would be killed
+  fuku stop --yes                 Kill without asking for confirmation (-y)
 
   fuku logs [service...]          Stream logs from running services
   fuku --logs                     Same as above (--logs, -l, logs, l)
   fuku logs --profile <name> [service...] Stream logs from specific profile
 
+  fuku attach <service>           Attach to a service's stdin and output (detach with Ctrl-P Ctrl-Q)
+  fuku attach --profile <name> <service> Attach to a service of a specific profile
+
+  fuku start <service>            Start a service of a running fuku and wait until it is running
+  fuku stop-service <service>     Stop a service of a running fuku and wait until it has stopped
+  fuku restart <service>          Restart a service of a running fuku and wait until it is running
+                                  (--profile <name>, --timeout <duration>; exit code 2 on timeout)
+  fuku ps                         List the services of a running fuku (--json, --profile <name>)
+
+  fuku exec <service> -- <cmd>    Run a command in a service's directory and environment
+
+  fuku config validate            Validate config and exit non-zero on problems
+  fuku config print               Print fully merged config (--format yaml|json, --explain)
+                                  Secrets are redacted unless --show-secrets is set
+
   fuku --config <path>            Use custom config file, skip override merging (--config, -c)
 
   fuku help                       Show help (--help, -h, help)
@@ -39,11 +62,20 @@ Examples:
   fuku init                       Generate fuku.yaml in current directory
   fuku run core --no-ui           Run core services without TUI
   fuku -r core --no-ui            Same as above using flag
+  fuku up -d core                 Run core services in the background
+  fuku status                     Show what the background fuku is running
+  fuku down                       Stop the background fuku
   fuku stop                       Stop all services (default profile)
   fuku stop backend               Stop backend services
+  fuku stop --dry-run             Show what stop would kill
   fuku logs                       Stream all logs from running fuku
   fuku logs api auth              Stream logs from api and auth services
   fuku -l                         Stream logs using flag
+  fuku attach api                 Type into the api service, e.g. a debugger or REPL
+  fuku restart api                Restart the api service and wait until it is running again
+  fuku ps --json                  List services of the running fuku as JSON
+  fuku exec api -- make migrate   Run a migration with the api service's env
+  fuku config print --explain     Show merged config with the file and line of each value
   fuku -c custom.yaml run core    Use custom config file (no override merging)
   fuku --config /path/fuku.yaml   Use config from another directory (no override merging)`
 )
@@ -1,6 +1,9 @@
 package cli
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"fuku/internal/app/errors"
@@ -18,6 +21,29 @@ const (
 	CommandLogs
 	CommandVersion
 	CommandHelp
+	CommandConfig
+	CommandAttach
+	CommandUp
+	CommandDown
+	CommandStatus
+	CommandUI
+	CommandStart
+	CommandStopService
+	CommandRestart
+	CommandPs
+	CommandExec
+)
+
+// Config subcommand actions
+const (
+	ConfigValidate = "validate"
+	ConfigPrint    = "print"
+)
+
+// Output formats for config print
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
 )
 
 // Standalone returns true for commands that run without config or FX container
@@ -45,6 +71,28 @@ func (c CommandType) String() string {
 		return "version"
 	case CommandHelp:
 		return "help"
+	case CommandConfig:
+		return "config"
+	case CommandAttach:
+		return "attach"
+	case CommandUp:
+		return "up"
+	case CommandDown:
+		return "down"
+	case CommandStatus:
+		return "status"
+	case CommandUI:
+		return "ui"
+	case CommandStart:
+		return "start"
+	case CommandStopService:
+		return "stop-service"
+	case CommandRestart:
+		return "restart"
+	case CommandPs:
+		return "ps"
+	case CommandExec:
+		return "exec"
 	default:
 		return "unknown"
 	}
@@ -52,11 +100,20 @@ func (c CommandType) String() string {
 
 // Options contains the parsed command-line arguments
 type Options struct {
-	ConfigFile string
-	Type       CommandType
-	Profile    string
-	Services   []string
-	NoUI       bool
+	ConfigFile   string
+	Type         CommandType
+	Profile      string
+	Services     []string
+	NoUI         bool
+	ConfigAction string
+	Format       string
+	Explain      bool
+	ShowSecrets  bool
+	DryRun       bool
+	Yes          bool
+	JSON         bool
+	Timeout      time.Duration
+	Command      []string
 }
 
 // rootFlags holds flag values for the root command
@@ -83,7 +140,18 @@ func Parse(args []string) (*Options, error) {
 		buildRunCommand(result),
 		buildStopCommand(result),
 		buildLogsCommand(result),
+		buildAttachCommand(result),
+		buildUpCommand(result),
+		buildDownCommand(result),
+		buildStatusCommand(result),
+		buildUICommand(result),
+		buildStartCommand(result),
+		buildStopServiceCommand(result),
+		buildRestartCommand(result),
+		buildPsCommand(result),
+		buildExecCommand(result),
 		buildVersionCommand(result),
+		buildConfigCommand(result),
 	)
 
 	root.SetArgs(args)
@@ -189,7 +257,7 @@ func buildStopCommand(result *Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "stop [profile]",
 		Aliases: []string{"s"},
-		Short:   "Stop services by killing processes in service directories",
+		Short:   "Stop services by killing the processes fuku started for them",
 		Args:    cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			result.Type = CommandStop
@@ -199,6 +267,9 @@ func buildStopCommand(result *Options) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVar(&result.DryRun, "dry-run", false, "List the processes that would be killed without killing them")
+	cmd.Flags().BoolVarP(&result.Yes, "yes", "y", false, "Kill without asking for confirmation")
+
 	return cmd
 }
 
@@ -222,6 +293,173 @@ func buildLogsCommand(result *Options) *cobra.Command {
 	return cmd
 }
 
+// buildAttachCommand creates the attach subcommand
+func buildAttachCommand(result *Options) *cobra.Command {
+	var attachProfile string
+
+	cmd := &cobra.Command{
+		Use:   "attach <service>",
+		Short: "Attach the terminal to a running service's stdin and output",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			result.Type = CommandAttach
+			result.Services = args
+			result.Profile = attachProfile
+		},
+	}
+
+	cmd.Flags().StringVar(&attachProfile, "profile", "", "Filter by profile")
+
+	return cmd
+}
+
+// buildUpCommand creates the up subcommand, which runs like run or in the background with --detach
+func buildUpCommand(result *Options) *cobra.Command {
+	var detach bool
+
+	cmd := &cobra.Command{
+		Use:   "up [profile]",
+		Short: "Run services with the specified profile, in the background with --detach",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			result.Type = CommandRun
+			if detach {
+				result.Type = CommandUp
+			}
+
+			if len(args) > 0 {
+				result.Profile = args[0]
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&detach, "detach", "d", false, "Run in the background without TUI, logging to a file")
+
+	return cmd
+}
+
+// buildDownCommand creates the down subcommand
+func buildDownCommand(result *Options) *cobra.Command {
+	return buildInstanceCommand(result, CommandDown, "down", "Stop a running fuku instance and its services")
+}
+
+// buildStatusCommand creates the status subcommand
+func buildStatusCommand(result *Options) *cobra.Command {
+	return buildInstanceCommand(result, CommandStatus, "status", "Show the services of a running fuku instance")
+}
+
+// buildUICommand creates the ui subcommand
+func buildUICommand(result *Options) *cobra.Command {
+	return buildInstanceCommand(result, CommandUI, "ui", "Attach the TUI to a running fuku instance")
+}
+
+// buildInstanceCommand creates a subcommand acting on a running instance, found by its optional profile argument
+func buildInstanceCommand(result *Options, commandType CommandType, use, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " [profile]",
+		Short: short,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			result.Type = commandType
+			result.Profile = ""
+
+			if len(args) > 0 {
+				result.Profile = args[0]
+			}
+		},
+	}
+}
+
+// buildStartCommand creates the start subcommand
+func buildStartCommand(result *Options) *cobra.Command {
+	return buildServiceCommand(result, CommandStart, "start", "Start a service of a running fuku instance and wait until it is running")
+}
+
+// buildStopServiceCommand creates the stop-service subcommand
+func buildStopServiceCommand(result *Options) *cobra.Command {
+	return buildServiceCommand(result, CommandStopService, "stop-service", "Stop a service of a running fuku instance and wait until it has stopped")
+}
+
+// buildRestartCommand creates the restart subcommand
+func buildRestartCommand(result *Options) *cobra.Command {
+	return buildServiceCommand(result, CommandRestart, "restart", "Restart a service of a running fuku instance and wait until it is running")
+}
+
+// buildServiceCommand creates a subcommand acting on a single service of a running instance, addressed by name
+func buildServiceCommand(result *Options, commandType CommandType, use, short string) *cobra.Command {
+	var (
+		serviceProfile string
+		timeout        time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   use + " <service>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			result.Type = commandType
+			result.Services = args
+			result.Profile = serviceProfile
+			result.Timeout = timeout
+		},
+	}
+
+	cmd.Flags().StringVar(&serviceProfile, "profile", "", "Filter by profile")
+	cmd.Flags().DurationVar(&timeout, "timeout", config.ServiceWaitTimeout, "How long to wait for the service to settle")
+
+	return cmd
+}
+
+// buildPsCommand creates the ps subcommand
+func buildPsCommand(result *Options) *cobra.Command {
+	var psProfile string
+
+	cmd := &cobra.Command{
+		Use:   "ps",
+		Short: "List the services of a running fuku instance",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			result.Type = CommandPs
+			result.Profile = psProfile
+		},
+	}
+
+	cmd.Flags().StringVar(&psProfile, "profile", "", "Filter by profile")
+	cmd.Flags().BoolVar(&result.JSON, "json", false, "Print the services as JSON")
+
+	return cmd
+}
+
+// buildExecCommand creates the exec subcommand. Flags are only parsed before the service,
+// so the command's own flags need no quoting and the "--" separator is optional
+func buildExecCommand(result *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec <service> -- <command> [args...]",
+		Short: "Run a command in a service's directory and environment",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			command := args[1:]
+			if len(command) > 0 && command[0] == "--" {
+				command = command[1:]
+			}
+
+			if len(command) == 0 {
+				return errors.ErrExecCommandRequired
+			}
+
+			result.Type = CommandExec
+			result.Services = args[:1]
+			result.Command = command
+
+			return nil
+		},
+	}
+
+	cmd.Flags().SetInterspersed(false)
+
+	return cmd
+}
+
 // buildVersionCommand creates the version subcommand
 func buildVersionCommand(result *Options) *cobra.Command {
 	cmd := &cobra.Command{
@@ -235,3 +473,46 @@ func buildVersionCommand(result *Options) *cobra.Command {
 
 	return cmd
 }
+
+// buildConfigCommand creates the config subcommand with its validate and print actions
+func buildConfigCommand(result *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Validate or print the resolved configuration",
+		Args:  cobra.NoArgs,
+	}
+
+	validateCmd := &cobra.Command{
+		Use:   ConfigValidate,
+		Short: "Validate the configuration and exit non-zero on problems",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			result.Type = CommandConfig
+			result.ConfigAction = ConfigValidate
+		},
+	}
+
+	printCmd := &cobra.Command{
+		Use:   ConfigPrint,
+		Short: "Print the fully merged configuration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if result.Format != FormatYAML && result.Format != FormatJSON {
+				return fmt.Errorf("%w: %s", errors.ErrUnsupportedFormat, result.Format)
+			}
+
+			result.Type = CommandConfig
+			result.ConfigAction = ConfigPrint
+
+			return nil
+		},
+	}
+
+	printCmd.Flags().StringVarP(&result.Format, "format", "f", FormatYAML, "Output format (yaml or json)")
+	printCmd.Flags().BoolVar(&result.Explain, "explain", false, "Annotate each value with the file and line it came from")
+	printCmd.Flags().BoolVar(&result.ShowSecrets, "show-secrets", false, "Print secrets such as the server auth token instead of redacting them")
+
+	cmd.AddCommand(validateCmd, printCmd)
+
+	return cmd
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -138,6 +139,91 @@ func Test_Parse(t *testing.T) {
 			expectedServices: []string{},
 			expectedNoUI:     false,
 		},
+		{
+			name:             "attach command",
+			args:             []string{"attach", "api"},
+			expectedType:     CommandAttach,
+			expectedProfile:  "",
+			expectedServices: []string{"api"},
+			expectedNoUI:     false,
+		},
+		{
+			name:             "attach command with --profile",
+			args:             []string{"attach", "--profile", "core", "api"},
+			expectedType:     CommandAttach,
+			expectedProfile:  "core",
+			expectedServices: []string{"api"},
+			expectedNoUI:     false,
+		},
+		{
+			name:            "up command runs in the foreground",
+			args:            []string{"up", "core"},
+			expectedType:    CommandRun,
+			expectedProfile: "core",
+		},
+		{
+			name:            "up command with --detach",
+			args:            []string{"up", "--detach", "core"},
+			expectedType:    CommandUp,
+			expectedProfile: "core",
+		},
+		{
+			name:            "up command with -d and default profile",
+			args:            []string{"up", "-d"},
+			expectedType:    CommandUp,
+			expectedProfile: config.Default,
+		},
+		{
+			name:            "down command finds the running instance",
+			args:            []string{"down"},
+			expectedType:    CommandDown,
+			expectedProfile: "",
+		},
+		{
+			name:            "down command with profile",
+			args:            []string{"down", "core"},
+			expectedType:    CommandDown,
+			expectedProfile: "core",
+		},
+		{
+			name:            "status command",
+			args:            []string{"status"},
+			expectedType:    CommandStatus,
+			expectedProfile: "",
+		},
+		{
+			name:            "ui command with profile",
+			args:            []string{"ui", "core"},
+			expectedType:    CommandUI,
+			expectedProfile: "core",
+		},
+		{
+			name:             "restart command",
+			args:             []string{"restart", "api"},
+			expectedType:     CommandRestart,
+			expectedProfile:  "",
+			expectedServices: []string{"api"},
+		},
+		{
+			name:             "start command with --profile",
+			args:             []string{"start", "--profile", "core", "web"},
+			expectedType:     CommandStart,
+			expectedProfile:  "core",
+			expectedServices: []string{"web"},
+		},
+		{
+			name:             "stop-service command",
+			args:             []string{"stop-service", "worker"},
+			expectedType:     CommandStopService,
+			expectedProfile:  "",
+			expectedServices: []string{"worker"},
+		},
+		{
+			name:            "ps command",
+			args:            []string{"ps"},
+			expectedType:    CommandPs,
+			expectedProfile: "",
+		},
 		{
 			name:            "stop command without profile",
 			args:            []string{"stop"},
@@ -335,6 +421,36 @@ func Test_CommandType_Standalone(t *testing.T) {
 			cmd:      CommandLogs,
 			expected: false,
 		},
+		{
+			name:     "attach is not standalone",
+			cmd:      CommandAttach,
+			expected: false,
+		},
+		{
+			name:     "up is not standalone",
+			cmd:      CommandUp,
+			expected: false,
+		},
+		{
+			name:     "status is not standalone",
+			cmd:      CommandStatus,
+			expected: false,
+		},
+		{
+			name:     "restart is not standalone",
+			cmd:      CommandRestart,
+			expected: false,
+		},
+		{
+			name:     "ps is not standalone",
+			cmd:      CommandPs,
+			expected: false,
+		},
+		{
+			name:     "config is not standalone",
+			cmd:      CommandConfig,
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -344,6 +460,75 @@ func Test_CommandType_Standalone(t *testing.T) {
 	}
 }
 
+func Test_Parse_Config(t *testing.T) {
+	tests := []struct {
+		name               string
+		args               []string
+		expectedAction     string
+		expectedFormat     string
+		expectedExplain    bool
+		expectedSecrets    bool
+		expectedConfigFile string
+	}{
+		{
+			name:           "config validate",
+			args:           []string{"config", "validate"},
+			expectedAction: ConfigValidate,
+			expectedFormat: FormatYAML,
+		},
+		{
+			name:           "config print defaults to yaml",
+			args:           []string{"config", "print"},
+			expectedAction: ConfigPrint,
+			expectedFormat: FormatYAML,
+		},
+		{
+			name:            "config print json with explain",
+			args:            []string{"config", "print", "--format", "json", "--explain"},
+			expectedAction:  ConfigPrint,
+			expectedFormat:  FormatJSON,
+			expectedExplain: true,
+		},
+		{
+			name:            "config print with secrets",
+			args:            []string{"config", "print", "--show-secrets"},
+			expectedAction:  ConfigPrint,
+			expectedFormat:  FormatYAML,
+			expectedSecrets: true,
+		},
+		{
+			name:               "config validate with --config",
+			args:               []string{"-c", "custom.yaml", "config", "validate"},
+			expectedAction:     ConfigValidate,
+			expectedFormat:     FormatYAML,
+			expectedConfigFile: "custom.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.args)
+			require.NoError(t, err)
+
+			assert.Equal(t, CommandConfig, result.Type)
+			assert.Equal(t, tt.expectedAction, result.ConfigAction)
+			assert.Equal(t, tt.expectedExplain, result.Explain)
+			assert.Equal(t, tt.expectedSecrets, result.ShowSecrets)
+			assert.Equal(t, tt.expectedConfigFile, result.ConfigFile)
+
+			if tt.expectedAction == ConfigPrint {
+				assert.Equal(t, tt.expectedFormat, result.Format)
+			}
+		})
+	}
+}
+
+func Test_Parse_ConfigPrintUnsupportedFormat(t *testing.T) {
+	result, err := Parse([]string{"config", "print", "--format", "toml"})
+	require.ErrorIs(t, err, errors.ErrUnsupportedFormat)
+	assert.Nil(t, result)
+}
+
 func Test_Parse_InvalidCommand(t *testing.T) {
 	result, err := Parse([]string{"unknown"})
 	require.Error(t, err)
@@ -362,6 +547,105 @@ func Test_Parse_StopWithTooManyArgs(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func Test_Parse_StopFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		profile string
+		dryRun  bool
+		yes     bool
+	}{
+		{name: "defaults", args: []string{"stop"}, profile: config.Default},
+		{name: "dry run", args: []string{"stop", "backend", "--dry-run"}, profile: "backend", dryRun: true},
+		{name: "yes short flag", args: []string{"stop", "-y"}, profile: config.Default, yes: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.args)
+			require.NoError(t, err)
+
+			assert.Equal(t, CommandStop, result.Type)
+			assert.Equal(t, tt.profile, result.Profile)
+			assert.Equal(t, tt.dryRun, result.DryRun)
+			assert.Equal(t, tt.yes, result.Yes)
+		})
+	}
+}
+
+func Test_Parse_AttachRequiresOneService(t *testing.T) {
+	for _, args := range [][]string{{"attach"}, {"attach", "api", "web"}} {
+		result, err := Parse(args)
+		require.Error(t, err)
+		assert.Nil(t, result)
+	}
+}
+
+func Test_Parse_ServiceCommands(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedTimeout time.Duration
+		expectedJSON    bool
+	}{
+		{name: "default timeout", args: []string{"restart", "api"}, expectedTimeout: config.ServiceWaitTimeout},
+		{name: "custom timeout", args: []string{"start", "api", "--timeout", "30s"}, expectedTimeout: 30 * time.Second},
+		{name: "ps as JSON", args: []string{"ps", "--json"}, expectedJSON: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTimeout, result.Timeout)
+			assert.Equal(t, tt.expectedJSON, result.JSON)
+		})
+	}
+}
+
+func Test_Parse_ServiceCommandsRequireOneService(t *testing.T) {
+	for _, args := range [][]string{{"start"}, {"stop-service", "api", "web"}, {"restart"}, {"ps", "api"}} {
+		result, err := Parse(args)
+		require.Error(t, err)
+		assert.Nil(t, result)
+	}
+}
+
+func Test_Parse_Exec(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedService string
+		expectedCommand []string
+	}{
+		{name: "with separator", args: []string{"exec", "api", "--", "make", "migrate"}, expectedService: "api", expectedCommand: []string{"make", "migrate"}},
+		{name: "without separator", args: []string{"exec", "api", "go", "test", "-v"}, expectedService: "api", expectedCommand: []string{"go", "test", "-v"}},
+		{name: "command flags after separator", args: []string{"exec", "web", "--", "npm", "run", "--silent", "lint"}, expectedService: "web", expectedCommand: []string{"npm", "run", "--silent", "lint"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, CommandExec, result.Type)
+			assert.Equal(t, []string{tt.expectedService}, result.Services)
+			assert.Equal(t, tt.expectedCommand, result.Command)
+		})
+	}
+}
+
+func Test_Parse_ExecRequiresCommand(t *testing.T) {
+	result, err := Parse([]string{"exec"})
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	for _, args := range [][]string{{"exec", "api"}, {"exec", "api", "--"}} {
+		result, err := Parse(args)
+		require.ErrorIs(t, err, errors.ErrExecCommandRequired)
+		assert.Nil(t, result)
+	}
+}
+
 func Test_Parse_ConfigFlagNotSupported(t *testing.T) {
 	tests := []struct {
 		name string
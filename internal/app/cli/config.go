@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+)
+
+// RunConfig executes a config subcommand and returns the exit code
+func RunConfig(cmd *Options, stdout, stderr io.Writer) int {
+	switch cmd.ConfigAction {
+	case ConfigValidate:
+		return validateConfig(cmd.ConfigFile, stdout, stderr)
+	case ConfigPrint:
+		return printConfig(cmd, stdout, stderr)
+	default:
+		fmt.Fprintln(stderr, Usage)
+		return 1
+	}
+}
+
+// validateConfig loads the config, runs static checks and lint, and reports every problem found
+func validateConfig(configFile string, stdout, stderr io.Writer) int {
+	cfg, err := loadConfig(configFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	for _, warning := range cfg.Warnings() {
+		fmt.Fprintf(stderr, "Warning: %s\n", warning)
+	}
+
+	var problems []string
+
+	if len(cfg.Services) == 0 {
+		problems = append(problems, errors.ErrNoServicesDefined.Error())
+	}
+
+	for _, err := range cfg.Check() {
+		problems = append(problems, err.Error())
+	}
+
+	problems = append(problems, cfg.Lint()...)
+
+	for _, problem := range problems {
+		fmt.Fprintf(stderr, "Error: %s\n", problem)
+	}
+
+	if len(problems) > 0 {
+		return 1
+	}
+
+	fmt.Fprintln(stdout, "Configuration is valid")
+
+	return 0
+}
+
+// printConfig writes the fully resolved config in the requested format, with secrets redacted unless asked for
+func printConfig(cmd *Options, stdout, stderr io.Writer) int {
+	resolved, err := config.Resolve(cmd.ConfigFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if !cmd.ShowSecrets {
+		resolved.RedactSecrets()
+	}
+
+	var out []byte
+
+	switch cmd.Format {
+	case FormatJSON:
+		out, err = resolved.JSON(cmd.Explain)
+		if err == nil {
+			out = append(out, '\n')
+		}
+	default:
+		out, err = resolved.YAML(cmd.Explain)
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if _, err := stdout.Write(out); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// loadConfig loads an explicit config file without overrides, or the default config with overrides
+func loadConfig(configFile string) (*config.Config, error) {
+	if configFile != "" {
+		cfg, _, err := config.LoadFromFile(configFile)
+		return cfg, err
+	}
+
+	cfg, _, err := config.Load()
+
+	return cfg, err
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/config"
+)
+
+func Test_RunConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expectedCode   int
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "valid config",
+			content:        "version: 1\nservices:\n  api:\n    dir: .\n",
+			expectedCode:   0,
+			expectedStdout: "Configuration is valid",
+		},
+		{
+			name:           "invalid config",
+			content:        "version: 1\nservices:\n  api:\n    dir: .\nconcurrency:\n  workers: 0\n",
+			expectedCode:   1,
+			expectedStderr: "concurrency workers must be greater than 0",
+		},
+		{
+			name:           "no services",
+			content:        "version: 1\n",
+			expectedCode:   1,
+			expectedStderr: "no services defined",
+		},
+		{
+			name:           "unsupported profile format",
+			content:        "version: 1\nservices:\n  api:\n    dir: .\nprofiles:\n  broken:\n    nested: true\n",
+			expectedCode:   1,
+			expectedStderr: "unsupported profile format",
+		},
+		{
+			name:           "lint findings fail validation",
+			content:        "version: 1\nservices:\n  api:\n    dir: ./missing\n",
+			expectedCode:   1,
+			expectedStderr: "Error: service api: dir './missing' does not exist",
+		},
+		{
+			name:           "warnings do not fail validation",
+			content:        "version: 1\nservices:\n  api:\n    dir: .\n    profiles: [backend]\n  db:\n    dir: .\nprofiles:\n  backend: [db]\n",
+			expectedCode:   0,
+			expectedStdout: "Configuration is valid",
+			expectedStderr: "Warning: profile 'backend'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			require.NoError(t, os.WriteFile(config.ConfigFile, []byte(tt.content), 0644))
+
+			var stdout, stderr bytes.Buffer
+
+			code := RunConfig(&Options{Type: CommandConfig, ConfigAction: ConfigValidate}, &stdout, &stderr)
+
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Contains(t, stdout.String(), tt.expectedStdout)
+			assert.Contains(t, stderr.String(), tt.expectedStderr)
+		})
+	}
+}
+
+func Test_RunConfig_Print_Secrets(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(config.ConfigFile, []byte("version: 1\nserver:\n  auth:\n    token: s3cr3t\nservices:\n  api:\n    dir: .\n"), 0644))
+
+	tests := []struct {
+		name        string
+		format      string
+		showSecrets bool
+		expected    string
+		unexpected  string
+	}{
+		{name: "yaml redacted", format: FormatYAML, expected: "token: " + config.RedactedValue, unexpected: "s3cr3t"},
+		{name: "json redacted", format: FormatJSON, expected: `"token": "` + config.RedactedValue + `"`, unexpected: "s3cr3t"},
+		{name: "shown on request", format: FormatYAML, showSecrets: true, expected: "token: s3cr3t", unexpected: config.RedactedValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := RunConfig(&Options{ConfigAction: ConfigPrint, Format: tt.format, ShowSecrets: tt.showSecrets}, &stdout, &stderr)
+			require.Equal(t, 0, code, stderr.String())
+
+			assert.Contains(t, stdout.String(), tt.expected)
+			assert.NotContains(t, stdout.String(), tt.unexpected)
+		})
+	}
+}
+
+func Test_RunConfig_Print(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(config.ConfigFile, []byte("version: 1\nservices:\n  api:\n    dir: .\n    command: make run\n"), 0644))
+	require.NoError(t, os.WriteFile(config.OverrideConfigFile, []byte("services:\n  api:\n    command: make debug\n"), 0644))
+
+	t.Run("yaml with explain", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := RunConfig(&Options{ConfigAction: ConfigPrint, Format: FormatYAML, Explain: true}, &stdout, &stderr)
+		require.Equal(t, 0, code, stderr.String())
+
+		assert.Contains(t, stdout.String(), "command: make debug # fuku.override.yaml:3")
+		assert.Contains(t, stdout.String(), "workers: 5 # default")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := RunConfig(&Options{ConfigAction: ConfigPrint, Format: FormatJSON}, &stdout, &stderr)
+		require.Equal(t, 0, code, stderr.String())
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+		assert.Contains(t, out, "services")
+	})
+
+	t.Run("explicit config skips override", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := RunConfig(&Options{ConfigFile: config.ConfigFile, ConfigAction: ConfigPrint, Format: FormatYAML}, &stdout, &stderr)
+		require.Equal(t, 0, code, stderr.String())
+
+		assert.Contains(t, stdout.String(), "command: make run")
+	})
+
+	t.Run("load error", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := RunConfig(&Options{ConfigFile: "missing.yaml", ConfigAction: ConfigPrint, Format: FormatYAML}, &stdout, &stderr)
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr.String(), "Error:")
+	})
+}
@@ -1,12 +1,23 @@
 package cli
 
 import (
+	"bufio"
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/charmbracelet/x/term"
 	"go.uber.org/fx"
 
+	"fuku/internal/app/attach"
 	"fuku/internal/app/bus"
+	"fuku/internal/app/daemon"
+	"fuku/internal/app/errors"
 	"fuku/internal/app/logs"
+	"fuku/internal/app/preflight"
 	"fuku/internal/app/runner"
 	"fuku/internal/app/ui/wire"
 	"fuku/internal/app/watcher"
@@ -27,31 +38,45 @@ type TUIParams struct {
 	Runner   runner.Runner
 	Watcher  watcher.Watcher
 	Streamer logs.Screen
+	Attacher attach.Screen
+	Daemon   daemon.Daemon
 	UI       wire.UI
 	Logger   logger.Logger
 }
 
 // tui represents the terminal UI for the application
 type tui struct {
-	cmd      *Options
-	bus      bus.Bus
-	runner   runner.Runner
-	watcher  watcher.Watcher
-	streamer logs.Screen
-	ui       wire.UI
-	log      logger.Logger
+	cmd         *Options
+	bus         bus.Bus
+	runner      runner.Runner
+	watcher     watcher.Watcher
+	streamer    logs.Screen
+	attacher    attach.Screen
+	daemon      daemon.Daemon
+	ui          wire.UI
+	stdin       io.Reader
+	stdout      io.Writer
+	stderr      io.Writer
+	interactive bool
+	log         logger.Logger
 }
 
 // NewTUI creates a new TUI instance
 func NewTUI(p TUIParams) TUI {
 	return &tui{
-		cmd:      p.Cmd,
-		bus:      p.Bus,
-		runner:   p.Runner,
-		watcher:  p.Watcher,
-		streamer: p.Streamer,
-		ui:       p.UI,
-		log:      p.Logger.WithComponent("TUI"),
+		cmd:         p.Cmd,
+		bus:         p.Bus,
+		runner:      p.Runner,
+		watcher:     p.Watcher,
+		streamer:    p.Streamer,
+		attacher:    p.Attacher,
+		daemon:      p.Daemon,
+		ui:          p.UI,
+		stdin:       os.Stdin,
+		stdout:      os.Stdout,
+		stderr:      os.Stderr,
+		interactive: term.IsTerminal(os.Stdin.Fd()),
+		log:         p.Logger.WithComponent("TUI"),
 	}
 }
 
@@ -71,6 +96,26 @@ func (t *tui) Execute(ctx context.Context) (int, error) {
 		return t.handleStop(ctx, t.cmd.Profile)
 	case CommandLogs:
 		return t.handleLogs(ctx)
+	case CommandAttach:
+		return t.handleAttach(ctx)
+	case CommandUp:
+		return t.daemon.Up(ctx, t.cmd.Profile, t.cmd.ConfigFile), nil
+	case CommandDown:
+		return t.daemon.Down(ctx, t.cmd.Profile), nil
+	case CommandStatus:
+		return t.daemon.Status(ctx, t.cmd.Profile), nil
+	case CommandUI:
+		return t.daemon.UI(ctx, t.cmd.Profile), nil
+	case CommandStart:
+		return t.daemon.Start(ctx, t.cmd.Profile, t.cmd.Services[0], t.cmd.Timeout), nil
+	case CommandStopService:
+		return t.daemon.StopService(ctx, t.cmd.Profile, t.cmd.Services[0], t.cmd.Timeout), nil
+	case CommandRestart:
+		return t.daemon.Restart(ctx, t.cmd.Profile, t.cmd.Services[0], t.cmd.Timeout), nil
+	case CommandPs:
+		return t.daemon.Ps(ctx, t.cmd.Profile, t.cmd.JSON), nil
+	case CommandExec:
+		return t.handleExec(ctx)
 	default:
 		return t.handleRun(ctx, t.cmd.Profile)
 	}
@@ -95,11 +140,11 @@ func (t *tui) handleRun(ctx context.Context, profile string) (int, error) {
 	return 0, nil
 }
 
-// handleStop kills processes in service directories for the given profile
+// handleStop kills the processes started for the given profile's services
 func (t *tui) handleStop(ctx context.Context, profile string) (int, error) {
 	t.log.Debug().Msgf("Stopping services for profile: %s", profile)
 
-	if err := t.runner.Stop(ctx, profile); err != nil {
+	if err := t.runner.Stop(ctx, profile, t.confirmStop); err != nil {
 		t.log.Error().Err(err).Msgf("Failed to stop profile '%s'", profile)
 
 		return 1, err
@@ -108,6 +153,51 @@ func (t *tui) handleStop(ctx context.Context, profile string) (int, error) {
 	return 0, nil
 }
 
+// confirmStop lists the processes a stop found and decides whether to kill them. A dry run only lists them,
+// --yes kills them, input that is not a terminal fails the stop since nobody can confirm, and otherwise the user is asked
+func (t *tui) confirmStop(targets []preflight.Result) (bool, error) {
+	if len(targets) == 0 {
+		fmt.Fprintln(t.stdout, "No running processes found")
+		return false, nil
+	}
+
+	printTargets(t.stdout, targets)
+
+	switch {
+	case t.cmd.DryRun:
+		return false, nil
+	case t.cmd.Yes:
+		return true, nil
+	case !t.interactive:
+		return false, errors.ErrStopNotConfirmed
+	}
+
+	fmt.Fprintf(t.stdout, "Kill %d process(es)? [y/N] ", len(targets))
+
+	answer, _ := bufio.NewReader(t.stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes", nil
+}
+
+// printTargets writes one line per process with the service it belongs to and how it was matched
+func printTargets(out io.Writer, targets []preflight.Result) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "SERVICE\tPID\tNAME\tMATCHED BY")
+
+	for _, target := range targets {
+		match := target.Match
+		if match == preflight.MatchCwd {
+			match += " " + target.Dir
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", target.Service, target.PID, target.Name, match)
+	}
+
+	w.Flush()
+}
+
 // runWithUI runs the TUI alongside the runner
 func (t *tui) runWithUI(ctx context.Context, profile string) (int, error) {
 	ctx, cancel := context.WithCancel(ctx)
@@ -148,3 +238,22 @@ func (t *tui) runWithUI(ctx context.Context, profile string) (int, error) {
 func (t *tui) handleLogs(ctx context.Context) (int, error) {
 	return t.streamer.Run(ctx, t.cmd.Profile, t.cmd.Services), nil
 }
+
+// handleExec runs a command in the context of a service and returns the command's exit code
+func (t *tui) handleExec(ctx context.Context) (int, error) {
+	service := t.cmd.Services[0]
+
+	exitCode, err := t.runner.Exec(ctx, service, t.cmd.Command)
+	if err != nil {
+		fmt.Fprintf(t.stderr, "Error: %v\n", err)
+
+		return exitCode, err
+	}
+
+	return exitCode, nil
+}
+
+// handleAttach connects the terminal to a service of a running fuku instance
+func (t *tui) handleAttach(ctx context.Context) (int, error) {
+	return t.attacher.Run(ctx, t.cmd.Profile, t.cmd.Services[0]), nil
+}
@@ -5,16 +5,21 @@ import (
 	"context"
 	"io"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"fuku/internal/app/attach"
 	"fuku/internal/app/bus"
+	"fuku/internal/app/daemon"
 	"fuku/internal/app/errors"
 	"fuku/internal/app/logs"
+	"fuku/internal/app/preflight"
 	"fuku/internal/app/runner"
 	"fuku/internal/app/ui/wire"
 	"fuku/internal/app/watcher"
@@ -103,7 +108,7 @@ func Test_Execute(t *testing.T) {
 			},
 			before: func(ctx context.Context) {
 				mockLogger.EXPECT().Debug().Return(nil)
-				mockRunner.EXPECT().Stop(ctx, config.Default).Return(nil)
+				mockRunner.EXPECT().Stop(ctx, config.Default, gomock.Any()).Return(nil)
 			},
 			expectedExit:  0,
 			expectedError: false,
@@ -239,6 +244,160 @@ func Test_Execute_LogsMode(t *testing.T) {
 	}
 }
 
+func Test_Execute_AttachMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachScreen := attach.NewMockScreen(ctrl)
+
+	tu := &tui{
+		cmd:      &Options{Type: CommandAttach, Profile: "core", Services: []string{"api"}},
+		bus:      bus.NoOp(),
+		attacher: mockAttachScreen,
+		log:      logger.NewMockLogger(ctrl),
+	}
+
+	ctx := t.Context()
+	mockAttachScreen.EXPECT().Run(ctx, "core", "api").Return(1)
+
+	exitCode, err := tu.Execute(ctx)
+
+	assert.Equal(t, 1, exitCode)
+	require.NoError(t, err)
+}
+
+func Test_Execute_DaemonCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      *Options
+		expect   func(ctx context.Context, d *daemon.MockDaemon)
+		exitCode int
+	}{
+		{
+			name: "up starts a detached instance",
+			cmd:  &Options{Type: CommandUp, Profile: "core", ConfigFile: "custom.yaml"},
+			expect: func(ctx context.Context, d *daemon.MockDaemon) {
+				d.EXPECT().Up(ctx, "core", "custom.yaml").Return(0)
+			},
+		},
+		{
+			name: "down stops the running instance",
+			cmd:  &Options{Type: CommandDown},
+			expect: func(ctx context.Context, d *daemon.MockDaemon) {
+				d.EXPECT().Down(ctx, "").Return(1)
+			},
+			exitCode: 1,
+		},
+		{
+			name: "status prints the running instance",
+			cmd:  &Options{Type: CommandStatus, Profile: "core"},
+			expect: func(ctx context.Context, d *daemon.MockDaemon) {
+				d.EXPECT().Status(ctx, "core").Return(0)
+			},
+		},
+		{
+			name: "ui attaches to the running instance",
+			cmd:  &Options{Type: CommandUI},
+			expect: func(ctx context.Context, d *daemon.MockDaemon) {
+				d.EXPECT().UI(ctx, "").Return(0)
+			},
+		},
+		{
+			name: "restart waits for the service",
+			cmd:  &Options{Type: CommandRestart, Services: []string{"api"}, Timeout: time.Minute},
+			expect: func(ctx context.Context, d *daemon.MockDaemon) {
+				d.EXPECT().Restart(ctx, "", "api", time.Minute).Return(2)
+			},
+			exitCode: 2,
+		},
+		{
+			name: "start waits for the service",
+			cmd:  &Options{Type: CommandStart, Profile: "core", Services: []string{"web"}, Timeout: time.Minute},
+			expect: func(ctx context.Context, d *daemon.MockDaemon) {
+				d.EXPECT().Start(ctx, "core", "web", time.Minute).Return(0)
+			},
+		},
+		{
+			name: "stop-service waits for the service",
+			cmd:  &Options{Type: CommandStopService, Services: []string{"worker"}, Timeout: time.Minute},
+			expect: func(ctx context.Context, d *daemon.MockDaemon) {
+				d.EXPECT().StopService(ctx, "", "worker", time.Minute).Return(0)
+			},
+		},
+		{
+			name: "ps lists the services",
+			cmd:  &Options{Type: CommandPs, JSON: true},
+			expect: func(ctx context.Context, d *daemon.MockDaemon) {
+				d.EXPECT().Ps(ctx, "", true).Return(0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDaemon := daemon.NewMockDaemon(ctrl)
+
+			tu := &tui{
+				cmd:    tt.cmd,
+				bus:    bus.NoOp(),
+				daemon: mockDaemon,
+				log:    logger.NewMockLogger(ctrl),
+			}
+
+			ctx := t.Context()
+			tt.expect(ctx, mockDaemon)
+
+			exitCode, err := tu.Execute(ctx)
+
+			assert.Equal(t, tt.exitCode, exitCode)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_Execute_Exec(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		exitCode int
+		stderr   string
+	}{
+		{name: "passes the exit code through", exitCode: 3},
+		{name: "reports a failure to run", err: errors.ErrServiceNotFound, exitCode: 1, stderr: "Error: service not found\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := runner.NewMockRunner(ctrl)
+
+			var stderr bytes.Buffer
+
+			tu := &tui{
+				cmd:    &Options{Type: CommandExec, Services: []string{"api"}, Command: []string{"make", "migrate"}},
+				bus:    bus.NoOp(),
+				runner: mockRunner,
+				stderr: &stderr,
+				log:    logger.NewMockLogger(ctrl),
+			}
+
+			ctx := t.Context()
+			mockRunner.EXPECT().Exec(ctx, "api", []string{"make", "migrate"}).Return(tt.exitCode, tt.err)
+
+			exitCode, err := tu.Execute(ctx)
+
+			assert.Equal(t, tt.exitCode, exitCode)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.stderr, stderr.String())
+		})
+	}
+}
+
 func Test_handleRun(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -451,7 +610,7 @@ func Test_handleStop(t *testing.T) {
 			profile: "test-profile",
 			before: func(ctx context.Context) {
 				mockLogger.EXPECT().Debug().Return(nil)
-				mockRunner.EXPECT().Stop(ctx, "test-profile").Return(nil)
+				mockRunner.EXPECT().Stop(ctx, "test-profile", gomock.Any()).Return(nil)
 			},
 			expectedExit:  0,
 			expectedError: false,
@@ -461,7 +620,7 @@ func Test_handleStop(t *testing.T) {
 			profile: "failed-profile",
 			before: func(ctx context.Context) {
 				mockLogger.EXPECT().Debug().Return(nil)
-				mockRunner.EXPECT().Stop(ctx, "failed-profile").Return(errors.New("stop failed"))
+				mockRunner.EXPECT().Stop(ctx, "failed-profile", gomock.Any()).Return(errors.New("stop failed"))
 				mockLogger.EXPECT().Error().Return(nil)
 			},
 			expectedExit:  1,
@@ -491,3 +650,50 @@ func Test_handleStop(t *testing.T) {
 		})
 	}
 }
+
+func Test_ConfirmStop(t *testing.T) {
+	targets := []preflight.Result{
+		{Service: "api", PID: 100, Name: "node", Match: preflight.MatchTag},
+		{Service: "web", PID: 200, Name: "vite", Dir: "/project/web", Match: preflight.MatchCwd},
+	}
+
+	tests := []struct {
+		name        string
+		cmd         *Options
+		targets     []preflight.Result
+		interactive bool
+		input       string
+		expected    bool
+		err         error
+		output      []string
+	}{
+		{name: "nothing found", cmd: &Options{}, output: []string{"No running processes found"}},
+		{name: "dry run only lists", cmd: &Options{DryRun: true, Yes: true}, targets: targets, interactive: true, output: []string{"api", "100", "node", "tag", "cwd /project/web"}},
+		{name: "yes skips the prompt", cmd: &Options{Yes: true}, targets: targets, interactive: true, expected: true},
+		{name: "non-interactive input fails the stop", cmd: &Options{}, targets: targets, input: "y\n", err: errors.ErrStopNotConfirmed, output: []string{"api", "100"}},
+		{name: "confirmed", cmd: &Options{}, targets: targets, interactive: true, input: "y\n", expected: true, output: []string{"Kill 2 process(es)? [y/N]"}},
+		{name: "declined by default", cmd: &Options{}, targets: targets, interactive: true, input: "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			tu := &tui{
+				cmd:         tt.cmd,
+				stdin:       strings.NewReader(tt.input),
+				stdout:      &out,
+				interactive: tt.interactive,
+			}
+
+			confirmed, err := tu.confirmStop(tt.targets)
+
+			assert.Equal(t, tt.expected, confirmed)
+			assert.ErrorIs(t, err, tt.err)
+
+			for _, text := range tt.output {
+				assert.Contains(t, out.String(), text)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/app/registry"
+	"fuku/internal/app/relay"
+	"fuku/internal/config"
+)
+
+// exitTimeout is the exit code of a service command whose service did not settle in time
+const exitTimeout = 2
+
+// serviceAction describes a start, stop or restart of a service on a running instance
+type serviceAction struct {
+	verb     string
+	progress string
+	request  func(relay.Client, string) (registry.ServiceSnapshot, error)
+	want     []registry.Status
+}
+
+var (
+	actionStart = serviceAction{
+		verb:     "start",
+		progress: "Starting",
+		request:  relay.Client.Start,
+		want:     []registry.Status{registry.StatusRunning, registry.StatusCompleted},
+	}
+	actionStop = serviceAction{
+		verb:     "stop",
+		progress: "Stopping",
+		request:  relay.Client.Stop,
+		want:     []registry.Status{registry.StatusStopped},
+	}
+	actionRestart = serviceAction{
+		verb:     "restart",
+		progress: "Restarting",
+		request:  relay.Client.Restart,
+		want:     []registry.Status{registry.StatusRunning, registry.StatusCompleted},
+	}
+)
+
+// Start starts a service of a running instance and waits until it is running
+func (d *daemon) Start(ctx context.Context, profile, service string, timeout time.Duration) int {
+	return d.act(ctx, profile, service, timeout, actionStart)
+}
+
+// StopService stops a service of a running instance and waits until it has stopped
+func (d *daemon) StopService(ctx context.Context, profile, service string, timeout time.Duration) int {
+	return d.act(ctx, profile, service, timeout, actionStop)
+}
+
+// Restart restarts a service of a running instance and waits until it is running again
+func (d *daemon) Restart(ctx context.Context, profile, service string, timeout time.Duration) int {
+	return d.act(ctx, profile, service, timeout, actionRestart)
+}
+
+// Ps prints the services of a running instance, as JSON when asked
+func (d *daemon) Ps(_ context.Context, profile string, asJSON bool) int {
+	if err := d.connect(profile); err != nil {
+		d.log.Error().Err(err).Msg("Failed to connect to fuku")
+
+		return 1
+	}
+
+	defer d.client.Close()
+
+	services, err := d.client.List()
+	if err != nil {
+		d.log.Error().Err(err).Msg("Failed to list services")
+
+		return 1
+	}
+
+	if !asJSON {
+		printServices(d.out, services, time.Now())
+
+		return 0
+	}
+
+	if services == nil {
+		services = []registry.ServiceSnapshot{}
+	}
+
+	encoder := json.NewEncoder(d.out)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(services); err != nil {
+		d.log.Error().Err(err).Msg("Failed to write services")
+
+		return 1
+	}
+
+	return 0
+}
+
+// act sends a service action to the running instance and waits for the service to settle.
+// It returns 0 once the service reached the state the action aims for, 1 when the action
+// is refused or the service settles elsewhere, and exitTimeout when it does not settle in time
+func (d *daemon) act(ctx context.Context, profile, service string, timeout time.Duration, action serviceAction) int {
+	if err := d.connect(profile); err != nil {
+		d.log.Error().Err(err).Msg("Failed to connect to fuku")
+
+		return 1
+	}
+
+	defer d.client.Close()
+
+	accepted, err := action.request(d.client, service)
+	if err != nil {
+		d.log.Error().Err(err).Msgf("Failed to %s service '%s'", action.verb, service)
+
+		return 1
+	}
+
+	fmt.Fprintf(d.out, "%s service '%s'...\n", action.progress, accepted.Name)
+
+	svc, err := d.waitSettled(ctx, accepted, timeout)
+	if err != nil {
+		d.log.Error().Err(err).Msgf("Failed to %s service '%s'", action.verb, accepted.Name)
+
+		if errors.Is(err, errors.ErrServiceTimeout) {
+			return exitTimeout
+		}
+
+		return 1
+	}
+
+	if !slices.Contains(action.want, svc.Status) {
+		d.log.Error().Msgf("Failed to %s service '%s': %s %s", action.verb, svc.Name, svc.Status, svc.Error)
+
+		return 1
+	}
+
+	if svc.Status == registry.StatusRunning {
+		fmt.Fprintf(d.out, "Service '%s' is running (PID: %d)\n", svc.Name, svc.PID)
+	} else {
+		fmt.Fprintf(d.out, "Service '%s' is %s\n", svc.Name, svc.Status)
+	}
+
+	return 0
+}
+
+// waitSettled polls the instance until the service has moved past the accepted state and settled
+func (d *daemon) waitSettled(ctx context.Context, accepted registry.ServiceSnapshot, timeout time.Duration) (registry.ServiceSnapshot, error) {
+	ticker := time.NewTicker(config.DaemonPollInterval)
+	defer ticker.Stop()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return registry.ServiceSnapshot{}, ctx.Err()
+		case <-deadline.C:
+			return registry.ServiceSnapshot{}, fmt.Errorf("%w after %s", errors.ErrServiceTimeout, timeout)
+		case <-ticker.C:
+		}
+
+		services, err := d.client.List()
+		if err != nil {
+			return registry.ServiceSnapshot{}, err
+		}
+
+		i := slices.IndexFunc(services, func(svc registry.ServiceSnapshot) bool { return svc.ID == accepted.ID })
+		if i < 0 {
+			return registry.ServiceSnapshot{}, errors.ErrServiceNotFound
+		}
+
+		if svc := services[i]; svc.LifecycleSeq > accepted.LifecycleSeq && settled(svc.Status) {
+			return svc, nil
+		}
+	}
+}
+
+// settled reports whether a service is in a state it stays in until the next action or exit
+func settled(status registry.Status) bool {
+	//nolint:exhaustive // every other status is settled
+	switch status {
+	case registry.StatusStarting, registry.StatusStopping, registry.StatusRestarting:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,217 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/app/registry"
+	"fuku/internal/app/relay"
+)
+
+func Test_Act(t *testing.T) {
+	running := registry.ServiceSnapshot{ID: "id-api", Name: "api", Status: registry.StatusRunning, PID: 100, LifecycleSeq: 4}
+	restarting := running
+	restarting.Status = registry.StatusRestarting
+
+	tests := []struct {
+		name     string
+		action   serviceAction
+		service  string
+		expect   func(c *relay.MockClient)
+		exitCode int
+		output   string
+	}{
+		{
+			name:    "restart waits for the new process",
+			action:  actionRestart,
+			service: "api",
+			expect: func(c *relay.MockClient) {
+				gomock.InOrder(
+					c.EXPECT().Restart("api").Return(restarting, nil),
+					c.EXPECT().List().Return([]registry.ServiceSnapshot{running}, nil),
+					c.EXPECT().List().Return([]registry.ServiceSnapshot{{ID: "id-api", Name: "api", Status: registry.StatusRestarting, LifecycleSeq: 5}}, nil),
+					c.EXPECT().List().Return([]registry.ServiceSnapshot{{ID: "id-api", Name: "api", Status: registry.StatusRunning, PID: 200, LifecycleSeq: 7}}, nil),
+				)
+			},
+			output: "Restarting service 'api'...\nService 'api' is running (PID: 200)\n",
+		},
+		{
+			name:    "stop waits until stopped",
+			action:  actionStop,
+			service: "api",
+			expect: func(c *relay.MockClient) {
+				gomock.InOrder(
+					c.EXPECT().Stop("api").Return(registry.ServiceSnapshot{ID: "id-api", Name: "api", Status: registry.StatusStopping, LifecycleSeq: 4}, nil),
+					c.EXPECT().List().Return([]registry.ServiceSnapshot{{ID: "id-api", Name: "api", Status: registry.StatusStopped, LifecycleSeq: 6}}, nil),
+				)
+			},
+			output: "Stopping service 'api'...\nService 'api' is stopped\n",
+		},
+		{
+			name:    "start of a job completes",
+			action:  actionStart,
+			service: "migrate",
+			expect: func(c *relay.MockClient) {
+				gomock.InOrder(
+					c.EXPECT().Start("migrate").Return(registry.ServiceSnapshot{ID: "id-migrate", Name: "migrate", Status: registry.StatusStarting, LifecycleSeq: 2}, nil),
+					c.EXPECT().List().Return([]registry.ServiceSnapshot{{ID: "id-migrate", Name: "migrate", Status: registry.StatusCompleted, LifecycleSeq: 4}}, nil),
+				)
+			},
+			output: "Starting service 'migrate'...\nService 'migrate' is completed\n",
+		},
+		{
+			name:    "start that fails",
+			action:  actionStart,
+			service: "api",
+			expect: func(c *relay.MockClient) {
+				gomock.InOrder(
+					c.EXPECT().Start("api").Return(registry.ServiceSnapshot{ID: "id-api", Name: "api", Status: registry.StatusStarting, LifecycleSeq: 2}, nil),
+					c.EXPECT().List().Return([]registry.ServiceSnapshot{{ID: "id-api", Name: "api", Status: registry.StatusFailed, Error: "exit status 1", LifecycleSeq: 4}}, nil),
+				)
+			},
+			exitCode: 1,
+			output:   "Starting service 'api'...\n",
+		},
+		{
+			name:    "refused request",
+			action:  actionRestart,
+			service: "api",
+			expect: func(c *relay.MockClient) {
+				c.EXPECT().Restart("api").Return(registry.ServiceSnapshot{}, errors.ErrRequestFailed)
+			},
+			exitCode: 1,
+		},
+		{
+			name:    "lost connection",
+			action:  actionStop,
+			service: "api",
+			expect: func(c *relay.MockClient) {
+				gomock.InOrder(
+					c.EXPECT().Stop("api").Return(registry.ServiceSnapshot{ID: "id-api", Name: "api", Status: registry.StatusStopping}, nil),
+					c.EXPECT().List().Return(nil, errors.ErrFailedToReadSocket),
+				)
+			},
+			exitCode: 1,
+			output:   "Stopping service 'api'...\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := relay.NewMockClient(ctrl)
+			d, out := newTestDaemon(t, ctrl, mockClient)
+
+			socketPath := relay.SocketPathForProfile(d.socketDir, "core")
+			listen(t, socketPath)
+
+			mockClient.EXPECT().Connect(socketPath).Return(nil)
+			mockClient.EXPECT().Close().Return(nil)
+			tt.expect(mockClient)
+
+			assert.Equal(t, tt.exitCode, d.act(context.Background(), "", tt.service, time.Minute, tt.action))
+			assert.Equal(t, tt.output, out.String())
+		})
+	}
+}
+
+func Test_Act_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := relay.NewMockClient(ctrl)
+	d, _ := newTestDaemon(t, ctrl, mockClient)
+
+	socketPath := relay.SocketPathForProfile(d.socketDir, "core")
+	listen(t, socketPath)
+
+	starting := registry.ServiceSnapshot{ID: "id-api", Name: "api", Status: registry.StatusStarting, LifecycleSeq: 2}
+
+	mockClient.EXPECT().Connect(socketPath).Return(nil)
+	mockClient.EXPECT().Start("api").Return(starting, nil)
+	mockClient.EXPECT().List().Return([]registry.ServiceSnapshot{starting}, nil).AnyTimes()
+	mockClient.EXPECT().Close().Return(nil)
+
+	assert.Equal(t, exitTimeout, d.Start(context.Background(), "core", "api", 250*time.Millisecond))
+}
+
+func Test_Act_NoInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, out := newTestDaemon(t, ctrl, relay.NewMockClient(ctrl))
+
+	assert.Equal(t, 1, d.Restart(context.Background(), "", "api", time.Minute))
+	assert.Empty(t, out.String())
+}
+
+func Test_Ps(t *testing.T) {
+	services := []registry.ServiceSnapshot{
+		{ID: "id-api", Name: "api", Tier: "platform", Status: registry.StatusRunning, PID: 100, StartTime: time.Now(), LifecycleSeq: 7},
+		{ID: "id-web", Name: "web", Tier: "edge", Status: registry.StatusStopped},
+	}
+
+	tests := []struct {
+		name     string
+		asJSON   bool
+		services []registry.ServiceSnapshot
+		contains []string
+		excludes []string
+	}{
+		{name: "table", services: services, contains: []string{"SERVICE  TIER", "api      platform  running  100", "web      edge      stopped  -"}},
+		{
+			name:     "JSON",
+			asJSON:   true,
+			services: services,
+			contains: []string{`"name": "api"`, `"status": "stopped"`},
+			excludes: []string{"lifecycle_seq", "lifecycle_at", "watch_seq", "watch_at", "attempt_started_at"},
+		},
+		{name: "empty JSON", asJSON: true, contains: []string{"[]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := relay.NewMockClient(ctrl)
+			d, out := newTestDaemon(t, ctrl, mockClient)
+
+			socketPath := relay.SocketPathForProfile(d.socketDir, "core")
+			listen(t, socketPath)
+
+			gomock.InOrder(
+				mockClient.EXPECT().Connect(socketPath).Return(nil),
+				mockClient.EXPECT().List().Return(tt.services, nil),
+				mockClient.EXPECT().Close().Return(nil),
+			)
+
+			assert.Equal(t, 0, d.Ps(context.Background(), "core", tt.asJSON))
+
+			for _, s := range tt.contains {
+				assert.Contains(t, out.String(), s)
+			}
+
+			for _, s := range tt.excludes {
+				assert.NotContains(t, out.String(), s)
+			}
+		})
+	}
+}
+
+func Test_Settled(t *testing.T) {
+	for _, status := range []registry.Status{registry.StatusStarting, registry.StatusStopping, registry.StatusRestarting} {
+		assert.False(t, settled(status), status)
+	}
+
+	for _, status := range []registry.Status{registry.StatusRunning, registry.StatusStopped, registry.StatusFailed, registry.StatusCrashLoop, registry.StatusCompleted, registry.StatusBuildFailed} {
+		assert.True(t, settled(status), status)
+	}
+}
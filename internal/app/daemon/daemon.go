@@ -0,0 +1,361 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/fx"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/monitor"
+	"fuku/internal/app/registry"
+	"fuku/internal/app/relay"
+	"fuku/internal/app/session"
+	"fuku/internal/app/ui/services"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+// Daemon runs fuku in the background and handles the commands that manage a background instance
+type Daemon interface {
+	Up(ctx context.Context, profile, configFile string) int
+	Down(ctx context.Context, profile string) int
+	Status(ctx context.Context, profile string) int
+	UI(ctx context.Context, profile string) int
+	Start(ctx context.Context, profile, service string, timeout time.Duration) int
+	StopService(ctx context.Context, profile, service string, timeout time.Duration) int
+	Restart(ctx context.Context, profile, service string, timeout time.Duration) int
+	Ps(ctx context.Context, profile string, asJSON bool) int
+}
+
+// Params contains dependencies for creating a Daemon
+type Params struct {
+	fx.In
+
+	Config  *config.Config
+	Client  relay.Client
+	Monitor monitor.Monitor
+	Loader  *services.Loader
+	Logger  logger.Logger
+}
+
+// instance is the state of a running instance and its services
+type instance struct {
+	relay.InstanceStatus
+	Services []registry.ServiceSnapshot
+}
+
+// daemon implements the Daemon interface
+type daemon struct {
+	cfg        *config.Config
+	client     relay.Client
+	monitor    monitor.Monitor
+	loader     *services.Loader
+	executable string
+	socketDir  string
+	socketErr  error
+	out        io.Writer
+	errOut     io.Writer
+	log        logger.Logger
+	uiLog      logger.Logger
+}
+
+// NewDaemon creates a new Daemon
+func NewDaemon(p Params) Daemon {
+	executable, err := os.Executable()
+	if err != nil {
+		executable = os.Args[0]
+	}
+
+	socketDir, socketErr := relay.SocketDir()
+
+	return &daemon{
+		cfg:        p.Config,
+		client:     p.Client,
+		monitor:    p.Monitor,
+		loader:     p.Loader,
+		executable: executable,
+		socketDir:  socketDir,
+		socketErr:  socketErr,
+		out:        os.Stdout,
+		errOut:     os.Stderr,
+		log:        p.Logger.WithComponent("DAEMON"),
+		uiLog:      p.Logger,
+	}
+}
+
+// Up starts the profile in a detached fuku process without a UI, writing its output to a log file,
+// and returns once the process is accepting connections on its socket
+func (d *daemon) Up(ctx context.Context, profile, configFile string) int {
+	if d.socketErr != nil {
+		d.log.Error().Err(d.socketErr).Msg("Failed to start detached fuku")
+
+		return 1
+	}
+
+	socketPath := relay.SocketPathForProfile(d.socketDir, profile)
+	if listening(socketPath) {
+		d.log.Error().Err(fmt.Errorf("%w '%s'", errors.ErrInstanceAlreadyRunning, profile)).Msg("Failed to start detached fuku")
+
+		return 1
+	}
+
+	logPath, err := session.LogPath(profile)
+	if err != nil {
+		d.log.Error().Err(err).Msg("Failed to resolve log file")
+
+		return 1
+	}
+
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
+		d.log.Error().Err(err).Msg("Failed to create log directory")
+
+		return 1
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		d.log.Error().Err(err).Msg("Failed to open log file")
+
+		return 1
+	}
+
+	defer logFile.Close()
+
+	args := []string{"run", profile, "--no-ui"}
+	if configFile != "" {
+		args = append(args, "--config", configFile)
+	}
+
+	//nolint:gosec // re-executes this binary with a fixed set of arguments
+	cmd := exec.Command(d.executable, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		d.log.Error().Err(err).Msg("Failed to start detached fuku")
+
+		return 1
+	}
+
+	exited := make(chan error, 1)
+
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	if err := waitListening(ctx, socketPath, exited); err != nil {
+		d.log.Error().Err(err).Msgf("Failed to start detached fuku, see %s", logPath)
+
+		return 1
+	}
+
+	fmt.Fprintf(d.out, "Started fuku (PID: %d) with profile '%s'\n", cmd.Process.Pid, profile)
+	fmt.Fprintf(d.out, "Logs: %s\n", logPath)
+
+	return 0
+}
+
+// Down asks a running instance to stop all services and exit, and waits until it has
+func (d *daemon) Down(ctx context.Context, profile string) int {
+	if err := d.connect(profile); err != nil {
+		d.log.Error().Err(err).Msg("Failed to connect to fuku")
+
+		return 1
+	}
+
+	defer d.client.Close()
+
+	reply, err := d.client.Shutdown()
+	if err != nil {
+		d.log.Error().Err(err).Msg("Failed to stop fuku")
+
+		return 1
+	}
+
+	fmt.Fprintf(d.out, "Stopping fuku (PID: %d)...\n", reply.PID)
+
+	if err := waitExited(ctx, reply.PID); err != nil {
+		d.log.Error().Err(err).Msgf("Failed to wait for fuku (PID: %d) to exit", reply.PID)
+
+		return 1
+	}
+
+	fmt.Fprintln(d.out, "Stopped")
+
+	return 0
+}
+
+// Status prints the state of a running instance and its services
+func (d *daemon) Status(_ context.Context, profile string) int {
+	if err := d.connect(profile); err != nil {
+		d.log.Error().Err(err).Msg("Failed to connect to fuku")
+
+		return 1
+	}
+
+	defer d.client.Close()
+
+	snapshot, err := d.snapshot()
+	if err != nil {
+		d.log.Error().Err(err).Msg("Failed to read status")
+
+		return 1
+	}
+
+	printSnapshot(d.out, snapshot, time.Now())
+
+	return 0
+}
+
+// connect finds the socket of the running instance and connects to it
+func (d *daemon) connect(profile string) error {
+	if d.socketErr != nil {
+		return d.socketErr
+	}
+
+	socketPath, err := relay.FindSocket(d.socketDir, profile)
+	if err != nil {
+		return err
+	}
+
+	return d.client.Connect(socketPath)
+}
+
+// snapshot reads the state of the connected instance and its services
+func (d *daemon) snapshot() (instance, error) {
+	status, err := d.client.Status()
+	if err != nil {
+		return instance{}, err
+	}
+
+	services, err := d.client.List()
+	if err != nil {
+		return instance{}, err
+	}
+
+	return instance{InstanceStatus: status, Services: services}, nil
+}
+
+// listening reports whether a fuku instance accepts connections on the socket
+func listening(socketPath string) bool {
+	conn, err := net.DialTimeout("unix", socketPath, config.SocketDialTimeout)
+	if err != nil {
+		return false
+	}
+
+	conn.Close()
+
+	return true
+}
+
+// waitListening waits until the detached process accepts connections on its socket, failing when it exits first
+func waitListening(ctx context.Context, socketPath string, exited <-chan error) error {
+	ticker := time.NewTicker(config.DaemonPollInterval)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(config.DaemonStartTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-exited:
+			if err != nil {
+				return fmt.Errorf("%w: %w", errors.ErrDaemonExited, err)
+			}
+
+			return errors.ErrDaemonExited
+		case <-timeout.C:
+			return fmt.Errorf("%w after %s", errors.ErrDaemonTimeout, config.DaemonStartTimeout)
+		case <-ticker.C:
+			if listening(socketPath) {
+				return nil
+			}
+		}
+	}
+}
+
+// waitExited waits until the process is gone
+func waitExited(ctx context.Context, pid int) error {
+	ticker := time.NewTicker(config.DaemonPollInterval)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(config.DaemonStopTimeout)
+	defer timeout.Stop()
+
+	for {
+		if err := syscall.Kill(pid, 0); errors.Is(err, syscall.ESRCH) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return fmt.Errorf("%w after %s", errors.ErrDaemonTimeout, config.DaemonStopTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// printSnapshot writes the instance summary followed by one line per service
+func printSnapshot(out io.Writer, snapshot instance, now time.Time) {
+	fmt.Fprintf(out, "Profile: %s  Phase: %s  PID: %d  Uptime: %s\n", snapshot.Profile, snapshot.Phase, snapshot.PID, snapshot.Uptime.Round(time.Second))
+	fmt.Fprintf(out, "Services: %d running, %d failed, %d stopped of %d\n\n",
+		snapshot.Counts.Running, snapshot.Counts.Failed+snapshot.Counts.CrashLoop+snapshot.Counts.BuildFailed, snapshot.Counts.Stopped, snapshot.Counts.Total)
+
+	printServices(out, snapshot.Services, now)
+}
+
+// printServices writes one line per service
+func printServices(out io.Writer, services []registry.ServiceSnapshot, now time.Time) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "SERVICE\tTIER\tSTATUS\tPID\tCPU\tMEM\tUPTIME\tRESTARTS")
+
+	for _, svc := range services {
+		pid, cpu, mem, uptime := "-", "-", "-", "-"
+
+		if svc.HasProcess() {
+			pid = fmt.Sprintf("%d", svc.PID)
+			cpu = fmt.Sprintf("%.1f%%", svc.CPU)
+			mem = fmt.Sprintf("%.1fMB", float64(svc.Memory)/1024/1024)
+		}
+
+		if svc.HasProcess() && !svc.StartTime.IsZero() {
+			uptime = now.Sub(svc.StartTime).Round(time.Second).String()
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n", svc.Name, svc.Tier, svc.Status, pid, cpu, mem, uptime, svc.Restarts)
+	}
+
+	w.Flush()
+}
+
+// tiers rebuilds the tier layout of an instance from its services, which the store lists in tier order
+func tiers(snapshot instance) []bus.Tier {
+	var result []bus.Tier
+
+	for _, svc := range snapshot.Services {
+		if len(result) == 0 || result[len(result)-1].Name != svc.Tier {
+			result = append(result, bus.Tier{ID: svc.Tier, Name: svc.Tier})
+		}
+
+		last := &result[len(result)-1]
+		last.Services = append(last.Services, bus.Service{ID: svc.ID, Name: svc.Name})
+	}
+
+	return result
+}
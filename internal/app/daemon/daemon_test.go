@@ -0,0 +1,240 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/registry"
+	"fuku/internal/app/relay"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func newTestDaemon(t *testing.T, ctrl *gomock.Controller, client relay.Client) (*daemon, *bytes.Buffer) {
+	t.Helper()
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Error().Return(nil).AnyTimes()
+	mockLog.EXPECT().Debug().Return(nil).AnyTimes()
+
+	out := &bytes.Buffer{}
+
+	return &daemon{
+		cfg:       config.DefaultConfig(),
+		client:    client,
+		socketDir: t.TempDir(),
+		out:       out,
+		errOut:    out,
+		log:       mockLog,
+	}, out
+}
+
+func listen(t *testing.T, socketPath string) {
+	t.Helper()
+
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			conn.Close()
+		}
+	}()
+
+	t.Cleanup(func() { listener.Close() })
+}
+
+func Test_Up_AlreadyRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, out := newTestDaemon(t, ctrl, relay.NewMockClient(ctrl))
+	d.executable = "true"
+
+	listen(t, relay.SocketPathForProfile(d.socketDir, "core"))
+
+	assert.Equal(t, 1, d.Up(context.Background(), "core", ""))
+	assert.Empty(t, out.String())
+}
+
+func Test_SocketDirUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, out := newTestDaemon(t, ctrl, relay.NewMockClient(ctrl))
+	d.socketErr = errors.ErrSocketSearchFailed
+
+	assert.Equal(t, 1, d.Up(context.Background(), "core", ""))
+	assert.Equal(t, 1, d.Status(context.Background(), "core"))
+	assert.Empty(t, out.String())
+}
+
+func Test_Up_ProcessExits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	state := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", state)
+	t.Chdir(t.TempDir())
+
+	script := filepath.Join(t.TempDir(), "fuku")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho \"$@\"\nexit 1\n"), 0o700))
+
+	d, out := newTestDaemon(t, ctrl, relay.NewMockClient(ctrl))
+	d.executable = script
+
+	assert.Equal(t, 1, d.Up(context.Background(), "core", "custom.yaml"))
+	assert.Empty(t, out.String())
+
+	logPath, err := filepath.Glob(filepath.Join(state, "fuku", "*", "fuku-core.log"))
+	require.NoError(t, err)
+	require.Len(t, logPath, 1)
+
+	data, err := os.ReadFile(logPath[0])
+	require.NoError(t, err)
+	assert.Equal(t, "run core --no-ui --config custom.yaml\n", string(data))
+}
+
+func Test_WaitListening(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "fuku-core.sock")
+
+	exited := make(chan error, 1)
+
+	go func() {
+		//nolint:forbidigo // let a few polls miss the socket first
+		time.Sleep(3 * config.DaemonPollInterval)
+		listen(t, socketPath)
+	}()
+
+	require.NoError(t, waitListening(context.Background(), socketPath, exited))
+
+	exited <- &exec.ExitError{}
+	require.ErrorIs(t, waitListening(context.Background(), filepath.Join(t.TempDir(), "missing.sock"), exited), errors.ErrDaemonExited)
+}
+
+func Test_Down(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exited := exec.Command("true")
+	require.NoError(t, exited.Run())
+
+	mockClient := relay.NewMockClient(ctrl)
+	d, out := newTestDaemon(t, ctrl, mockClient)
+
+	socketPath := relay.SocketPathForProfile(d.socketDir, "core")
+	listen(t, socketPath)
+
+	gomock.InOrder(
+		mockClient.EXPECT().Connect(socketPath).Return(nil),
+		mockClient.EXPECT().Shutdown().Return(relay.InstanceStatus{PID: exited.Process.Pid}, nil),
+		mockClient.EXPECT().Close().Return(nil),
+	)
+
+	assert.Equal(t, 0, d.Down(context.Background(), "core"))
+	assert.Contains(t, out.String(), "Stopping fuku")
+	assert.Contains(t, out.String(), "Stopped\n")
+}
+
+func Test_Down_NoInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d, out := newTestDaemon(t, ctrl, relay.NewMockClient(ctrl))
+
+	assert.Equal(t, 1, d.Down(context.Background(), ""))
+	assert.Empty(t, out.String())
+}
+
+func Test_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := relay.NewMockClient(ctrl)
+	d, out := newTestDaemon(t, ctrl, mockClient)
+
+	socketPath := relay.SocketPathForProfile(d.socketDir, "core")
+	listen(t, socketPath)
+
+	gomock.InOrder(
+		mockClient.EXPECT().Connect(socketPath).Return(nil),
+		mockClient.EXPECT().Status().Return(relay.InstanceStatus{Profile: "core", Phase: "running"}, nil),
+		mockClient.EXPECT().List().Return(nil, nil),
+		mockClient.EXPECT().Close().Return(nil),
+	)
+
+	assert.Equal(t, 0, d.Status(context.Background(), ""))
+	assert.Contains(t, out.String(), "Profile: core  Phase: running")
+
+	mockClient.EXPECT().Connect(socketPath).Return(nil)
+	mockClient.EXPECT().Status().Return(relay.InstanceStatus{}, errors.ErrRequestFailed)
+	mockClient.EXPECT().Close().Return(nil)
+
+	assert.Equal(t, 1, d.Status(context.Background(), "core"))
+}
+
+func Test_PrintSnapshot(t *testing.T) {
+	now := time.Now()
+	snapshot := instance{
+		InstanceStatus: relay.InstanceStatus{
+			Profile: "core",
+			Phase:   "running",
+			PID:     42,
+			Uptime:  90 * time.Second,
+			Counts:  registry.StatusCounts{Total: 4, Running: 1, Failed: 1, Stopped: 1, BuildFailed: 1},
+		},
+		Services: []registry.ServiceSnapshot{
+			{Name: "api", Tier: "platform", Status: registry.StatusRunning, PID: 100, CPU: 2.5, Memory: 50 * 1024 * 1024, StartTime: now.Add(-time.Minute)},
+			{Name: "auth", Tier: "platform", Status: registry.StatusBuildFailed, PID: 101, CPU: 1.0, Memory: 20 * 1024 * 1024, StartTime: now.Add(-2 * time.Minute)},
+			{Name: "worker", Tier: "platform", Status: registry.StatusFailed, Restarts: 3},
+			{Name: "web", Tier: "edge", Status: registry.StatusStopped},
+		},
+	}
+
+	var out bytes.Buffer
+
+	printSnapshot(&out, snapshot, now)
+
+	assert.Equal(t, `Profile: core  Phase: running  PID: 42  Uptime: 1m30s
+Services: 1 running, 2 failed, 1 stopped of 4
+
+SERVICE  TIER      STATUS        PID  CPU   MEM     UPTIME  RESTARTS
+api      platform  running       100  2.5%  50.0MB  1m0s    0
+auth     platform  build-failed  101  1.0%  20.0MB  2m0s    0
+worker   platform  failed        -    -     -       -       3
+web      edge      stopped       -    -     -       -       0
+`, out.String())
+}
+
+func Test_Tiers(t *testing.T) {
+	snapshot := instance{
+		Services: []registry.ServiceSnapshot{
+			{ID: "id-db", Name: "db", Tier: "foundation"},
+			{ID: "id-api", Name: "api", Tier: "platform"},
+			{ID: "id-worker", Name: "worker", Tier: "platform"},
+		},
+	}
+
+	assert.Equal(t, []bus.Tier{
+		{ID: "foundation", Name: "foundation", Services: []bus.Service{{ID: "id-db", Name: "db"}}},
+		{ID: "platform", Name: "platform", Services: []bus.Service{{ID: "id-api", Name: "api"}, {ID: "id-worker", Name: "worker"}}},
+	}, tiers(snapshot))
+	assert.Empty(t, tiers(instance{}))
+}
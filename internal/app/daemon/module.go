@@ -0,0 +1,8 @@
+package daemon
+
+import "go.uber.org/fx"
+
+// Module provides the daemon package dependencies
+var Module = fx.Options(
+	fx.Provide(NewDaemon),
+)
@@ -0,0 +1,215 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/registry"
+	"fuku/internal/app/relay"
+	"fuku/internal/app/ui/services"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+// UI runs the services UI against a running instance. The UI follows the instance's state on a bus
+// of its own and sends service commands over the socket, and quitting detaches while the services keep running
+func (d *daemon) UI(ctx context.Context, profile string) int {
+	if err := d.connect(profile); err != nil {
+		fmt.Fprintf(d.errOut, "Error: %v\n", err)
+
+		return 1
+	}
+
+	defer d.client.Close()
+
+	snapshot, err := d.snapshot()
+	if err != nil {
+		fmt.Fprintf(d.errOut, "Error: %v\n", err)
+
+		return 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	b := bus.NewBus(d.cfg, nil, nil)
+	defer b.Close()
+
+	store := newRemoteStore(snapshot)
+	model := services.NewModel(ctx, snapshot.Profile, b, &remoteController{client: d.client, log: d.log}, store, d.monitor, nil, d.loader, d.uiLog).Detachable()
+	program := tea.NewProgram(model, tea.WithContext(ctx))
+
+	go d.follow(ctx, b, store, snapshot)
+
+	if _, err := program.Run(); err != nil {
+		d.log.Error().Err(err).Msg("UI error")
+
+		return 1
+	}
+
+	return 0
+}
+
+// follow announces the instance's services on the bus and keeps the store up to date with its snapshots,
+// ending the UI once the instance is gone
+func (d *daemon) follow(ctx context.Context, b bus.Bus, store *remoteStore, snapshot instance) {
+	layout := tiers(snapshot)
+
+	b.Publish(bus.Message{Type: bus.EventProfileResolved, Data: bus.ProfileResolved{Profile: snapshot.Profile, Tiers: layout}, Critical: true})
+	b.Publish(bus.Message{Type: bus.EventPhaseChanged, Data: bus.PhaseChanged{Phase: bus.Phase(snapshot.Phase)}, Critical: true})
+
+	if bus.Phase(snapshot.Phase) == bus.PhaseRunning {
+		for _, tier := range layout {
+			b.Publish(bus.Message{Type: bus.EventTierReady, Data: bus.TierReady{Name: tier.Name, ServiceCount: len(tier.Services)}, Critical: true})
+		}
+	}
+
+	ticker := time.NewTicker(config.RemotePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		next, err := d.snapshot()
+		if err != nil {
+			d.log.Debug().Err(err).Msg("Lost connection to fuku")
+			b.Publish(bus.Message{Type: bus.EventPhaseChanged, Data: bus.PhaseChanged{Phase: bus.PhaseStopped}, Critical: true})
+
+			return
+		}
+
+		if next.Phase != store.Phase() {
+			b.Publish(bus.Message{Type: bus.EventPhaseChanged, Data: bus.PhaseChanged{Phase: bus.Phase(next.Phase)}, Critical: true})
+		}
+
+		store.update(next)
+	}
+}
+
+// remoteController is the controller of a UI attached to a running instance, sending service commands over its socket
+type remoteController struct {
+	client relay.Client
+	log    logger.Logger
+}
+
+// Start asks the instance to start the service
+func (c *remoteController) Start(service bus.Service) {
+	if _, err := c.client.Start(service.Name); err != nil {
+		c.log.Warn().Err(err).Msgf("Failed to start service '%s'", service.Name)
+	}
+}
+
+// Stop asks the instance to stop the service
+func (c *remoteController) Stop(service bus.Service) {
+	if _, err := c.client.Stop(service.Name); err != nil {
+		c.log.Warn().Err(err).Msgf("Failed to stop service '%s'", service.Name)
+	}
+}
+
+// Restart asks the instance to restart the service
+func (c *remoteController) Restart(service bus.Service) {
+	if _, err := c.client.Restart(service.Name); err != nil {
+		c.log.Warn().Err(err).Msgf("Failed to restart service '%s'", service.Name)
+	}
+}
+
+// StopAll does nothing, quitting the attached UI leaves the services running
+func (c *remoteController) StopAll() {}
+
+// remoteStore serves the latest snapshot of a running instance as a registry.Store
+type remoteStore struct {
+	mu       sync.RWMutex
+	snapshot instance
+	at       time.Time
+}
+
+// newRemoteStore creates a store holding the given snapshot
+func newRemoteStore(snapshot instance) *remoteStore {
+	return &remoteStore{snapshot: snapshot, at: time.Now()}
+}
+
+// update replaces the snapshot
+func (s *remoteStore) update(snapshot instance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshot = snapshot
+	s.at = time.Now()
+}
+
+// Run does nothing, the store is updated by the snapshots the UI follows
+func (s *remoteStore) Run(context.Context) {}
+
+// WaitReady returns immediately, the store holds a snapshot from the start
+func (s *remoteStore) WaitReady() {}
+
+// WaitResolved returns immediately, the instance's profile is already resolved
+func (s *remoteStore) WaitResolved(context.Context) {}
+
+// IsResolved returns true, the instance's profile is already resolved
+func (s *remoteStore) IsResolved() bool {
+	return true
+}
+
+// Phase returns the instance's phase
+func (s *remoteStore) Phase() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.snapshot.Phase
+}
+
+// Profile returns the instance's profile
+func (s *remoteStore) Profile() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.snapshot.Profile
+}
+
+// Uptime returns the instance's uptime, advanced by the age of the snapshot
+func (s *remoteStore) Uptime() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.snapshot.Uptime + time.Since(s.at)
+}
+
+// Services returns the instance's services
+func (s *remoteStore) Services() []registry.ServiceSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]registry.ServiceSnapshot(nil), s.snapshot.Services...)
+}
+
+// Service returns a single service by ID
+func (s *remoteStore) Service(id string) (registry.ServiceSnapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, svc := range s.snapshot.Services {
+		if svc.ID == id {
+			return svc, true
+		}
+	}
+
+	return registry.ServiceSnapshot{}, false
+}
+
+// Counts returns the instance's service counts
+func (s *remoteStore) Counts() registry.StatusCounts {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.snapshot.Counts
+}
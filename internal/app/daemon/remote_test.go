@@ -0,0 +1,140 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/registry"
+	"fuku/internal/app/relay"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func Test_RemoteStore(t *testing.T) {
+	store := newRemoteStore(instance{
+		InstanceStatus: relay.InstanceStatus{
+			Profile: "core",
+			Phase:   "startup",
+			Uptime:  time.Minute,
+			Counts:  registry.StatusCounts{Total: 1, Starting: 1},
+		},
+		Services: []registry.ServiceSnapshot{{ID: "id-api", Name: "api", Status: registry.StatusStarting}},
+	})
+
+	assert.True(t, store.IsResolved())
+	assert.Equal(t, "core", store.Profile())
+	assert.Equal(t, "startup", store.Phase())
+	assert.GreaterOrEqual(t, store.Uptime(), time.Minute)
+	assert.Equal(t, registry.StatusCounts{Total: 1, Starting: 1}, store.Counts())
+
+	store.update(instance{
+		InstanceStatus: relay.InstanceStatus{Profile: "core", Phase: "running"},
+		Services:       []registry.ServiceSnapshot{{ID: "id-api", Name: "api", Status: registry.StatusRunning, PID: 100}},
+	})
+
+	assert.Equal(t, "running", store.Phase())
+
+	svc, ok := store.Service("id-api")
+	require.True(t, ok)
+	assert.Equal(t, 100, svc.PID)
+
+	_, ok = store.Service("missing")
+	assert.False(t, ok)
+
+	services := store.Services()
+	services[0].PID = 0
+	assert.Equal(t, 100, store.Services()[0].PID)
+}
+
+func Test_Follow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshot := instance{
+		InstanceStatus: relay.InstanceStatus{Profile: "core", Phase: string(bus.PhaseRunning)},
+		Services:       []registry.ServiceSnapshot{{ID: "id-api", Name: "api", Tier: "platform", Status: registry.StatusRunning}},
+	}
+	stopping := snapshot
+	stopping.Phase = string(bus.PhaseStopping)
+
+	mockClient := relay.NewMockClient(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().Status().Return(stopping.InstanceStatus, nil),
+		mockClient.EXPECT().List().Return(stopping.Services, nil),
+		mockClient.EXPECT().Status().Return(relay.InstanceStatus{}, errors.ErrFailedToReadSocket),
+	)
+
+	d, _ := newTestDaemon(t, ctrl, mockClient)
+
+	b := bus.NewBus(config.DefaultConfig(), nil, nil)
+	defer b.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	msgs := b.Subscribe(ctx)
+	store := newRemoteStore(snapshot)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		d.follow(ctx, b, store, snapshot)
+	}()
+
+	expected := []bus.Message{
+		{Type: bus.EventProfileResolved, Data: bus.ProfileResolved{Profile: "core", Tiers: []bus.Tier{
+			{ID: "platform", Name: "platform", Services: []bus.Service{{ID: "id-api", Name: "api"}}},
+		}}},
+		{Type: bus.EventPhaseChanged, Data: bus.PhaseChanged{Phase: bus.PhaseRunning}},
+		{Type: bus.EventTierReady, Data: bus.TierReady{Name: "platform", ServiceCount: 1}},
+		{Type: bus.EventPhaseChanged, Data: bus.PhaseChanged{Phase: bus.PhaseStopping}},
+		{Type: bus.EventPhaseChanged, Data: bus.PhaseChanged{Phase: bus.PhaseStopped}},
+	}
+
+	for _, want := range expected {
+		select {
+		case msg := <-msgs:
+			assert.Equal(t, want.Type, msg.Type)
+			assert.Equal(t, want.Data, msg.Data)
+		case <-ctx.Done():
+			t.Fatalf("missing %s", want.Type)
+		}
+	}
+
+	<-done
+
+	assert.Equal(t, string(bus.PhaseStopping), store.Phase())
+}
+
+func Test_RemoteController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := relay.NewMockClient(ctrl)
+	mockLog := logger.NewMockLogger(ctrl)
+
+	api := bus.Service{ID: "id-api", Name: "api"}
+
+	gomock.InOrder(
+		mockClient.EXPECT().Stop("api").Return(registry.ServiceSnapshot{}, nil),
+		mockClient.EXPECT().Start("api").Return(registry.ServiceSnapshot{}, nil),
+		mockClient.EXPECT().Restart("api").Return(registry.ServiceSnapshot{}, errors.ErrRequestFailed),
+		mockLog.EXPECT().Warn().Return(nil),
+	)
+
+	c := &remoteController{client: mockClient, log: mockLog}
+
+	c.Stop(api)
+	c.Start(api)
+	c.Restart(api)
+	c.StopAll()
+}
@@ -2,6 +2,7 @@ package discovery
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
 	"fuku/internal/app/errors"
@@ -40,11 +41,20 @@ func (d *discovery) Resolve(profile string) ([]Tier, error) {
 		return nil, err
 	}
 
+	serviceNames, err = d.expandDependencies(serviceNames)
+	if err != nil {
+		return nil, err
+	}
+
 	services, err := d.resolveServiceOrder(serviceNames)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := d.validateDependencyTiers(services); err != nil {
+		return nil, err
+	}
+
 	if len(services) == 0 {
 		return []Tier{}, nil
 	}
@@ -54,40 +64,64 @@ func (d *discovery) Resolve(profile string) ([]Tier, error) {
 
 // getServicesForProfile returns the list of services for a given profile
 func (d *discovery) getServicesForProfile(profile string) ([]string, error) {
-	profileConfig, exists := d.cfg.Profiles[profile]
+	return d.cfg.ProfileServices(profile)
+}
 
-	if !exists {
-		return nil, fmt.Errorf("%w: %s", errors.ErrProfileNotFound, profile)
-	}
+// expandDependencies appends transitive depends_on entries of the profile members
+func (d *discovery) expandDependencies(serviceNames []string) ([]string, error) {
+	seen := make(map[string]bool, len(serviceNames))
+	result := make([]string, 0, len(serviceNames))
+	queue := slices.Clone(serviceNames)
 
-	switch v := profileConfig.(type) {
-	case string:
-		if v == "*" {
-			services := make([]string, 0, len(d.cfg.Services))
-			for name := range d.cfg.Services {
-				services = append(services, name)
-			}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
 
-			return services, nil
+		if seen[name] {
+			continue
 		}
 
-		return []string{v}, nil
-	case []any:
-		services := make([]string, 0, len(v))
+		seen[name] = true
+		result = append(result, name)
 
-		for _, item := range v {
-			str, ok := item.(string)
-			if !ok {
-				return nil, fmt.Errorf("%w: profile '%s' contains non-string entry", errors.ErrUnsupportedProfileFormat, profile)
-			}
+		srv, exists := d.cfg.Services[name]
+		if !exists {
+			return nil, fmt.Errorf("%w: '%s'", errors.ErrServiceNotFound, name)
+		}
+
+		queue = append(queue, srv.DependsOn...)
+	}
 
-			services = append(services, str)
+	return result, nil
+}
+
+// validateDependencyTiers rejects dependencies that point at a later tier than the dependent service
+func (d *discovery) validateDependencyTiers(services []string) error {
+	tierIndexMap := d.buildTierIndexMap()
+
+	for _, name := range services {
+		srv := d.cfg.Services[name]
+		tierIndex := d.getTierIndex(d.tierName(srv), tierIndexMap)
+
+		for _, dep := range srv.DependsOn {
+			depTier := d.tierName(d.cfg.Services[dep])
+
+			if d.getTierIndex(depTier, tierIndexMap) > tierIndex {
+				return fmt.Errorf("%w: '%s' (tier %s) depends on '%s' (tier %s)", errors.ErrDependencyTierOrder, name, d.tierName(srv), dep, depTier)
+			}
 		}
+	}
 
-		return services, nil
-	default:
-		return nil, fmt.Errorf("%w: %s", errors.ErrUnsupportedProfileFormat, profile)
+	return nil
+}
+
+// tierName returns the tier of a service, falling back to the default tier
+func (d *discovery) tierName(srv *config.Service) string {
+	if srv.Tier == "" {
+		return config.Default
 	}
+
+	return srv.Tier
 }
 
 // resolveServiceOrder validates, deduplicates, and orders services by tier
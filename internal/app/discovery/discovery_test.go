@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"fuku/internal/app/errors"
 	"fuku/internal/config"
 )
 
@@ -50,6 +51,41 @@ func Test_Resolve(t *testing.T) {
 			profile:  "invalid",
 			expected: result{tiers: nil, error: true},
 		},
+		{
+			name: "Transitive dependencies are auto-included",
+			services: map[string]*config.Service{
+				"db":   {Dir: "db", Tier: "foundation"},
+				"auth": {Dir: "auth", Tier: "foundation", DependsOn: []string{"db"}},
+				"api":  {Dir: "api", Tier: "platform", DependsOn: []string{"auth"}},
+				"web":  {Dir: "web", Tier: "edge"},
+			},
+			profiles: map[string]any{"backend": []any{"api"}},
+			profile:  "backend",
+			expected: result{
+				tiers: []Tier{
+					{Name: "foundation", Services: []string{"auth", "db"}},
+					{Name: "platform", Services: []string{"api"}},
+				},
+				error: false,
+			},
+		},
+		{
+			name: "Service-side profile declarations are merged",
+			services: map[string]*config.Service{
+				"db":  {Dir: "db", Tier: "foundation", Profiles: []string{"backend"}},
+				"api": {Dir: "api", Tier: "platform"},
+				"web": {Dir: "web", Tier: "edge"},
+			},
+			profiles: map[string]any{"backend": []any{"api"}},
+			profile:  "backend",
+			expected: result{
+				tiers: []Tier{
+					{Name: "foundation", Services: []string{"db"}},
+					{Name: "platform", Services: []string{"api"}},
+				},
+				error: false,
+			},
+		},
 		{
 			name:     "Single service with default tier",
 			services: map[string]*config.Service{"api": {Dir: "api"}},
@@ -315,6 +351,23 @@ func Test_Resolve(t *testing.T) {
 	}
 }
 
+func Test_Resolve_DependencyOnLaterTier(t *testing.T) {
+	cfg := &config.Config{
+		Services: map[string]*config.Service{
+			"db":  {Dir: "db", Tier: "foundation", DependsOn: []string{"api"}},
+			"api": {Dir: "api", Tier: "platform"},
+		},
+		Profiles: map[string]any{"backend": []any{"db"}},
+	}
+	topology := &config.Topology{Order: []string{"foundation", "platform"}}
+
+	tiers, err := NewDiscovery(cfg, topology).Resolve("backend")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrDependencyTierOrder)
+	assert.Nil(t, tiers)
+}
+
 func Test_getServicesForProfile(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -11,15 +11,33 @@ var (
 	ErrInvalidConcurrencyWorkers = errors.New("concurrency workers must be greater than 0")
 	ErrInvalidRetryAttempts      = errors.New("retry attempts must be greater than 0")
 	ErrInvalidRetryBackoff       = errors.New("retry backoff must not be negative")
+	ErrInvalidRetryMultiplier    = errors.New("retry multiplier must be at least 1")
+	ErrInvalidRetryJitter        = errors.New("retry jitter must be between 0 and 1")
+	ErrInvalidRetryErrorClass    = errors.New("unknown retry error class")
 	ErrInvalidLogsBuffer         = errors.New("logs buffer must be greater than 0")
 	ErrInvalidLogsHistory        = errors.New("logs history must be greater than 0")
 	ErrNoServicesDefined         = errors.New("no services defined")
+	ErrInvalidInterpolation      = errors.New("invalid variable interpolation")
+	ErrMissingVariable           = errors.New("required variable is not set")
+	ErrInvalidInclude            = errors.New("invalid include")
+	ErrIncludeNotFound           = errors.New("included config file not found")
+	ErrDuplicateService          = errors.New("duplicate service definition")
+	ErrInvalidExtends            = errors.New("invalid extends")
+	ErrExtendsCycle              = errors.New("extends cycle detected")
 
 	ErrProfileNotFound          = errors.New("profile not found")
 	ErrUnsupportedProfileFormat = errors.New("unsupported profile format")
 
 	ErrServiceNotFound          = errors.New("service not found")
 	ErrServiceDirectoryNotExist = errors.New("service directory does not exist")
+	ErrUnknownDependency        = errors.New("depends_on references unknown service")
+	ErrDependencyCycle          = errors.New("dependency cycle detected")
+	ErrDependencyTierOrder      = errors.New("service depends on a service in a later tier")
+	ErrDependencyFailed         = errors.New("dependency failed to start")
+	ErrInvalidTier              = errors.New("invalid tier")
+	ErrUndeclaredTier           = errors.New("tier is not declared in tiers")
+	ErrTierTimeout              = errors.New("tier did not become ready in time")
+	ErrTierFailed               = errors.New("tier failed to start")
 
 	ErrInvalidReadinessType     = errors.New("invalid readiness type")
 	ErrReadinessTypeRequired    = errors.New("readiness type is required")
@@ -42,13 +60,37 @@ var (
 	ErrAPINotAccepting    = errors.New("instance is not accepting actions")
 
 	ErrInvalidCommand       = errors.New("command must not be whitespace-only when provided")
+	ErrInvalidBuildCommand  = errors.New("build command must not be whitespace-only when provided")
 	ErrWatchIncludeRequired = errors.New("watch configuration requires include field")
+	ErrInvalidStopSignal    = errors.New("unsupported stop signal")
+	ErrInvalidStopTimeout   = errors.New("stop timeout must not be negative")
+	ErrInvalidStopGrace     = errors.New("stop grace period must not be negative")
+	ErrInvalidStopCommand   = errors.New("stop command must not be whitespace-only when provided")
+	ErrInvalidRestartPolicy = errors.New("invalid restart policy (must be 'never', 'on-failure' or 'always')")
+	ErrInvalidMaxRestarts   = errors.New("restart max_restarts must not be negative")
+	ErrInvalidRestartWindow = errors.New("restart window and backoff must not be negative")
+	ErrInvalidHookCommand   = errors.New("hook command is required")
+	ErrInvalidHookTimeout   = errors.New("hook timeout must not be negative")
+	ErrInvalidHookOnError   = errors.New("invalid hook on_error value (must be 'fail' or 'warn')")
+	ErrInvalidServiceType   = errors.New("invalid service type (must be 'service' or 'job')")
+	ErrJobReadiness         = errors.New("readiness is not supported for job services")
+	ErrJobRestart           = errors.New("restart is not supported for job services")
+	ErrJobTimeoutOnService  = errors.New("timeout is only supported for job services")
+	ErrInvalidJobTimeout    = errors.New("job timeout must not be negative")
+	ErrInvalidMemoryLimit   = errors.New("invalid memory limit (use a size such as 512M or 2G)")
+	ErrInvalidCPULimit      = errors.New("cpu limit must not be negative")
+	ErrInvalidPIDsLimit     = errors.New("pids limit must not be negative")
+	ErrInvalidNiceValue     = errors.New("nice value must be between -20 and 19")
 	ErrInvalidLogsOutput    = errors.New("invalid service logs output value (must be 'stdout' or 'stderr')")
+	ErrEnvFileNotFound      = errors.New("env file not found")
+	ErrFailedToReadEnvFile  = errors.New("failed to read env file")
 
 	ErrConfigFlagNotSupported = errors.New("--config flag is not supported for this command")
+	ErrUnsupportedFormat      = errors.New("unsupported output format")
 
 	ErrFailedToGetWorkingDir = errors.New("failed to get working directory")
 	ErrFailedToCreatePipe    = errors.New("failed to create pipe")
+	ErrFailedToOpenTerminal  = errors.New("failed to open pseudo-terminal")
 	ErrFailedToStartCommand  = errors.New("failed to start command")
 	ErrFailedToCreateRequest = errors.New("failed to create request")
 
@@ -57,7 +99,15 @@ var (
 	ErrFailedToAcquireWorker    = errors.New("failed to acquire worker")
 	ErrMaxRetriesExceeded       = errors.New("max retry attempts exceeded")
 	ErrFailedToTerminateProcess = errors.New("failed to terminate process")
+	ErrCgroupsUnavailable       = errors.New("cgroup v2 delegation is not available")
 	ErrUnexpectedExit           = errors.New("process exited")
+	ErrCrashLoop                = errors.New("crash loop")
+	ErrHookFailed               = errors.New("hook failed")
+	ErrJobFailed                = errors.New("job failed")
+	ErrJobTimeout               = errors.New("job timed out")
+	ErrBuildFailed              = errors.New("build failed")
+	ErrServiceNotRunning        = errors.New("service is not running")
+	ErrOutOfMemory              = errors.New("killed for exceeding its memory limit")
 
 	ErrFailedToConnectSocket    = errors.New("failed to connect to socket")
 	ErrFailedToListenSocket     = errors.New("failed to listen on socket")
@@ -70,6 +120,19 @@ var (
 	ErrNoInstanceRunning        = errors.New("no fuku instance is running")
 	ErrMultipleInstancesRunning = errors.New("multiple fuku instances running")
 	ErrInstanceNotFound         = errors.New("no fuku instance running with profile")
+	ErrAttachFailed             = errors.New("failed to attach")
+	ErrServiceNotAttachable     = errors.New("service does not accept input")
+	ErrRequestFailed            = errors.New("request failed")
+
+	ErrInstanceAlreadyRunning = errors.New("a fuku instance is already running with profile")
+	ErrDaemonExited           = errors.New("detached fuku exited during startup")
+	ErrDaemonTimeout          = errors.New("timed out waiting for detached fuku")
+	ErrServiceTimeout         = errors.New("timed out waiting for service")
+	ErrExecCommandRequired    = errors.New("exec requires a command to run, e.g. fuku exec api -- make migrate")
+	ErrStopNotConfirmed       = errors.New("input is not a terminal, pass --yes to kill the processes")
+
+	ErrSessionNotFound     = errors.New("no session file found")
+	ErrFailedToReadSession = errors.New("failed to read session file")
 )
 
 var (
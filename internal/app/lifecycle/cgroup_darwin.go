@@ -0,0 +1,49 @@
+package lifecycle
+
+import (
+	"fmt"
+	"os/exec"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+)
+
+// cgroup is unused on macOS, which has no cgroups
+type cgroup struct {
+	path   string
+	memory string
+}
+
+// cgroupParent is unused on macOS
+type cgroupParent struct {
+	dir  string
+	leaf string
+}
+
+// setupCgroupParent always fails on macOS, so limits are applied with rlimits
+func setupCgroupParent() (*cgroupParent, error) {
+	return nil, fmt.Errorf("%w: cgroups are only available on Linux", errors.ErrCgroupsUnavailable)
+}
+
+// restore does nothing on macOS
+func (p *cgroupParent) restore() error {
+	return nil
+}
+
+// openCgroup always fails on macOS
+func openCgroup(string, string, *config.Limits) (*cgroup, error) {
+	return nil, errors.ErrCgroupsUnavailable
+}
+
+// attach does nothing on macOS
+func (c *cgroup) attach(*exec.Cmd) {}
+
+// oomKilled is always false on macOS
+func (c *cgroup) oomKilled() bool {
+	return false
+}
+
+// remove does nothing on macOS
+func (c *cgroup) remove() error {
+	return nil
+}
@@ -0,0 +1,265 @@
+package lifecycle
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+)
+
+const (
+	// cgroupMount is where the cgroup v2 hierarchy is mounted
+	cgroupMount = "/sys/fs/cgroup"
+	// cpuPeriod is the cpu.max period in microseconds that CPU quotas are expressed against
+	cpuPeriod = 100000
+	// cgroupRemoveAttempts and cgroupRemoveDelay bound how long a cgroup may take to empty once killed
+	cgroupRemoveAttempts = 20
+	cgroupRemoveDelay    = 50 * time.Millisecond
+)
+
+// cgroupControllers are the controllers service cgroups need
+var cgroupControllers = []string{"cpu", "memory", "pids"}
+
+// cgroup is the cgroup v2 directory a single service process group runs in
+type cgroup struct {
+	path   string
+	fd     int
+	memory string
+}
+
+// cgroupSetting is a value written to a cgroup interface file
+type cgroupSetting struct {
+	file  string
+	value string
+}
+
+// cgroupParent is the delegated cgroup service cgroups are created in, and what fuku changed there
+type cgroupParent struct {
+	dir     string
+	leaf    string
+	enabled []string
+}
+
+// setupCgroupParent moves fuku into a leaf of its own cgroup and enables the controllers service
+// cgroups need. It fails unless cgroup v2 is in use and fuku's cgroup is delegated to the user.
+// The changes are undone by restore
+func setupCgroupParent() (*cgroupParent, error) {
+	data, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrCgroupsUnavailable, err)
+	}
+
+	rel, ok := unifiedCgroupPath(string(data))
+	if !ok {
+		return nil, fmt.Errorf("%w: not running on cgroup v2", errors.ErrCgroupsUnavailable)
+	}
+
+	dir := filepath.Join(cgroupMount, rel)
+
+	available, err := os.ReadFile(filepath.Join(dir, "cgroup.controllers"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrCgroupsUnavailable, err)
+	}
+
+	for _, controller := range cgroupControllers {
+		if !slices.Contains(strings.Fields(string(available)), controller) {
+			return nil, fmt.Errorf("%w: %s controller is not delegated", errors.ErrCgroupsUnavailable, controller)
+		}
+	}
+
+	subtree, err := os.ReadFile(filepath.Join(dir, "cgroup.subtree_control"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrCgroupsUnavailable, err)
+	}
+
+	removeStaleLeaves(dir)
+
+	pid := strconv.Itoa(os.Getpid())
+	parent := &cgroupParent{dir: dir, leaf: filepath.Join(dir, "fuku-"+pid)}
+
+	for _, controller := range cgroupControllers {
+		if !slices.Contains(strings.Fields(string(subtree)), controller) {
+			parent.enabled = append(parent.enabled, controller)
+		}
+	}
+
+	if err := os.Mkdir(parent.leaf, 0o755); err != nil && !os.IsExist(err) {
+		return nil, fmt.Errorf("%w: %w", errors.ErrCgroupsUnavailable, err)
+	}
+
+	if err := writeCgroupFile(parent.leaf, "cgroup.procs", pid); err != nil {
+		os.Remove(parent.leaf)
+
+		return nil, fmt.Errorf("%w: %w", errors.ErrCgroupsUnavailable, err)
+	}
+
+	if len(parent.enabled) > 0 {
+		if err := writeCgroupFile(dir, "cgroup.subtree_control", "+"+strings.Join(parent.enabled, " +")); err != nil {
+			parent.enabled = nil
+			//nolint:errcheck // best-effort move back into the original cgroup
+			parent.restore()
+
+			return nil, fmt.Errorf("%w: %w", errors.ErrCgroupsUnavailable, err)
+		}
+	}
+
+	return parent, nil
+}
+
+// restore disables the controllers fuku enabled, moves fuku back into its original cgroup and removes
+// its leaf. Service cgroups must have been removed first
+func (p *cgroupParent) restore() error {
+	if len(p.enabled) > 0 {
+		if err := writeCgroupFile(p.dir, "cgroup.subtree_control", "-"+strings.Join(p.enabled, " -")); err != nil {
+			return err
+		}
+	}
+
+	if err := writeCgroupFile(p.dir, "cgroup.procs", strconv.Itoa(os.Getpid())); err != nil {
+		return err
+	}
+
+	return unix.Rmdir(p.leaf)
+}
+
+// removeStaleLeaves removes the empty leaves earlier fuku processes that did not exit cleanly left behind
+func removeStaleLeaves(dir string) {
+	leaves, err := filepath.Glob(filepath.Join(dir, "fuku-*"))
+	if err != nil {
+		return
+	}
+
+	for _, leaf := range leaves {
+		if _, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(leaf), "fuku-")); err != nil {
+			continue
+		}
+
+		//nolint:errcheck // a leaf still holding a running fuku is not empty and stays
+		unix.Rmdir(leaf)
+	}
+}
+
+// unifiedCgroupPath returns the cgroup v2 path from the contents of /proc/self/cgroup
+func unifiedCgroupPath(content string) (string, bool) {
+	for line := range strings.Lines(content) {
+		if path, ok := strings.CutPrefix(strings.TrimSpace(line), "0::"); ok {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// openCgroup creates a cgroup under parent with the given limits and opens it for placing a process in it
+func openCgroup(parent, name string, limits *config.Limits) (*cgroup, error) {
+	path := filepath.Join(parent, name)
+
+	if err := os.Mkdir(path, 0o755); err != nil {
+		return nil, err
+	}
+
+	for _, setting := range cgroupSettings(limits) {
+		if err := writeCgroupFile(path, setting.file, setting.value); err != nil {
+			os.Remove(path)
+
+			return nil, err
+		}
+	}
+
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		os.Remove(path)
+
+		return nil, err
+	}
+
+	return &cgroup{path: path, fd: fd, memory: limits.Memory}, nil
+}
+
+// cgroupSettings returns the interface file values for the limits. The whole group is OOM-killed together
+func cgroupSettings(limits *config.Limits) []cgroupSetting {
+	var settings []cgroupSetting
+
+	if bytes := limits.MemoryBytes(); bytes > 0 {
+		settings = append(settings,
+			cgroupSetting{file: "memory.max", value: strconv.FormatInt(bytes, 10)},
+			cgroupSetting{file: "memory.oom.group", value: "1"},
+		)
+	}
+
+	if limits.CPU > 0 {
+		quota := int64(limits.CPU * cpuPeriod)
+		settings = append(settings, cgroupSetting{file: "cpu.max", value: fmt.Sprintf("%d %d", quota, cpuPeriod)})
+	}
+
+	if limits.PIDs > 0 {
+		settings = append(settings, cgroupSetting{file: "pids.max", value: strconv.Itoa(limits.PIDs)})
+	}
+
+	return settings
+}
+
+// attach makes the command start directly inside the cgroup
+func (c *cgroup) attach(cmd *exec.Cmd) {
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = c.fd
+}
+
+// oomKilled reports whether the kernel killed processes in the cgroup for exceeding its memory limit
+func (c *cgroup) oomKilled() bool {
+	data, err := os.ReadFile(filepath.Join(c.path, "memory.events"))
+	if err != nil {
+		return false
+	}
+
+	return parseOOMKills(string(data)) > 0
+}
+
+// parseOOMKills returns the oom_kill count from the contents of memory.events
+func parseOOMKills(content string) int {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "oom_kill "); ok {
+			count, _ := strconv.Atoi(strings.TrimSpace(value))
+
+			return count
+		}
+	}
+
+	return 0
+}
+
+// remove kills anything left in the cgroup and deletes it
+func (c *cgroup) remove() error {
+	unix.Close(c.fd)
+
+	//nolint:errcheck // cgroup.kill is missing before Linux 5.14, rmdir then fails while processes remain
+	writeCgroupFile(c.path, "cgroup.kill", "1")
+
+	var err error
+
+	for range cgroupRemoveAttempts {
+		if err = unix.Rmdir(c.path); err != unix.EBUSY {
+			return err
+		}
+
+		time.Sleep(cgroupRemoveDelay)
+	}
+
+	return err
+}
+
+// writeCgroupFile writes a value to a cgroup interface file
+func writeCgroupFile(dir, file, value string) error {
+	return os.WriteFile(filepath.Join(dir, file), []byte(value), 0o644)
+}
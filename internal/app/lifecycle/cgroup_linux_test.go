@@ -0,0 +1,103 @@
+package lifecycle
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/config"
+)
+
+func Test_UnifiedCgroupPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		ok       bool
+	}{
+		{name: "cgroup v2", content: "0::/user.slice/user-1000.slice/app.slice/fuku.scope\n", expected: "/user.slice/user-1000.slice/app.slice/fuku.scope", ok: true},
+		{name: "hybrid", content: "12:memory:/user.slice\n0::/user.slice/session.scope\n", expected: "/user.slice/session.scope", ok: true},
+		{name: "cgroup v1 only", content: "12:memory:/user.slice\n11:pids:/user.slice\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ok := unifiedCgroupPath(tt.content)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+}
+
+func Test_CgroupSettings(t *testing.T) {
+	settings := cgroupSettings(&config.Limits{Memory: "2G", CPU: 1.5, PIDs: 256, NoFile: 1024})
+
+	assert.Equal(t, []cgroupSetting{
+		{file: "memory.max", value: "2147483648"},
+		{file: "memory.oom.group", value: "1"},
+		{file: "cpu.max", value: "150000 100000"},
+		{file: "pids.max", value: "256"},
+	}, settings)
+
+	assert.Empty(t, cgroupSettings(&config.Limits{Nice: 5}))
+}
+
+func Test_OpenCgroup(t *testing.T) {
+	parent := t.TempDir()
+
+	cg, err := openCgroup(parent, "api-1", &config.Limits{Memory: "512M", PIDs: 64})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(parent, "api-1", "memory.max"))
+	require.NoError(t, err)
+	assert.Equal(t, "536870912", string(data))
+
+	assert.False(t, cg.oomKilled())
+
+	require.NoError(t, writeCgroupFile(cg.path, "memory.events", "low 0\nhigh 0\nmax 12\noom 1\noom_kill 1\n"))
+	assert.True(t, cg.oomKilled())
+}
+
+func Test_CgroupParent_Restore(t *testing.T) {
+	dir := t.TempDir()
+
+	parent := &cgroupParent{dir: dir, leaf: filepath.Join(dir, "fuku-1"), enabled: []string{"cpu", "memory"}}
+	require.NoError(t, os.Mkdir(parent.leaf, 0o755))
+
+	require.NoError(t, parent.restore())
+
+	data, err := os.ReadFile(filepath.Join(dir, "cgroup.subtree_control"))
+	require.NoError(t, err)
+	assert.Equal(t, "-cpu -memory", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "cgroup.procs"))
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid()), string(data))
+
+	assert.NoDirExists(t, parent.leaf)
+}
+
+func Test_RemoveStaleLeaves(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"fuku-123", "fuku-456", "fuku-web-1"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, name), 0o755))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fuku-456", "cgroup.procs"), []byte("456"), 0o644))
+
+	removeStaleLeaves(dir)
+
+	assert.NoDirExists(t, filepath.Join(dir, "fuku-123"))
+	assert.DirExists(t, filepath.Join(dir, "fuku-456"))
+	assert.DirExists(t, filepath.Join(dir, "fuku-web-1"))
+}
+
+func Test_ParseOOMKills(t *testing.T) {
+	assert.Equal(t, 3, parseOOMKills("low 0\nhigh 0\nmax 40\noom 3\noom_kill 3\noom_group_kill 1\n"))
+	assert.Equal(t, 0, parseOOMKills("low 0\nhigh 0\n"))
+}
@@ -1,39 +1,54 @@
 package lifecycle
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
+	"strings"
 	"syscall"
 	"time"
 
 	"fuku/internal/app/errors"
 	"fuku/internal/app/process"
+	"fuku/internal/config"
 	"fuku/internal/config/logger"
 )
 
 // Lifecycle handles process group configuration and termination
 type Lifecycle interface {
-	Configure(cmd *exec.Cmd)
-	Terminate(proc process.Process, timeout time.Duration) error
+	Configure(cmd *exec.Cmd, name string, limits *config.Limits)
+	Release(cmd *exec.Cmd) error
+	Terminate(proc process.Process, stop config.Stop) error
+	Close()
 }
 
 // lifecycle implements the Lifecycle interface
 type lifecycle struct {
-	log logger.Logger
+	cgroups *cgroups
+	log     logger.Logger
 }
 
 // NewLifecycle creates a new Lifecycle instance
 func NewLifecycle(log logger.Logger) Lifecycle {
-	return &lifecycle{log: log.WithComponent("LIFECYCLE")}
+	return &lifecycle{
+		cgroups: newCgroups(),
+		log:     log.WithComponent("LIFECYCLE"),
+	}
 }
 
-// Configure sets up process group for the command
-func (l *lifecycle) Configure(cmd *exec.Cmd) {
+// Configure sets up the process group for the command and applies the service's resource limits
+func (l *lifecycle) Configure(cmd *exec.Cmd, name string, limits *config.Limits) {
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	if limits != nil {
+		l.applyLimits(cmd, name, limits)
+	}
 }
 
-// Terminate gracefully stops a process and its group
-func (l *lifecycle) Terminate(proc process.Process, timeout time.Duration) error {
+// Terminate stops a process and its group: it runs the stop command if one is set, bounded by
+// stop.Timeout, sends the stop signal if the process is still running, and force-kills it once
+// stop.Grace has elapsed after the signal
+func (l *lifecycle) Terminate(proc process.Process, stop config.Stop) error {
 	cmd := proc.Cmd()
 	if cmd.Process == nil {
 		return nil
@@ -42,31 +57,75 @@ func (l *lifecycle) Terminate(proc process.Process, timeout time.Duration) error
 	pid := cmd.Process.Pid
 	l.log.Info().Msgf("Stopping service '%s' (PID: %d)", proc.Name(), pid)
 
-	groupErr := l.signalGroup(pid, syscall.SIGTERM)
+	if stop.Command != "" {
+		l.runStopCommand(proc, stop)
+
+		select {
+		case <-proc.Done():
+			return nil
+		default:
+		}
+	}
+
+	sig, ok := config.ParseSignal(stop.Signal)
+	if !ok {
+		sig = syscall.SIGTERM
+	}
+
+	groupErr := l.signalGroup(pid, sig)
 	if groupErr != nil {
-		l.log.Warn().Err(groupErr).Msgf("Failed to send SIGTERM to process group, trying direct signal")
+		l.log.Warn().Err(groupErr).Msgf("Failed to send %s to process group, trying direct signal", sig)
 	}
 
 	var directErr error
 	if groupErr != nil {
-		directErr = cmd.Process.Signal(syscall.SIGTERM)
+		directErr = cmd.Process.Signal(sig)
 	}
 
 	if directErr != nil {
-		l.log.Error().Err(directErr).Msgf("Failed to send SIGTERM to process '%s'", proc.Name())
+		l.log.Error().Err(directErr).Msgf("Failed to send %s to process '%s'", sig, proc.Name())
 
 		return l.forceKill(proc, pid)
 	}
 
+	grace := stop.Grace
+	if grace <= 0 {
+		grace = stop.Timeout
+	}
+
+	deadline := time.NewTimer(grace)
+	defer deadline.Stop()
+
 	select {
 	case <-proc.Done():
 		return nil
-	case <-time.After(timeout):
+	case <-deadline.C:
 		l.log.Warn().Msgf("Service '%s' did not stop gracefully, forcing kill", proc.Name())
 		return l.forceKill(proc, pid)
 	}
 }
 
+// runStopCommand runs the service's stop command in its directory and environment, bounded by the stop timeout
+func (l *lifecycle) runStopCommand(proc process.Process, stop config.Stop) {
+	ctx, cancel := context.WithTimeout(context.Background(), stop.Timeout)
+	defer cancel()
+
+	//nolint:gosec // stop command comes from the user's own config file
+	cmd := exec.CommandContext(ctx, "sh", "-c", stop.Command)
+	cmd.Dir = proc.Cmd().Dir
+	cmd.Env = proc.Cmd().Env
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+
+	l.log.Info().Msgf("Running stop command for service '%s': %s", proc.Name(), stop.Command)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		l.log.Warn().Err(err).Msgf("Stop command for service '%s' failed: %s", proc.Name(), strings.TrimSpace(string(out)))
+	}
+}
+
 // signalGroup sends a signal to the process group
 func (l *lifecycle) signalGroup(pid int, sig syscall.Signal) error {
 	return syscall.Kill(-pid, sig)
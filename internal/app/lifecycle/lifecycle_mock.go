@@ -11,9 +11,9 @@ package lifecycle
 
 import (
 	process "fuku/internal/app/process"
+	config "fuku/internal/config"
 	exec "os/exec"
 	reflect "reflect"
-	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,28 +42,54 @@ func (m *MockLifecycle) EXPECT() *MockLifecycleMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockLifecycle) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockLifecycleMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockLifecycle)(nil).Close))
+}
+
 // Configure mocks base method.
-func (m *MockLifecycle) Configure(cmd *exec.Cmd) {
+func (m *MockLifecycle) Configure(cmd *exec.Cmd, name string, limits *config.Limits) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Configure", cmd)
+	m.ctrl.Call(m, "Configure", cmd, name, limits)
 }
 
 // Configure indicates an expected call of Configure.
-func (mr *MockLifecycleMockRecorder) Configure(cmd any) *gomock.Call {
+func (mr *MockLifecycleMockRecorder) Configure(cmd, name, limits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockLifecycle)(nil).Configure), cmd, name, limits)
+}
+
+// Release mocks base method.
+func (m *MockLifecycle) Release(cmd *exec.Cmd) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockLifecycleMockRecorder) Release(cmd any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockLifecycle)(nil).Configure), cmd)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockLifecycle)(nil).Release), cmd)
 }
 
 // Terminate mocks base method.
-func (m *MockLifecycle) Terminate(proc process.Process, timeout time.Duration) error {
+func (m *MockLifecycle) Terminate(proc process.Process, stop config.Stop) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Terminate", proc, timeout)
+	ret := m.ctrl.Call(m, "Terminate", proc, stop)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Terminate indicates an expected call of Terminate.
-func (mr *MockLifecycleMockRecorder) Terminate(proc, timeout any) *gomock.Call {
+func (mr *MockLifecycleMockRecorder) Terminate(proc, stop any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Terminate", reflect.TypeOf((*MockLifecycle)(nil).Terminate), proc, timeout)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Terminate", reflect.TypeOf((*MockLifecycle)(nil).Terminate), proc, stop)
 }
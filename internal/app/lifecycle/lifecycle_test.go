@@ -2,6 +2,7 @@ package lifecycle
 
 import (
 	"os/exec"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"fuku/internal/app/process"
+	"fuku/internal/config"
 	"fuku/internal/config/logger"
 )
 
@@ -43,7 +45,7 @@ func Test_Configure(t *testing.T) {
 	cmd := exec.Command("echo", "test")
 	assert.Nil(t, cmd.SysProcAttr)
 
-	lc.Configure(cmd)
+	lc.Configure(cmd, "api", nil)
 
 	assert.NotNil(t, cmd.SysProcAttr)
 	assert.True(t, cmd.SysProcAttr.Setpgid)
@@ -63,7 +65,7 @@ func Test_Terminate_NilProcess(t *testing.T) {
 	mockCmd := &exec.Cmd{Process: nil}
 	mockProcess.EXPECT().Cmd().Return(mockCmd)
 
-	err := lc.Terminate(mockProcess, time.Second)
+	err := lc.Terminate(mockProcess, config.Stop{Signal: config.StopSignal, Timeout: time.Second})
 	require.NoError(t, err)
 }
 
@@ -98,7 +100,7 @@ func Test_Terminate_ProcessExitsGracefully(t *testing.T) {
 	mockProcess.EXPECT().Name().Return("test-service").AnyTimes()
 	mockProcess.EXPECT().Done().Return(done).AnyTimes()
 
-	err = lc.Terminate(mockProcess, 5*time.Second)
+	err = lc.Terminate(mockProcess, config.Stop{Signal: config.StopSignal, Timeout: 5 * time.Second})
 	require.NoError(t, err)
 
 	select {
@@ -152,7 +154,7 @@ func Test_Terminate_ProcessRequiresForceKill(t *testing.T) {
 	mockProcess.EXPECT().Name().Return("test-service").AnyTimes()
 	mockProcess.EXPECT().Done().Return(done).AnyTimes()
 
-	err = lc.Terminate(mockProcess, 100*time.Millisecond)
+	err = lc.Terminate(mockProcess, config.Stop{Signal: config.StopSignal, Timeout: 100 * time.Millisecond})
 	require.NoError(t, err)
 
 	select {
@@ -193,7 +195,7 @@ func Test_Terminate_SignalGroupFailsFallbackToDirectSignal(t *testing.T) {
 	mockProcess.EXPECT().Name().Return("test-service").AnyTimes()
 	mockProcess.EXPECT().Done().Return(done).AnyTimes()
 
-	err = lc.Terminate(mockProcess, 5*time.Second)
+	err = lc.Terminate(mockProcess, config.Stop{Signal: config.StopSignal, Timeout: 5 * time.Second})
 	require.NoError(t, err)
 }
 
@@ -231,6 +233,129 @@ func Test_Terminate_ProcessAlreadyExited(t *testing.T) {
 	mockProcess.EXPECT().Name().Return("test-service").AnyTimes()
 	mockProcess.EXPECT().Done().Return(done).AnyTimes()
 
-	err = lc.Terminate(mockProcess, time.Second)
+	err = lc.Terminate(mockProcess, config.Stop{Signal: config.StopSignal, Timeout: time.Second})
 	require.Error(t, err)
 }
+
+func Test_Terminate_CustomSignal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := logger.NewMockLogger(ctrl)
+	componentLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithComponent("LIFECYCLE").Return(componentLogger)
+	componentLogger.EXPECT().Info().Return(nil).AnyTimes()
+	componentLogger.EXPECT().Warn().Return(nil).AnyTimes()
+
+	lc := NewLifecycle(mockLogger)
+
+	cmd := exec.Command("sh", "-c", "trap '' TERM; trap 'exit 0' INT; echo ready; while true; do sleep 0.05; done")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+
+	buf := make([]byte, 6)
+	_, err = stdout.Read(buf)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+
+	go func() {
+		cmd.Wait()
+		close(done)
+	}()
+
+	mockProcess := process.NewMockProcess(ctrl)
+	mockProcess.EXPECT().Cmd().Return(cmd).AnyTimes()
+	mockProcess.EXPECT().Name().Return("test-service").AnyTimes()
+	mockProcess.EXPECT().Done().Return(done).AnyTimes()
+
+	start := time.Now()
+
+	err = lc.Terminate(mockProcess, config.Stop{Signal: "SIGINT", Timeout: 10 * time.Second})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "SIGINT should stop the process without waiting for the timeout")
+	assert.Equal(t, 0, cmd.ProcessState.ExitCode())
+}
+
+func Test_Terminate_StopCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := logger.NewMockLogger(ctrl)
+	componentLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithComponent("LIFECYCLE").Return(componentLogger)
+	componentLogger.EXPECT().Info().Return(nil).AnyTimes()
+	componentLogger.EXPECT().Warn().Return(nil).AnyTimes()
+
+	lc := NewLifecycle(mockLogger)
+
+	dir := t.TempDir()
+
+	cmd := exec.Command("sh", "-c", "trap '' TERM; while [ ! -f \"$STOP_FILE\" ]; do sleep 0.05; done")
+	cmd.Dir = dir
+	cmd.Env = []string{"STOP_FILE=stopped"}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	require.NoError(t, cmd.Start())
+
+	done := make(chan struct{})
+
+	go func() {
+		cmd.Wait()
+		close(done)
+	}()
+
+	mockProcess := process.NewMockProcess(ctrl)
+	mockProcess.EXPECT().Cmd().Return(cmd).AnyTimes()
+	mockProcess.EXPECT().Name().Return("test-service").AnyTimes()
+	mockProcess.EXPECT().Done().Return(done).AnyTimes()
+
+	err := lc.Terminate(mockProcess, config.Stop{Signal: config.StopSignal, Timeout: 10 * time.Second, Command: "touch \"$STOP_FILE\""})
+	require.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Process should have exited after the stop command")
+	}
+
+	assert.Equal(t, 0, cmd.ProcessState.ExitCode(), "process should exit on its own rather than being killed")
+	assert.FileExists(t, filepath.Join(dir, "stopped"))
+}
+
+func Test_Terminate_SignalGetsGraceAfterStopCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := logger.NewMockLogger(ctrl)
+	componentLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithComponent("LIFECYCLE").Return(componentLogger)
+	componentLogger.EXPECT().Info().Return(nil).AnyTimes()
+	componentLogger.EXPECT().Warn().Return(nil).AnyTimes()
+
+	lc := NewLifecycle(mockLogger)
+
+	cmd := exec.Command("sh", "-c", "trap 'sleep 0.3; exit 0' TERM; while true; do sleep 0.05; done")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	require.NoError(t, cmd.Start())
+
+	done := make(chan struct{})
+
+	go func() {
+		cmd.Wait()
+		close(done)
+	}()
+
+	mockProcess := process.NewMockProcess(ctrl)
+	mockProcess.EXPECT().Cmd().Return(cmd).AnyTimes()
+	mockProcess.EXPECT().Name().Return("test-service").AnyTimes()
+	mockProcess.EXPECT().Done().Return(done).AnyTimes()
+
+	stop := config.Stop{Signal: config.StopSignal, Timeout: 200 * time.Millisecond, Grace: 5 * time.Second, Command: "sleep 5"}
+	require.NoError(t, lc.Terminate(mockProcess, stop))
+
+	<-done
+	assert.Equal(t, 0, cmd.ProcessState.ExitCode(), "the stop command used up its timeout, the signal should still get its grace period")
+}
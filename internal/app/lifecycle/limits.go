@@ -0,0 +1,159 @@
+package lifecycle
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+)
+
+// limitsShellName is the name of the shell that applies rlimits before it execs the service command
+const limitsShellName = "fuku-limits"
+
+// cgroups tracks the cgroup created for each configured command
+type cgroups struct {
+	parent *cgroupParent
+	err    error
+	seq    atomic.Int64
+	mu     sync.Mutex
+	groups map[*exec.Cmd]*cgroup
+}
+
+// newCgroups creates an empty cgroup tracker
+func newCgroups() *cgroups {
+	return &cgroups{groups: make(map[*exec.Cmd]*cgroup)}
+}
+
+// applyLimits places the command in its own cgroup when cgroup v2 is delegated to fuku, and applies
+// the open files rlimit and nice value through a wrapping shell. Memory, CPU and pids limits are
+// skipped with a warning when no cgroup is available: they have no rlimit fallback, since RLIMIT_AS
+// caps address space rather than memory use and RLIMIT_NPROC counts every process of the user
+func (l *lifecycle) applyLimits(cmd *exec.Cmd, name string, limits *config.Limits) {
+	if limits.MemoryBytes() > 0 || limits.CPU > 0 || limits.PIDs > 0 {
+		if !l.placeInCgroup(cmd, name, limits) {
+			l.log.Warn().Msgf("Memory, CPU and pids limits of service '%s' are not enforced: they need a delegated cgroup v2 and have no rlimit fallback", name)
+		}
+	}
+
+	wrapWithRlimits(cmd, limits)
+}
+
+// placeInCgroup creates a cgroup for the command with the service's limits, returning false when
+// cgroups are not available
+func (l *lifecycle) placeInCgroup(cmd *exec.Cmd, name string, limits *config.Limits) bool {
+	parent, err := l.cgroupParent()
+	if err != nil {
+		return false
+	}
+
+	cg, err := openCgroup(parent, fmt.Sprintf("%s-%d", name, l.cgroups.seq.Add(1)), limits)
+	if err != nil {
+		l.log.Warn().Err(err).Msgf("Failed to create cgroup for service '%s'", name)
+
+		return false
+	}
+
+	cg.attach(cmd)
+
+	l.cgroups.mu.Lock()
+	l.cgroups.groups[cmd] = cg
+	l.cgroups.mu.Unlock()
+
+	return true
+}
+
+// cgroupParent returns the cgroup that service cgroups are created in, setting it up on first use
+func (l *lifecycle) cgroupParent() (string, error) {
+	l.cgroups.mu.Lock()
+	defer l.cgroups.mu.Unlock()
+
+	if l.cgroups.parent == nil && l.cgroups.err == nil {
+		l.cgroups.parent, l.cgroups.err = setupCgroupParent()
+		if l.cgroups.err != nil {
+			l.log.Info().Err(l.cgroups.err).Msg("Resource limits other than open files and nice are unavailable")
+		}
+	}
+
+	if l.cgroups.err != nil {
+		return "", l.cgroups.err
+	}
+
+	return l.cgroups.parent.dir, nil
+}
+
+// Close undoes the cgroup changes fuku made for its services, once they have all been released
+func (l *lifecycle) Close() {
+	l.cgroups.mu.Lock()
+	defer l.cgroups.mu.Unlock()
+
+	if l.cgroups.parent == nil {
+		return
+	}
+
+	if len(l.cgroups.groups) > 0 {
+		l.log.Warn().Msgf("Leaving cgroup %s in place, %d service cgroup(s) remain", l.cgroups.parent.leaf, len(l.cgroups.groups))
+
+		return
+	}
+
+	if err := l.cgroups.parent.restore(); err != nil {
+		l.log.Warn().Err(err).Msgf("Failed to restore cgroup %s", l.cgroups.parent.dir)
+	}
+
+	l.cgroups.parent = nil
+}
+
+// Release removes the cgroup of a finished command, reporting when it was killed for exceeding its memory limit
+func (l *lifecycle) Release(cmd *exec.Cmd) error {
+	l.cgroups.mu.Lock()
+	cg, ok := l.cgroups.groups[cmd]
+	delete(l.cgroups.groups, cmd)
+	l.cgroups.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	oomKilled := cg.oomKilled()
+
+	if err := cg.remove(); err != nil {
+		l.log.Warn().Err(err).Msgf("Failed to remove cgroup %s", cg.path)
+	}
+
+	if oomKilled {
+		return fmt.Errorf("%w of %s", errors.ErrOutOfMemory, cg.memory)
+	}
+
+	return nil
+}
+
+// wrapWithRlimits runs the command through a shell that sets the open files rlimit and the nice value
+// before it execs the original command
+func wrapWithRlimits(cmd *exec.Cmd, limits *config.Limits) {
+	var steps []string
+
+	if limits.NoFile > 0 {
+		steps = append(steps, fmt.Sprintf("ulimit -n %d", limits.NoFile))
+	}
+
+	if len(steps) == 0 && limits.Nice == 0 {
+		return
+	}
+
+	run := `exec "$@"`
+	if limits.Nice != 0 {
+		run = fmt.Sprintf(`exec nice -n %d "$@"`, limits.Nice)
+	}
+
+	shell, err := exec.LookPath("sh")
+	if err != nil {
+		return
+	}
+
+	cmd.Args = append([]string{"sh", "-c", strings.Join(append(steps, run), "; "), limitsShellName}, cmd.Args...)
+	cmd.Path = shell
+}
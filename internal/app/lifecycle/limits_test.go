@@ -0,0 +1,74 @@
+package lifecycle
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func Test_WrapWithRlimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   config.Limits
+		expected []string
+	}{
+		{
+			name:     "no rlimits",
+			limits:   config.Limits{CPU: 2, PIDs: 100},
+			expected: []string{"make", "run"},
+		},
+		{
+			name:     "open files and nice",
+			limits:   config.Limits{NoFile: 4096, Nice: 10},
+			expected: []string{"sh", "-c", `ulimit -n 4096; exec nice -n 10 "$@"`, limitsShellName, "make", "run"},
+		},
+		{
+			name:     "memory is never an address space rlimit",
+			limits:   config.Limits{Memory: "512M"},
+			expected: []string{"make", "run"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command("make", "run")
+
+			wrapWithRlimits(cmd, &tt.limits)
+
+			assert.Equal(t, tt.expected, cmd.Args)
+		})
+	}
+}
+
+func Test_Configure_AppliesRlimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Info().Return(nil).AnyTimes()
+	mockLogger.EXPECT().Warn().Return(nil).AnyTimes()
+
+	lc := &lifecycle{cgroups: newCgroups(), log: mockLogger}
+	lc.cgroups.err = errors.ErrCgroupsUnavailable
+
+	cmd := exec.Command("sh", "-c", "ulimit -n; ulimit -v; nice")
+	lc.Configure(cmd, "api", &config.Limits{NoFile: 321, Nice: 5, CPU: 1, Memory: "2G"})
+
+	assert.True(t, cmd.SysProcAttr.Setpgid)
+
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"321", "unlimited", "5"}, strings.Fields(string(out)))
+
+	assert.NoError(t, lc.Release(cmd))
+
+	lc.Close()
+}
@@ -1,10 +1,26 @@
 package lifecycle
 
-import "go.uber.org/fx"
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
 
 // Module provides the lifecycle and its dependencies
 var Module = fx.Options(
 	fx.Provide(
 		NewLifecycle,
 	),
+	fx.Invoke(closeLifecycle),
 )
+
+// closeLifecycle undoes the lifecycle's cgroup changes when the app stops, after the services are gone
+func closeLifecycle(lc fx.Lifecycle, l Lifecycle) {
+	lc.Append(fx.Hook{
+		OnStop: func(_ context.Context) error {
+			l.Close()
+
+			return nil
+		},
+	})
+}
@@ -54,7 +54,13 @@ func terminalWidth() int {
 
 // Run handles the logs command to stream logs from a running instance
 func (s *screen) Run(ctx context.Context, profile string, services []string) int {
-	socketPath, err := relay.FindSocket(config.SocketDir, profile)
+	socketDir, err := relay.SocketDir()
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to find socket")
+		return 1
+	}
+
+	socketPath, err := relay.FindSocket(socketDir, profile)
 	if err != nil {
 		s.log.Error().Err(err).Msg("Failed to find socket")
 		return 1
@@ -116,3 +122,6 @@ func (h *screenHandler) HandleLog(msg relay.LogMessage) {
 	//nolint:errcheck // best-effort write to output
 	io.WriteString(h.out, line)
 }
+
+// HandleOutput ignores raw output, which is only sent to attached clients
+func (h *screenHandler) HandleOutput(relay.OutputMessage) {}
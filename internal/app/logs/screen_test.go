@@ -195,7 +195,11 @@ func Test_screen_Run(t *testing.T) {
 		defer ctrl.Finish()
 
 		profile := "screen-run-test"
-		socketPath := relay.SocketPathForProfile(config.SocketDir, profile)
+		socketDir, err := relay.SocketDir()
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(socketDir, 0o700))
+
+		socketPath := relay.SocketPathForProfile(socketDir, profile)
 
 		ln, err := net.Listen("unix", socketPath)
 		require.NoError(t, err)
@@ -6,8 +6,10 @@ import (
 	"go.uber.org/fx"
 
 	"fuku/internal/app/api"
+	"fuku/internal/app/attach"
 	"fuku/internal/app/bus"
 	"fuku/internal/app/cli"
+	"fuku/internal/app/daemon"
 	"fuku/internal/app/logs"
 	"fuku/internal/app/metrics"
 	"fuku/internal/app/monitor"
@@ -22,8 +24,10 @@ import (
 // Module provides the fx dependency injection options for the app package
 var Module = fx.Options(
 	api.Module,
+	attach.Module,
 	bus.Module,
 	cli.Module,
+	daemon.Module,
 	logs.Module,
 	metrics.Module,
 	monitor.Module,
@@ -6,19 +6,28 @@ import (
 	"sync"
 	"time"
 
+	"github.com/shirou/gopsutil/v4/net"
 	"github.com/shirou/gopsutil/v4/process"
 )
 
 // Stats contains process resource statistics
 type Stats struct {
-	CPU    float64
-	MEM    float64 // in MB
-	RawMEM uint64  // in bytes
+	CPU       float64
+	MEM       float64 // in MB
+	RawMEM    uint64  // in bytes
+	Processes int     // processes in the tree, 0 for a single process sample
+	PIDs      []int   // the root followed by its descendants, nil for a single process sample
 }
 
+// ProcessTable maps each process to its children, read once per sampling tick
+type ProcessTable map[int32][]int32
+
 // Monitor provides process resource monitoring
 type Monitor interface {
 	GetStats(ctx context.Context, pid int) (Stats, error)
+	ProcessTable(ctx context.Context) (ProcessTable, error)
+	GetTreeStats(ctx context.Context, table ProcessTable, pid int) (Stats, error)
+	ListenerPID(ctx context.Context, pids []int) int
 }
 
 type cpuState struct {
@@ -29,14 +38,16 @@ type cpuState struct {
 
 // monitor implements the Monitor interface
 type monitor struct {
-	mu   sync.Mutex
-	prev map[int32]cpuState
+	mu    sync.Mutex
+	prev  map[int32]cpuState
+	trees map[int32]map[int32]struct{}
 }
 
 // NewMonitor creates a new Monitor instance
 func NewMonitor() Monitor {
 	return &monitor{
-		prev: make(map[int32]cpuState),
+		prev:  make(map[int32]cpuState),
+		trees: make(map[int32]map[int32]struct{}),
 	}
 }
 
@@ -55,6 +66,99 @@ func (m *monitor) GetStats(ctx context.Context, pid int) (Stats, error) {
 		return Stats{}, err
 	}
 
+	return m.sample(ctx, proc), nil
+}
+
+// ProcessTable reads the parent of every process once, so the trees of all services can be walked
+// without scanning the process list again for each of their members
+func (m *monitor) ProcessTable(ctx context.Context) (ProcessTable, error) {
+	pids, err := process.PidsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	table := make(ProcessTable, len(pids))
+
+	for _, pid := range pids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		proc := &process.Process{Pid: pid}
+
+		ppid, err := proc.PpidWithContext(ctx)
+		if err != nil || ppid == pid {
+			continue
+		}
+
+		table[ppid] = append(table[ppid], pid)
+	}
+
+	return table, nil
+}
+
+// GetTreeStats retrieves CPU and memory statistics summed over a process and all of its descendants,
+// so a server started through a shell or a build tool is counted under the service that launched it.
+// A sample cut short by ctx is discarded rather than reported with partial sums
+func (m *monitor) GetTreeStats(ctx context.Context, table ProcessTable, pid int) (Stats, error) {
+	if pid <= 0 || pid > math.MaxInt32 {
+		return Stats{}, nil
+	}
+
+	pid32 := int32(pid) // #nosec G115 -- PID range checked above
+
+	root, err := process.NewProcessWithContext(ctx, pid32)
+	if err != nil {
+		m.evictTree(pid32)
+
+		return Stats{}, err
+	}
+
+	tree := table.tree(root.Pid)
+	stats := Stats{Processes: len(tree), PIDs: make([]int, 0, len(tree))}
+	members := make(map[int32]struct{}, len(tree))
+
+	for _, member := range tree {
+		if ctx.Err() != nil {
+			break
+		}
+
+		members[member] = struct{}{}
+		stats.PIDs = append(stats.PIDs, int(member))
+
+		sample := m.sample(ctx, &process.Process{Pid: member})
+		stats.CPU += sample.CPU
+		stats.RawMEM += sample.RawMEM
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Stats{}, err
+	}
+
+	stats.MEM = float64(stats.RawMEM) / 1024 / 1024
+
+	m.replaceTree(pid32, members)
+
+	return stats, nil
+}
+
+// ListenerPID returns the first of pids that holds a listening TCP socket, or 0 when none does
+func (m *monitor) ListenerPID(ctx context.Context, pids []int) int {
+	for _, pid := range pids {
+		if ctx.Err() != nil || pid <= 0 || pid > math.MaxInt32 {
+			return 0
+		}
+
+		if isListening(ctx, int32(pid)) { // #nosec G115 -- PID range checked above
+			return pid
+		}
+	}
+
+	return 0
+}
+
+// sample reads the CPU and memory usage of a single process
+func (m *monitor) sample(ctx context.Context, proc *process.Process) Stats {
 	stats := Stats{}
 
 	times, timesErr := proc.TimesWithContext(ctx)
@@ -62,9 +166,9 @@ func (m *monitor) GetStats(ctx context.Context, pid int) (Stats, error) {
 
 	switch {
 	case timesErr != nil || ctErr != nil:
-		m.evict(pid32)
+		m.evict(proc.Pid)
 	default:
-		stats.CPU = m.cpuPercent(pid32, createTime, times.User+times.System)
+		stats.CPU = m.cpuPercent(proc.Pid, createTime, times.User+times.System)
 	}
 
 	memInfo, err := proc.MemoryInfoWithContext(ctx)
@@ -73,7 +177,40 @@ func (m *monitor) GetStats(ctx context.Context, pid int) (Stats, error) {
 		stats.RawMEM = memInfo.RSS
 	}
 
-	return stats, nil
+	return stats
+}
+
+// tree returns root followed by its descendants in breadth-first order
+func (t ProcessTable) tree(root int32) []int32 {
+	tree := []int32{root}
+	seen := map[int32]bool{root: true}
+
+	for i := 0; i < len(tree); i++ {
+		for _, child := range t[tree[i]] {
+			if !seen[child] {
+				seen[child] = true
+				tree = append(tree, child)
+			}
+		}
+	}
+
+	return tree
+}
+
+// isListening reports whether a process holds a listening TCP socket
+func isListening(ctx context.Context, pid int32) bool {
+	conns, err := net.ConnectionsPidWithContext(ctx, "tcp", pid)
+	if err != nil {
+		return false
+	}
+
+	for _, conn := range conns {
+		if conn.Status == "LISTEN" {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (m *monitor) evict(pid int32) {
@@ -83,6 +220,33 @@ func (m *monitor) evict(pid int32) {
 	delete(m.prev, pid)
 }
 
+// replaceTree records the current members of a tree and forgets the CPU state of processes that left it
+func (m *monitor) replaceTree(root int32, members map[int32]struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for pid := range m.trees[root] {
+		if _, ok := members[pid]; !ok {
+			delete(m.prev, pid)
+		}
+	}
+
+	m.trees[root] = members
+}
+
+// evictTree forgets a tree and the CPU state of all of its processes
+func (m *monitor) evictTree(root int32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for pid := range m.trees[root] {
+		delete(m.prev, pid)
+	}
+
+	delete(m.prev, root)
+	delete(m.trees, root)
+}
+
 func (m *monitor) cpuPercent(pid int32, createTime int64, total float64) float64 {
 	now := time.Now()
 
@@ -54,3 +54,47 @@ func (mr *MockMonitorMockRecorder) GetStats(ctx, pid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockMonitor)(nil).GetStats), ctx, pid)
 }
+
+// GetTreeStats mocks base method.
+func (m *MockMonitor) GetTreeStats(ctx context.Context, table ProcessTable, pid int) (Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeStats", ctx, table, pid)
+	ret0, _ := ret[0].(Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeStats indicates an expected call of GetTreeStats.
+func (mr *MockMonitorMockRecorder) GetTreeStats(ctx, table, pid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeStats", reflect.TypeOf((*MockMonitor)(nil).GetTreeStats), ctx, table, pid)
+}
+
+// ListenerPID mocks base method.
+func (m *MockMonitor) ListenerPID(ctx context.Context, pids []int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenerPID", ctx, pids)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// ListenerPID indicates an expected call of ListenerPID.
+func (mr *MockMonitorMockRecorder) ListenerPID(ctx, pids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenerPID", reflect.TypeOf((*MockMonitor)(nil).ListenerPID), ctx, pids)
+}
+
+// ProcessTable mocks base method.
+func (m *MockMonitor) ProcessTable(ctx context.Context) (ProcessTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessTable", ctx)
+	ret0, _ := ret[0].(ProcessTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessTable indicates an expected call of ProcessTable.
+func (mr *MockMonitorMockRecorder) ProcessTable(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTable", reflect.TypeOf((*MockMonitor)(nil).ProcessTable), ctx)
+}
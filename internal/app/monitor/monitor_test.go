@@ -2,8 +2,11 @@ package monitor
 
 import (
 	"context"
+	"net"
 	"os"
+	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -102,3 +105,91 @@ func TestGetStats_CancelledContext(t *testing.T) {
 		assert.GreaterOrEqual(t, stats.CPU, 0.0)
 	}
 }
+
+func TestGetTreeStats_InvalidPID(t *testing.T) {
+	m := NewMonitor()
+
+	stats, err := m.GetTreeStats(context.Background(), ProcessTable{}, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, Stats{}, stats)
+}
+
+func TestGetTreeStats_SumsDescendants(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	file, err := ln.(*net.TCPListener).File()
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+
+	defer file.Close()
+
+	// The shell forks a child that inherits the listening socket, so the tree is sh -> sleep
+	cmd := exec.Command("sh", "-c", "sleep 5 & wait")
+	cmd.ExtraFiles = []*os.File{file}
+	require.NoError(t, cmd.Start())
+
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	m := NewMonitor()
+	ctx := context.Background()
+
+	var stats Stats
+
+	require.Eventually(t, func() bool {
+		table, err := m.ProcessTable(ctx)
+		if err != nil {
+			return false
+		}
+
+		stats, err = m.GetTreeStats(ctx, table, cmd.Process.Pid)
+
+		return err == nil && stats.Processes == 2
+	}, 2*time.Second, 20*time.Millisecond)
+
+	single, err := m.GetStats(ctx, cmd.Process.Pid)
+	require.NoError(t, err)
+
+	assert.Greater(t, stats.RawMEM, single.RawMEM)
+	assert.InDelta(t, float64(stats.RawMEM)/1024/1024, stats.MEM, 0.001)
+	require.Len(t, stats.PIDs, 2)
+	assert.Equal(t, cmd.Process.Pid, stats.PIDs[0])
+	assert.Equal(t, cmd.Process.Pid, m.ListenerPID(ctx, stats.PIDs))
+}
+
+func TestGetTreeStats_DiscardsCancelledSample(t *testing.T) {
+	m := NewMonitor()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	table := ProcessTable{int32(os.Getpid()): {int32(os.Getppid())}}
+
+	stats, err := m.GetTreeStats(ctx, table, os.Getpid())
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, Stats{}, stats)
+}
+
+func TestProcessTable_Tree(t *testing.T) {
+	table := ProcessTable{
+		1: {2, 3},
+		2: {4},
+		4: {2},
+		5: {6},
+	}
+
+	assert.Equal(t, []int32{1, 2, 3, 4}, table.tree(1))
+	assert.Equal(t, []int32{7}, table.tree(7))
+}
+
+func TestGetTreeStats_NonExistentProcess(t *testing.T) {
+	m := NewMonitor()
+
+	_, err := m.GetTreeStats(context.Background(), ProcessTable{}, 999999999)
+
+	require.Error(t, err)
+}
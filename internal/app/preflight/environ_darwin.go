@@ -0,0 +1,13 @@
+package preflight
+
+import "golang.org/x/sys/unix"
+
+// environ reads a process environment from the kern.procargs2 sysctl
+func environ(pid int32) ([]string, error) {
+	data, err := unix.SysctlRaw("kern.procargs2", int(pid))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProcArgs(data), nil
+}
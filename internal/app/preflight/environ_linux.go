@@ -0,0 +1,16 @@
+package preflight
+
+import (
+	"os"
+	"strconv"
+)
+
+// environ reads a process environment from /proc
+func environ(pid int32) ([]string, error) {
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(int(pid)) + "/environ")
+	if err != nil {
+		return nil, err
+	}
+
+	return splitEnviron(data), nil
+}
@@ -17,15 +17,26 @@ import (
 	"fuku/internal/config/logger"
 )
 
-// Result represents a process killed during preflight
+// How a process was matched to a service
+const (
+	MatchSession = "session"
+	MatchTag     = "tag"
+	MatchCwd     = "cwd"
+)
+
+// Result represents a process belonging to a service, found or killed during preflight
 type Result struct {
 	Service string
 	Name    string
 	PID     int32
+	Dir     string
+	Match   string
 }
 
 // Preflight handles pre-start cleanup of orphaned processes
 type Preflight interface {
+	Find(dirs map[string]string) ([]Result, error)
+	Kill(ctx context.Context, targets []Result) []Result
 	Cleanup(ctx context.Context, dirs map[string]string) ([]Result, error)
 }
 
@@ -34,37 +45,55 @@ type entry struct {
 	name string
 	dir  string
 	pid  int32
-}
-
-// match holds a process matched to a service
-type match struct {
-	service string
-	entry   entry
+	tag  tag
 }
 
 type scanFunc func() ([]entry, error)
 type killFunc func(pid int32) error
 
 type preflight struct {
-	scan   scanFunc
-	kill   killFunc
-	bus    bus.Bus
-	worker worker.Pool
-	log    logger.Logger
+	scan     scanFunc
+	kill     killFunc
+	project  string
+	session  string
+	matchCwd bool
+	bus      bus.Bus
+	worker   worker.Pool
+	log      logger.Logger
 }
 
 // NewPreflight creates a new Preflight instance
-func NewPreflight(bus bus.Bus, worker worker.Pool, log logger.Logger) Preflight {
+func NewPreflight(cfg *config.Config, bus bus.Bus, worker worker.Pool, log logger.Logger) Preflight {
+	project, _ := os.Getwd()
+
 	return &preflight{
-		scan:   scan,
-		kill:   kill,
-		bus:    bus,
-		worker: worker,
-		log:    log.WithComponent("PREFLIGHT"),
+		scan:     scan,
+		kill:     kill,
+		project:  project,
+		session:  session,
+		matchCwd: cfg.Preflight.MatchCwd,
+		bus:      bus,
+		worker:   worker,
+		log:      log.WithComponent("PREFLIGHT"),
+	}
+}
+
+// Find returns the running processes that fuku started for the services in earlier sessions,
+// plus untagged processes in a service directory when cwd matching is enabled
+func (p *preflight) Find(dirs map[string]string) ([]Result, error) {
+	if len(dirs) == 0 {
+		return nil, nil
 	}
+
+	return p.matchProcesses(dirs)
+}
+
+// Kill kills the given processes, returning those it attempted
+func (p *preflight) Kill(ctx context.Context, targets []Result) []Result {
+	return p.killMatches(ctx, targets)
 }
 
-// Cleanup scans running processes and kills any whose working directory matches a service directory
+// Cleanup scans running processes and kills any left behind by an earlier session of the services
 func (p *preflight) Cleanup(ctx context.Context, dirs map[string]string) ([]Result, error) {
 	if len(dirs) == 0 {
 		return nil, nil
@@ -101,37 +130,74 @@ func (p *preflight) Cleanup(ctx context.Context, dirs map[string]string) ([]Resu
 	return results, nil
 }
 
-// matchProcesses scans running processes and returns those matching service directories
-func (p *preflight) matchProcesses(dirs map[string]string) ([]match, error) {
+// matchProcesses scans running processes and returns those tagged for the services by another session
+// of this project, and with cwd matching enabled, untagged processes whose working directory is a service directory
+func (p *preflight) matchProcesses(dirs map[string]string) ([]Result, error) {
 	processes, err := p.scan()
 	if err != nil {
 		return nil, err
 	}
 
 	ownPID := int32(os.Getpid()) // #nosec G115 -- PID fits in int32
-	matches := make([]match, 0, len(processes))
+	matches := make([]Result, 0, len(processes))
 
 	for _, proc := range processes {
 		if proc.pid == ownPID {
 			continue
 		}
 
-		for service, dir := range dirs {
-			if proc.dir != dir {
-				continue
-			}
-
-			matches = append(matches, match{service: service, entry: proc})
+		service, ok := p.matchProcess(proc, dirs)
+		if !ok {
+			continue
+		}
 
-			break
+		result := Result{Service: service, Name: proc.name, PID: proc.pid, Dir: proc.dir, Match: MatchCwd}
+		if proc.tag.service != "" {
+			result.Match = MatchTag
 		}
+
+		matches = append(matches, result)
 	}
 
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Service != matches[j].Service {
+			return matches[i].Service < matches[j].Service
+		}
+
+		return matches[i].PID < matches[j].PID
+	})
+
 	return matches, nil
 }
 
+// matchProcess returns the service a process belongs to. Tagged processes match on their tags alone,
+// so a fuku-started process is found wherever it runs and an unrelated one in the directory is left alone
+func (p *preflight) matchProcess(proc entry, dirs map[string]string) (string, bool) {
+	if proc.tag.service != "" {
+		if proc.tag.project != p.project || proc.tag.session == p.session {
+			return "", false
+		}
+
+		_, ok := dirs[proc.tag.service]
+
+		return proc.tag.service, ok
+	}
+
+	if !p.matchCwd || proc.dir == "" {
+		return "", false
+	}
+
+	for service, dir := range dirs {
+		if proc.dir == dir {
+			return service, true
+		}
+	}
+
+	return "", false
+}
+
 // killMatches kills matched processes concurrently using the worker pool
-func (p *preflight) killMatches(ctx context.Context, matches []match) []Result {
+func (p *preflight) killMatches(ctx context.Context, matches []Result) []Result {
 	if len(matches) == 0 {
 		return nil
 	}
@@ -151,33 +217,27 @@ func (p *preflight) killMatches(ctx context.Context, matches []match) []Result {
 
 		wg.Add(1)
 
-		go func(m match) {
+		go func(m Result) {
 			defer wg.Done()
 			defer p.worker.Release()
 
-			p.log.Info().Msgf("Killing process '%s' (PID: %d) in '%s' for service '%s'", m.entry.name, m.entry.pid, m.entry.dir, m.service)
+			p.log.Info().Msgf("Killing process '%s' (PID: %d) in '%s' for service '%s'", m.Name, m.PID, m.Dir, m.Service)
 
 			p.bus.Publish(bus.Message{
 				Type: bus.EventPreflightKill,
 				Data: bus.PreflightKill{
-					Service: m.service,
-					PID:     int(m.entry.pid),
-					Name:    m.entry.name,
+					Service: m.Service,
+					PID:     int(m.PID),
+					Name:    m.Name,
 				},
 			})
 
-			if err := p.kill(m.entry.pid); err != nil {
-				p.log.Warn().Err(err).Msgf("Failed to kill process %d", m.entry.pid)
+			if err := p.kill(m.PID); err != nil {
+				p.log.Warn().Err(err).Msgf("Failed to kill process %d", m.PID)
 			}
 
 			mu.Lock()
-
-			results = append(results, Result{
-				Service: m.service,
-				Name:    m.entry.name,
-				PID:     m.entry.pid,
-			})
-
+			results = append(results, m)
 			mu.Unlock()
 		}(m)
 	}
@@ -196,8 +256,10 @@ func scan() ([]entry, error) {
 	results := make([]entry, 0, len(processes))
 
 	for _, p := range processes {
-		dir, err := p.Cwd()
-		if err != nil {
+		env, envErr := environ(p.Pid)
+		dir, dirErr := p.Cwd()
+
+		if envErr != nil && dirErr != nil {
 			continue
 		}
 
@@ -206,6 +268,7 @@ func scan() ([]entry, error) {
 			name: name,
 			dir:  dir,
 			pid:  p.Pid,
+			tag:  parseTag(env),
 		})
 	}
 
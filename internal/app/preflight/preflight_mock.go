@@ -40,6 +40,35 @@ func (m *MockPreflight) EXPECT() *MockPreflightMockRecorder {
 	return m.recorder
 }
 
+// Find mocks base method.
+func (m *MockPreflight) Find(dirs map[string]string) ([]Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", dirs)
+	ret0, _ := ret[0].([]Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockPreflightMockRecorder) Find(dirs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockPreflight)(nil).Find), dirs)
+}
+
+// Kill mocks base method.
+func (m *MockPreflight) Kill(ctx context.Context, targets []Result) []Result {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Kill", ctx, targets)
+	ret0, _ := ret[0].([]Result)
+	return ret0
+}
+
+// Kill indicates an expected call of Kill.
+func (mr *MockPreflightMockRecorder) Kill(ctx, targets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kill", reflect.TypeOf((*MockPreflight)(nil).Kill), ctx, targets)
+}
+
 // Cleanup mocks base method.
 func (m *MockPreflight) Cleanup(ctx context.Context, dirs map[string]string) ([]Result, error) {
 	m.ctrl.T.Helper()
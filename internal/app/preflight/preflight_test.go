@@ -14,6 +14,7 @@ import (
 
 	"fuku/internal/app/bus"
 	"fuku/internal/app/worker"
+	"fuku/internal/config"
 	"fuku/internal/config/logger"
 )
 
@@ -26,9 +27,14 @@ func Test_NewPreflight(t *testing.T) {
 
 	mockWorker := worker.NewMockPool(ctrl)
 
-	p := NewPreflight(bus.NoOp(), mockWorker, mockLog)
+	cfg := config.DefaultConfig()
+	cfg.Preflight.MatchCwd = true
 
-	assert.NotNil(t, p)
+	p := NewPreflight(cfg, bus.NoOp(), mockWorker, mockLog)
+
+	require.NotNil(t, p)
+	assert.True(t, p.(*preflight).matchCwd)
+	assert.Equal(t, Session(), p.(*preflight).session)
 }
 
 func Test_Cleanup(t *testing.T) {
@@ -150,11 +156,12 @@ func Test_Cleanup(t *testing.T) {
 			}
 
 			p := &preflight{
-				bus:    bus.NoOp(),
-				log:    mockLog,
-				scan:   scan,
-				kill:   kill,
-				worker: mockWorker,
+				bus:      bus.NoOp(),
+				log:      mockLog,
+				scan:     scan,
+				kill:     kill,
+				matchCwd: true,
+				worker:   mockWorker,
 			}
 
 			results, err := p.Cleanup(context.Background(), tt.dirs)
@@ -188,11 +195,12 @@ func Test_Cleanup_ResultFields(t *testing.T) {
 	mockWorker.EXPECT().Release().AnyTimes()
 
 	p := &preflight{
-		bus:    bus.NoOp(),
-		log:    mockLog,
-		scan:   scan,
-		kill:   kill,
-		worker: mockWorker,
+		bus:      bus.NoOp(),
+		log:      mockLog,
+		scan:     scan,
+		kill:     kill,
+		matchCwd: true,
+		worker:   mockWorker,
 	}
 
 	results, err := p.Cleanup(context.Background(), map[string]string{
@@ -229,11 +237,12 @@ func Test_Cleanup_MatchesExactDirectory(t *testing.T) {
 	mockWorker.EXPECT().Release().AnyTimes()
 
 	p := &preflight{
-		bus:    bus.NoOp(),
-		log:    mockLog,
-		scan:   scan,
-		kill:   kill,
-		worker: mockWorker,
+		bus:      bus.NoOp(),
+		log:      mockLog,
+		scan:     scan,
+		kill:     kill,
+		matchCwd: true,
+		worker:   mockWorker,
 	}
 
 	results, err := p.Cleanup(context.Background(), map[string]string{
@@ -276,11 +285,12 @@ func Test_Cleanup_ContextCancellationStopsKills(t *testing.T) {
 	mockWorker.EXPECT().Acquire(gomock.Any()).Return(context.Canceled).AnyTimes()
 
 	p := &preflight{
-		bus:    bus.NoOp(),
-		log:    mockLog,
-		scan:   scan,
-		kill:   kill,
-		worker: mockWorker,
+		bus:      bus.NoOp(),
+		log:      mockLog,
+		scan:     scan,
+		kill:     kill,
+		matchCwd: true,
+		worker:   mockWorker,
 	}
 
 	results, err := p.Cleanup(ctx, map[string]string{
@@ -294,6 +304,155 @@ func Test_Cleanup_ContextCancellationStopsKills(t *testing.T) {
 	assert.Equal(t, killCount.Load(), int32(len(results)))
 }
 
+func Test_Find_Tags(t *testing.T) {
+	tagged := func(pid int32, service, session, project string) entry {
+		return entry{pid: pid, name: "node", dir: "/elsewhere", tag: tag{service: service, session: session, project: project}}
+	}
+
+	tests := []struct {
+		name      string
+		matchCwd  bool
+		processes []entry
+		expected  []Result
+	}{
+		{
+			name: "tagged process of an earlier session matches wherever it runs",
+			processes: []entry{
+				tagged(100, "api", "old", "/project"),
+			},
+			expected: []Result{{Service: "api", Name: "node", PID: 100, Dir: "/elsewhere", Match: MatchTag}},
+		},
+		{
+			name: "own session is left alone",
+			processes: []entry{
+				tagged(100, "api", "current", "/project"),
+			},
+			expected: []Result{},
+		},
+		{
+			name: "other projects and services are left alone",
+			processes: []entry{
+				tagged(100, "api", "old", "/other-project"),
+				tagged(200, "worker", "old", "/project"),
+			},
+			expected: []Result{},
+		},
+		{
+			name: "untagged process in a service directory is ignored by default",
+			processes: []entry{
+				{pid: 100, name: "gopls", dir: "/project/api"},
+			},
+			expected: []Result{},
+		},
+		{
+			name:     "untagged process matches by cwd when enabled",
+			matchCwd: true,
+			processes: []entry{
+				{pid: 100, name: "node", dir: "/project/api"},
+			},
+			expected: []Result{{Service: "api", Name: "node", PID: 100, Dir: "/project/api", Match: MatchCwd}},
+		},
+		{
+			name:     "tags win over cwd",
+			matchCwd: true,
+			processes: []entry{
+				{pid: 100, name: "node", dir: "/project/api", tag: tag{service: "api", session: "current", project: "/project"}},
+			},
+			expected: []Result{},
+		},
+		{
+			name: "results are sorted by service and PID",
+			processes: []entry{
+				tagged(300, "web", "old", "/project"),
+				tagged(200, "api", "old", "/project"),
+				tagged(100, "web", "old", "/project"),
+			},
+			expected: []Result{
+				{Service: "api", Name: "node", PID: 200, Dir: "/elsewhere", Match: MatchTag},
+				{Service: "web", Name: "node", PID: 100, Dir: "/elsewhere", Match: MatchTag},
+				{Service: "web", Name: "node", PID: 300, Dir: "/elsewhere", Match: MatchTag},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &preflight{
+				scan: func() ([]entry, error) {
+					return tt.processes, nil
+				},
+				project:  "/project",
+				session:  "current",
+				matchCwd: tt.matchCwd,
+			}
+
+			results, err := p.Find(map[string]string{"api": "/project/api", "web": "/project/web"})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, results)
+		})
+	}
+}
+
+func Test_Kill_Targets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+
+	mockWorker := worker.NewMockPool(ctrl)
+	mockWorker.EXPECT().Acquire(gomock.Any()).Return(nil).Times(2)
+	mockWorker.EXPECT().Release().Times(2)
+
+	var killed []int32
+
+	p := &preflight{
+		bus:    bus.NoOp(),
+		log:    mockLog,
+		worker: mockWorker,
+		kill: func(pid int32) error {
+			killed = append(killed, pid)
+			return nil
+		},
+	}
+
+	targets := []Result{{Service: "api", PID: 100}}
+	results := p.Kill(context.Background(), targets)
+	assert.Equal(t, targets, results)
+	assert.Equal(t, []int32{100}, killed)
+
+	second := []Result{{Service: "web", PID: 200}}
+	assert.Equal(t, second, p.Kill(context.Background(), second))
+	assert.Equal(t, []int32{100, 200}, killed)
+}
+
+func Test_Scan_ReadsTags(t *testing.T) {
+	cmd := exec.Command("sleep", "5")
+	cmd.Env = append(os.Environ(), Tags("api")...)
+	require.NoError(t, cmd.Start())
+
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	entries, err := scan()
+	require.NoError(t, err)
+
+	project, err := os.Getwd()
+	require.NoError(t, err)
+
+	for _, e := range entries {
+		if int(e.pid) == cmd.Process.Pid {
+			assert.Equal(t, tag{service: "api", session: Session(), project: project}, e.tag)
+			return
+		}
+	}
+
+	t.Fatal("tagged process not found")
+}
+
 func Test_Scan(t *testing.T) {
 	entries, err := scan()
 
@@ -0,0 +1,113 @@
+package preflight
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Environment variables that mark a process as started by fuku
+const (
+	EnvService = "FUKU_SERVICE"
+	EnvSession = "FUKU_SESSION"
+	EnvProject = "FUKU_PROJECT"
+)
+
+// session identifies this fuku process in the tags of everything it starts
+var session = uuid.NewString()
+
+// tag holds the fuku markers found in a process environment
+type tag struct {
+	service string
+	session string
+	project string
+}
+
+// Session returns the identifier of this fuku process
+func Session() string {
+	return session
+}
+
+// Tags returns the environment entries that mark a process as started for a service by this session.
+// The project is the current working directory, which is the config file's directory
+func Tags(service string) []string {
+	project, _ := os.Getwd()
+
+	return []string{
+		EnvService + "=" + service,
+		EnvSession + "=" + session,
+		EnvProject + "=" + project,
+	}
+}
+
+// parseTag extracts the fuku markers from a process environment
+func parseTag(environ []string) tag {
+	var t tag
+
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case EnvService:
+			t.service = value
+		case EnvSession:
+			t.session = value
+		case EnvProject:
+			t.project = value
+		}
+	}
+
+	return t
+}
+
+// splitEnviron splits a NUL separated environment block into its entries
+func splitEnviron(data []byte) []string {
+	var environ []string
+
+	for entry := range bytes.SplitSeq(data, []byte{0}) {
+		if len(entry) > 0 {
+			environ = append(environ, string(entry))
+		}
+	}
+
+	return environ
+}
+
+// parseProcArgs extracts the environment from a KERN_PROCARGS2 buffer, laid out as argc, the executable
+// path, NUL padding, argc arguments and then the environment, each NUL terminated
+func parseProcArgs(data []byte) []string {
+	if len(data) < 4 {
+		return nil
+	}
+
+	argc := int(binary.LittleEndian.Uint32(data))
+	rest := data[4:]
+
+	end := bytes.IndexByte(rest, 0)
+	if end < 0 {
+		return nil
+	}
+
+	rest = bytes.TrimLeft(rest[end:], "\x00")
+
+	for range argc {
+		end := bytes.IndexByte(rest, 0)
+		if end < 0 {
+			return nil
+		}
+
+		rest = rest[end+1:]
+	}
+
+	if end := bytes.Index(rest, []byte{0, 0}); end >= 0 {
+		rest = rest[:end]
+	}
+
+	return splitEnviron(rest)
+}
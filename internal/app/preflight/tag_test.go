@@ -0,0 +1,37 @@
+package preflight
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseTag(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin",
+		"FUKU_SERVICE=outer",
+		"FUKU_SERVICE=api",
+		"FUKU_SESSION=abc",
+		"FUKU_PROJECT=/project",
+		"BROKEN",
+	}
+
+	assert.Equal(t, tag{service: "api", session: "abc", project: "/project"}, parseTag(environ))
+	assert.Equal(t, tag{}, parseTag(nil))
+}
+
+func Test_SplitEnviron(t *testing.T) {
+	assert.Equal(t, []string{"A=1", "B=2"}, splitEnviron([]byte("A=1\x00B=2\x00")))
+	assert.Nil(t, splitEnviron(nil))
+}
+
+func Test_ParseProcArgs(t *testing.T) {
+	argc := make([]byte, 4)
+	binary.LittleEndian.PutUint32(argc, 2)
+
+	data := append(argc, []byte("/usr/bin/node\x00\x00\x00\x00node\x00server.js\x00FUKU_SERVICE=api\x00HOME=/Users/dev\x00\x00ptr_munge=\x00")...)
+
+	assert.Equal(t, []string{"FUKU_SERVICE=api", "HOME=/Users/dev"}, parseProcArgs(data))
+	assert.Nil(t, parseProcArgs([]byte{1}))
+}
@@ -10,6 +10,7 @@ type Process interface {
 	Name() string
 	Cmd() *exec.Cmd
 	Done() <-chan struct{}
+	Err() error
 	Ready() <-chan error
 	SignalReady(err error)
 	StdoutReader() *io.PipeReader
@@ -20,6 +21,12 @@ type Process interface {
 type Handle struct {
 	Process
 	done chan struct{}
+	proc *proc
+}
+
+// Fail records why the process was killed when that is known beyond its exit status, must be called before Close
+func (h *Handle) Fail(err error) {
+	h.proc.err = err
 }
 
 // Close signals that the process has exited
@@ -40,6 +47,7 @@ type proc struct {
 	name         string
 	cmd          *exec.Cmd
 	done         chan struct{}
+	err          error
 	ready        chan error
 	stdoutReader *io.PipeReader
 	stderrReader *io.PipeReader
@@ -60,6 +68,7 @@ func NewProcess(p Params) *Handle {
 	return &Handle{
 		Process: process,
 		done:    done,
+		proc:    process,
 	}
 }
 
@@ -78,6 +87,12 @@ func (p *proc) Done() <-chan struct{} {
 	return p.done
 }
 
+// Err returns why the process was killed when known beyond its exit status, such as exceeding its
+// memory limit. It is only set once Done is closed
+func (p *proc) Err() error {
+	return p.err
+}
+
 // Ready returns a channel that receives when the process is ready
 func (p *proc) Ready() <-chan error {
 	return p.ready
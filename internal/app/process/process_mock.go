@@ -69,6 +69,20 @@ func (mr *MockProcessMockRecorder) Done() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Done", reflect.TypeOf((*MockProcess)(nil).Done))
 }
 
+// Err mocks base method.
+func (m *MockProcess) Err() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Err")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Err indicates an expected call of Err.
+func (mr *MockProcessMockRecorder) Err() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockProcess)(nil).Err))
+}
+
 // Name mocks base method.
 func (m *MockProcess) Name() string {
 	m.ctrl.T.Helper()
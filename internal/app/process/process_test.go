@@ -156,3 +156,15 @@ func Test_Handle_Close(t *testing.T) {
 		t.Fatal("done channel should be closed after Close()")
 	}
 }
+
+func Test_Handle_Fail(t *testing.T) {
+	handle := NewProcess(Params{Name: "test-service"})
+	assert.NoError(t, handle.Err())
+
+	err := errors.New("killed")
+	handle.Fail(err)
+	handle.Close()
+
+	<-handle.Done()
+	assert.Equal(t, err, handle.Err())
+}
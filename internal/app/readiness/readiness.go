@@ -10,6 +10,8 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/charmbracelet/x/ansi"
+
 	"fuku/internal/app/bus"
 	"fuku/internal/app/errors"
 	"fuku/internal/app/process"
@@ -92,7 +94,7 @@ func (r *readiness) CheckLog(ctx context.Context, pattern string, stdout, stderr
 	scanStream := func(reader *io.PipeReader) {
 		scanner := bufio.NewScanner(reader)
 		for scanner.Scan() {
-			if re.MatchString(scanner.Text()) {
+			if re.MatchString(ansi.Strip(scanner.Text())) {
 				select {
 				case matched <- struct{}{}:
 				default:
@@ -133,6 +133,36 @@ func Test_CheckLog_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_CheckLog_IgnoresEscapeCodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := logger.NewMockLogger(ctrl)
+	componentLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithComponent("READINESS").Return(componentLogger)
+	checker := NewReadiness(bus.NoOp(), mockLogger)
+
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+
+	defer stdoutReader.Close()
+	defer stderrReader.Close()
+
+	go func() {
+		defer stdoutWriter.Close()
+
+		fmt.Fprintln(stdoutWriter, "\x1b[1mServer\x1b[0m ready on port \x1b[36m8080\x1b[0m")
+	}()
+
+	go func() {
+		defer stderrWriter.Close()
+	}()
+
+	done := make(chan struct{})
+	err := checker.CheckLog(context.Background(), "ready on port 8080", stdoutReader, stderrReader, 2*time.Second, done)
+	require.NoError(t, err)
+}
+
 func Test_CheckLog_Timeout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
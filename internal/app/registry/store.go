@@ -2,12 +2,14 @@ package registry
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
 	"fuku/internal/app/monitor"
 	"fuku/internal/config"
 )
@@ -17,12 +19,15 @@ type Status string
 
 // Status values for service lifecycle
 const (
-	StatusStarting   Status = "starting"
-	StatusRunning    Status = "running"
-	StatusStopping   Status = "stopping"
-	StatusRestarting Status = "restarting"
-	StatusFailed     Status = "failed"
-	StatusStopped    Status = "stopped"
+	StatusStarting    Status = "starting"
+	StatusRunning     Status = "running"
+	StatusStopping    Status = "stopping"
+	StatusRestarting  Status = "restarting"
+	StatusFailed      Status = "failed"
+	StatusStopped     Status = "stopped"
+	StatusCrashLoop   Status = "crash-loop"
+	StatusCompleted   Status = "completed"
+	StatusBuildFailed Status = "build-failed"
 )
 
 // IsRunning returns true if the status is running
@@ -32,47 +37,62 @@ func (s Status) IsRunning() bool {
 
 // IsStartable returns true if the service can be started
 func (s Status) IsStartable() bool {
-	return s == StatusStopped || s == StatusFailed
+	return s == StatusStopped || s == StatusFailed || s == StatusCrashLoop || s == StatusCompleted || s == StatusBuildFailed
 }
 
-// IsStoppable returns true if the service can be stopped
+// IsStoppable returns true if the service can be stopped; after a failed build the previous process may still be running
 func (s Status) IsStoppable() bool {
-	return s == StatusRunning
+	return s == StatusRunning || s == StatusBuildFailed
 }
 
 // IsRestartable returns true if the service can be restarted
 func (s Status) IsRestartable() bool {
-	return s == StatusRunning || s == StatusFailed || s == StatusStopped
+	return s == StatusRunning || s == StatusFailed || s == StatusStopped || s == StatusCrashLoop || s == StatusCompleted || s == StatusBuildFailed
 }
 
 // ServiceSnapshot contains a point-in-time snapshot of a service
 type ServiceSnapshot struct {
-	ID               string
-	Name             string
-	Tier             string
-	Status           Status
-	Watching         bool
-	Error            string
-	PID              int
-	CPU              float64
-	Memory           uint64
-	StartTime        time.Time
-	AttemptStartedAt time.Time
-	LifecycleAt      time.Time
-	LifecycleSeq     uint64
-	WatchAt          time.Time
-	WatchSeq         uint64
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	Tier             string    `json:"tier"`
+	Status           Status    `json:"status"`
+	Watching         bool      `json:"watching"`
+	Error            string    `json:"error,omitempty"`
+	PID              int       `json:"pid"`
+	CPU              float64   `json:"cpu"`
+	Memory           uint64    `json:"memory"`
+	Processes        int       `json:"processes"`
+	PIDs             []int     `json:"pids,omitempty"`
+	Attempt          int       `json:"attempt"`
+	MaxAttempts      int       `json:"max_attempts"`
+	Restarts         int       `json:"restarts"`
+	ExitCode         *int      `json:"exit_code,omitempty"`
+	BuildOutput      string    `json:"build_output,omitempty"`
+	StartTime        time.Time `json:"start_time"`
+	AttemptStartedAt time.Time `json:"-"`
+	LifecycleAt      time.Time `json:"-"`
+	LifecycleSeq     uint64    `json:"-"`
+	WatchAt          time.Time `json:"-"`
+	WatchSeq         uint64    `json:"-"`
+}
+
+// HasProcess returns true if the service has a live process, including the previous one kept after a failed rebuild
+func (s ServiceSnapshot) HasProcess() bool {
+	return s.PID != 0 && (s.Status == StatusRunning || s.Status == StatusBuildFailed)
 }
 
 // StatusCounts contains service counts grouped by status
 type StatusCounts struct {
-	Total      int
-	Starting   int
-	Running    int
-	Stopping   int
-	Restarting int
-	Stopped    int
-	Failed     int
+	Total       int `json:"total"`
+	Starting    int `json:"starting"`
+	Running     int `json:"running"`
+	Stopping    int `json:"stopping"`
+	Restarting  int `json:"restarting"`
+	Stopped     int `json:"stopped"`
+	Failed      int `json:"failed"`
+	CrashLoop   int `json:"crash_loop"`
+	Completed   int `json:"completed"`
+	BuildFailed int `json:"build_failed"`
 }
 
 // Store provides a bus-backed snapshot of the runtime state
@@ -100,6 +120,13 @@ type serviceState struct {
 	pid              int
 	cpu              float64
 	memory           uint64
+	processes        int
+	pids             []int
+	attempt          int
+	maxAttempts      int
+	restarts         int
+	exitCode         *int
+	buildOutput      string
 	startTime        time.Time
 	attemptStartedAt time.Time
 	lifecycleAt      time.Time
@@ -275,6 +302,12 @@ func (s *store) incrementCount(status Status) {
 		s.counts.Stopped++
 	case StatusFailed:
 		s.counts.Failed++
+	case StatusCrashLoop:
+		s.counts.CrashLoop++
+	case StatusCompleted:
+		s.counts.Completed++
+	case StatusBuildFailed:
+		s.counts.BuildFailed++
 	}
 }
 
@@ -292,6 +325,12 @@ func (s *store) decrementCount(status Status) {
 		s.counts.Stopped--
 	case StatusFailed:
 		s.counts.Failed--
+	case StatusCrashLoop:
+		s.counts.CrashLoop--
+	case StatusCompleted:
+		s.counts.Completed--
+	case StatusBuildFailed:
+		s.counts.BuildFailed--
 	}
 }
 
@@ -306,6 +345,13 @@ func (s *store) snapshot(svc *serviceState) ServiceSnapshot {
 		PID:              svc.pid,
 		CPU:              svc.cpu,
 		Memory:           svc.memory,
+		Processes:        svc.processes,
+		PIDs:             svc.pids,
+		Attempt:          svc.attempt,
+		MaxAttempts:      svc.maxAttempts,
+		Restarts:         svc.restarts,
+		ExitCode:         svc.exitCode,
+		BuildOutput:      svc.buildOutput,
 		StartTime:        svc.startTime,
 		AttemptStartedAt: svc.attemptStartedAt,
 		LifecycleAt:      svc.lifecycleAt,
@@ -329,6 +375,10 @@ func (s *store) handleEvent(msg bus.Message) {
 		s.handleServiceStarting(msg)
 	case bus.EventServiceReady:
 		s.handleServiceReady(msg)
+	case bus.EventServiceCompleted:
+		s.handleServiceCompleted(msg)
+	case bus.EventServiceBuildFailed:
+		s.handleServiceBuildFailed(msg)
 	case bus.EventServiceFailed:
 		s.handleServiceFailed(msg)
 	case bus.EventServiceStopping:
@@ -337,6 +387,10 @@ func (s *store) handleEvent(msg bus.Message) {
 		s.handleServiceStopped(msg)
 	case bus.EventServiceRestarting:
 		s.handleServiceRestarting(msg)
+	case bus.EventServiceBackoff:
+		s.handleServiceBackoff(msg)
+	case bus.EventServiceCrashLoop:
+		s.handleServiceCrashLoop(msg)
 	case bus.EventWatchStarted:
 		s.setWatching(msg, true)
 	case bus.EventWatchStopped:
@@ -388,10 +442,15 @@ func (s *store) handleServiceStarting(msg bus.Message) {
 	s.transitionStatus(svc, StatusStarting)
 	svc.pid = data.PID
 	svc.err = ""
+	svc.buildOutput = ""
+	svc.attempt = data.Attempt
+	svc.maxAttempts = data.MaxAttempts
 	svc.startTime = data.StartedAt
 	svc.attemptStartedAt = data.StartedAt
 	svc.cpu = 0
 	svc.memory = 0
+	svc.processes = 0
+	svc.pids = nil
 }
 
 func (s *store) handleServiceReady(msg bus.Message) {
@@ -418,9 +477,37 @@ func (s *store) handleServiceReady(msg bus.Message) {
 	if newProcess {
 		svc.cpu = 0
 		svc.memory = 0
+		svc.processes = 0
+		svc.pids = nil
 	}
 }
 
+func (s *store) handleServiceCompleted(msg bus.Message) {
+	data, ok := msg.Data.(bus.ServiceCompleted)
+	if !ok {
+		return
+	}
+
+	svc, exists := s.services[data.Service.ID]
+	if !exists || msg.Seq <= svc.lifecycleSeq {
+		return
+	}
+
+	exitCode := 0
+
+	svc.lifecycleSeq = msg.Seq
+	svc.lifecycleAt = msg.Timestamp
+	s.transitionStatus(svc, StatusCompleted)
+	svc.pid = 0
+	svc.cpu = 0
+	svc.memory = 0
+	svc.processes = 0
+	svc.pids = nil
+	svc.startTime = time.Time{}
+	svc.err = ""
+	svc.exitCode = &exitCode
+}
+
 func (s *store) handleServiceStopped(msg bus.Message) {
 	data, ok := msg.Data.(bus.ServiceStopped)
 	if !ok {
@@ -438,8 +525,14 @@ func (s *store) handleServiceStopped(msg bus.Message) {
 	svc.pid = 0
 	svc.cpu = 0
 	svc.memory = 0
+	svc.processes = 0
+	svc.pids = nil
 	svc.startTime = time.Time{}
 	svc.err = ""
+
+	if data.Unexpected {
+		svc.exitCode = &data.ExitCode
+	}
 }
 
 func (s *store) handleServiceRestarting(msg bus.Message) {
@@ -459,6 +552,58 @@ func (s *store) handleServiceRestarting(msg bus.Message) {
 	svc.startTime = time.Time{}
 	svc.cpu = 0
 	svc.memory = 0
+	svc.processes = 0
+	svc.pids = nil
+}
+
+func (s *store) handleServiceBackoff(msg bus.Message) {
+	data, ok := msg.Data.(bus.ServiceBackoff)
+	if !ok {
+		return
+	}
+
+	svc, exists := s.services[data.Service.ID]
+	if !exists || msg.Seq <= svc.lifecycleSeq {
+		return
+	}
+
+	svc.lifecycleSeq = msg.Seq
+	svc.lifecycleAt = msg.Timestamp
+	s.transitionStatus(svc, StatusRestarting)
+	svc.pid = 0
+	svc.cpu = 0
+	svc.memory = 0
+	svc.processes = 0
+	svc.pids = nil
+	svc.startTime = time.Time{}
+	svc.err = ""
+	svc.restarts = data.Restarts
+	svc.exitCode = &data.ExitCode
+}
+
+func (s *store) handleServiceCrashLoop(msg bus.Message) {
+	data, ok := msg.Data.(bus.ServiceCrashLoop)
+	if !ok {
+		return
+	}
+
+	svc, exists := s.services[data.Service.ID]
+	if !exists || msg.Seq <= svc.lifecycleSeq {
+		return
+	}
+
+	svc.lifecycleSeq = msg.Seq
+	svc.lifecycleAt = msg.Timestamp
+	s.transitionStatus(svc, StatusCrashLoop)
+	svc.pid = 0
+	svc.cpu = 0
+	svc.memory = 0
+	svc.processes = 0
+	svc.pids = nil
+	svc.startTime = time.Time{}
+	svc.err = fmt.Sprintf("%s: exited with code %d after %d restarts", errors.ErrCrashLoop, data.ExitCode, data.Restarts)
+	svc.restarts = data.Restarts
+	svc.exitCode = &data.ExitCode
 }
 
 // serviceIdentifier extracts the service ID from bus event data
@@ -482,6 +627,28 @@ func (s *store) setServiceStatus(msg bus.Message, status Status) {
 	s.transitionStatus(svc, status)
 }
 
+func (s *store) handleServiceBuildFailed(msg bus.Message) {
+	data, ok := msg.Data.(bus.ServiceBuildFailed)
+	if !ok {
+		return
+	}
+
+	svc, exists := s.services[data.Service.ID]
+	if !exists || msg.Seq <= svc.lifecycleSeq {
+		return
+	}
+
+	svc.lifecycleSeq = msg.Seq
+	svc.lifecycleAt = msg.Timestamp
+	s.transitionStatus(svc, StatusBuildFailed)
+	svc.err = ""
+	svc.buildOutput = data.Output
+
+	if data.Error != nil {
+		svc.err = data.Error.Error()
+	}
+}
+
 func (s *store) handleServiceFailed(msg bus.Message) {
 	data, ok := msg.Data.(bus.ServiceFailed)
 	if !ok {
@@ -499,12 +666,18 @@ func (s *store) handleServiceFailed(msg bus.Message) {
 	svc.pid = 0
 	svc.cpu = 0
 	svc.memory = 0
+	svc.processes = 0
+	svc.pids = nil
 	svc.startTime = time.Time{}
 	svc.err = ""
 
 	if data.Error != nil {
 		svc.err = data.Error.Error()
 	}
+
+	if errors.Is(data.Error, errors.ErrUnexpectedExit) {
+		svc.exitCode = &data.ExitCode
+	}
 }
 
 func (s *store) setWatching(msg bus.Message, value bool) {
@@ -596,11 +769,20 @@ func (s *store) sampleStats(ctx context.Context) {
 		return
 	}
 
+	tableCtx, cancel := context.WithTimeout(ctx, config.StoreSampleTimeout)
+	table, err := s.monitor.ProcessTable(tableCtx)
+
+	cancel()
+
+	if err != nil {
+		return
+	}
+
 	stats := make(map[string]monitor.Stats, len(pids))
 
 	for id, pid := range pids {
 		svcCtx, cancel := context.WithTimeout(ctx, config.StoreSampleTimeout)
-		st, err := s.monitor.GetStats(svcCtx, pid)
+		st, err := s.monitor.GetTreeStats(svcCtx, table, pid)
 
 		cancel()
 
@@ -618,6 +800,8 @@ func (s *store) sampleStats(ctx context.Context) {
 		if svc, exists := s.services[id]; exists && svc.pid == pids[id] {
 			svc.cpu = st.CPU
 			svc.memory = st.RawMEM
+			svc.processes = st.Processes
+			svc.pids = st.PIDs
 		}
 	}
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	"fuku/internal/app/bus"
 	"fuku/internal/app/monitor"
@@ -126,6 +127,8 @@ func Test_Store_ServiceLifecycle(t *testing.T) {
 		Data: bus.ServiceStarting{
 			ServiceEvent: bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}, Tier: "foundation"},
 			PID:          1234,
+			Attempt:      2,
+			MaxAttempts:  3,
 		},
 	})
 
@@ -137,6 +140,8 @@ func Test_Store_ServiceLifecycle(t *testing.T) {
 	svc, found := s.Service("test-id-api")
 	require.True(t, found)
 	assert.Equal(t, StatusStarting, svc.Status)
+	assert.Equal(t, 2, svc.Attempt)
+	assert.Equal(t, 3, svc.MaxAttempts)
 
 	b.Publish(bus.Message{
 		Type: bus.EventServiceReady,
@@ -255,6 +260,34 @@ func Test_Store_ServiceOrder(t *testing.T) {
 	assert.Equal(t, "beta", services[2].Name)
 }
 
+func Test_Store_SampleStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	table := monitor.ProcessTable{100: {101}}
+
+	mockMonitor := monitor.NewMockMonitor(ctrl)
+	mockMonitor.EXPECT().ProcessTable(gomock.Any()).Return(table, nil).Times(1)
+	mockMonitor.EXPECT().GetTreeStats(gomock.Any(), table, 100).Return(monitor.Stats{CPU: 2, RawMEM: 2048, Processes: 2, PIDs: []int{100, 101}}, nil)
+	mockMonitor.EXPECT().GetTreeStats(gomock.Any(), table, 200).Return(monitor.Stats{}, context.DeadlineExceeded)
+
+	s := NewStore(bus.NoOp(), mockMonitor).(*store)
+	s.services["id-api"] = &serviceState{id: "id-api", name: "api", pid: 100}
+	s.services["id-web"] = &serviceState{id: "id-web", name: "web", pid: 200, cpu: 1, memory: 1024}
+
+	s.sampleStats(t.Context())
+
+	api := s.services["id-api"]
+	assert.InDelta(t, 2.0, api.cpu, 0.001)
+	assert.Equal(t, uint64(2048), api.memory)
+	assert.Equal(t, 2, api.processes)
+	assert.Equal(t, []int{100, 101}, api.pids)
+
+	web := s.services["id-web"]
+	assert.InDelta(t, 1.0, web.cpu, 0.001, "a timed out sample keeps the previous reading")
+	assert.Equal(t, uint64(1024), web.memory)
+}
+
 func Test_Store_Uptime_ZeroBeforeRunning(t *testing.T) {
 	cfg := config.DefaultConfig()
 	b := bus.NewBus(cfg, nil, nil)
@@ -315,6 +348,16 @@ func Test_Status_IsStartable(t *testing.T) {
 			status: StatusFailed,
 			want:   true,
 		},
+		{
+			name:   "crash-loop",
+			status: StatusCrashLoop,
+			want:   true,
+		},
+		{
+			name:   "completed",
+			status: StatusCompleted,
+			want:   true,
+		},
 		{
 			name:   "running",
 			status: StatusRunning,
@@ -370,6 +413,11 @@ func Test_Status_IsStoppable(t *testing.T) {
 			status: StatusFailed,
 			want:   false,
 		},
+		{
+			name:   "build-failed",
+			status: StatusBuildFailed,
+			want:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -379,6 +427,46 @@ func Test_Status_IsStoppable(t *testing.T) {
 	}
 }
 
+func Test_ServiceSnapshot_HasProcess(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot ServiceSnapshot
+		want     bool
+	}{
+		{
+			name:     "running",
+			snapshot: ServiceSnapshot{Status: StatusRunning, PID: 1234},
+			want:     true,
+		},
+		{
+			name:     "build-failed with the previous process",
+			snapshot: ServiceSnapshot{Status: StatusBuildFailed, PID: 1234},
+			want:     true,
+		},
+		{
+			name:     "build-failed without a process",
+			snapshot: ServiceSnapshot{Status: StatusBuildFailed},
+			want:     false,
+		},
+		{
+			name:     "starting",
+			snapshot: ServiceSnapshot{Status: StatusStarting, PID: 1234},
+			want:     false,
+		},
+		{
+			name:     "stopped",
+			snapshot: ServiceSnapshot{Status: StatusStopped},
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.snapshot.HasProcess())
+		})
+	}
+}
+
 func Test_Status_IsRestartable(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -400,6 +488,16 @@ func Test_Status_IsRestartable(t *testing.T) {
 			status: StatusStopped,
 			want:   true,
 		},
+		{
+			name:   "crash-loop",
+			status: StatusCrashLoop,
+			want:   true,
+		},
+		{
+			name:   "completed",
+			status: StatusCompleted,
+			want:   true,
+		},
 		{
 			name:   "starting",
 			status: StatusStarting,
@@ -775,6 +873,186 @@ func Test_Store_ServiceRestarting_ClearsStartTimePreservesAttempt(t *testing.T)
 	assert.Equal(t, uint64(0), svc.Memory)
 }
 
+func Test_Store_ServiceBackoffAndCrashLoop(t *testing.T) {
+	s, b := newTestStore(t, config.DefaultConfig())
+
+	event := bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}, Tier: "foundation"}
+
+	b.Publish(bus.Message{
+		Type: bus.EventProfileResolved,
+		Data: bus.ProfileResolved{
+			Profile: "default",
+			Tiers:   []bus.Tier{{Name: "foundation", Services: []bus.Service{event.Service}}},
+		},
+	})
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceReady,
+		Data: bus.ServiceReady{ServiceEvent: event, PID: 1234},
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Counts().Running == 1
+	}, testTimeout, testInterval)
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceBackoff,
+		Data: bus.ServiceBackoff{ServiceEvent: event, ExitCode: 1, Restarts: 2, Delay: time.Second},
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Counts().Restarting == 1
+	}, testTimeout, testInterval)
+
+	svc, _ := s.Service("test-id-api")
+	assert.Equal(t, StatusRestarting, svc.Status)
+	assert.Equal(t, 2, svc.Restarts)
+	require.NotNil(t, svc.ExitCode)
+	assert.Equal(t, 1, *svc.ExitCode)
+	assert.Equal(t, 0, svc.PID)
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceCrashLoop,
+		Data: bus.ServiceCrashLoop{ServiceEvent: event, ExitCode: 137, Restarts: 5},
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Counts().CrashLoop == 1
+	}, testTimeout, testInterval)
+
+	svc, _ = s.Service("test-id-api")
+	assert.Equal(t, StatusCrashLoop, svc.Status)
+	assert.Equal(t, 5, svc.Restarts)
+	require.NotNil(t, svc.ExitCode)
+	assert.Equal(t, 137, *svc.ExitCode)
+	assert.Equal(t, "crash loop: exited with code 137 after 5 restarts", svc.Error)
+	assert.Equal(t, 0, s.Counts().Restarting)
+}
+
+func Test_Store_ServiceCompleted(t *testing.T) {
+	s, b := newTestStore(t, config.DefaultConfig())
+
+	event := bus.ServiceEvent{Service: bus.Service{ID: "test-id-migrate", Name: "migrate"}, Tier: "foundation"}
+
+	b.Publish(bus.Message{
+		Type: bus.EventProfileResolved,
+		Data: bus.ProfileResolved{
+			Profile: "default",
+			Tiers:   []bus.Tier{{Name: "foundation", Services: []bus.Service{event.Service}}},
+		},
+	})
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceStarting,
+		Data: bus.ServiceStarting{ServiceEvent: event, PID: 1234, Attempt: 1, MaxAttempts: 1},
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Counts().Starting == 1
+	}, testTimeout, testInterval)
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceCompleted,
+		Data: bus.ServiceCompleted{ServiceEvent: event, Duration: time.Second},
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Counts().Completed == 1
+	}, testTimeout, testInterval)
+
+	svc, _ := s.Service("test-id-migrate")
+	assert.Equal(t, StatusCompleted, svc.Status)
+	assert.Equal(t, 0, svc.PID)
+	assert.Empty(t, svc.Error)
+	require.NotNil(t, svc.ExitCode)
+	assert.Equal(t, 0, *svc.ExitCode)
+	assert.Equal(t, 0, s.Counts().Starting)
+}
+
+func Test_Store_ServiceBuildFailed(t *testing.T) {
+	s, b := newTestStore(t, config.DefaultConfig())
+
+	event := bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}, Tier: "platform"}
+
+	b.Publish(bus.Message{
+		Type: bus.EventProfileResolved,
+		Data: bus.ProfileResolved{
+			Profile: "default",
+			Tiers:   []bus.Tier{{Name: "platform", Services: []bus.Service{event.Service}}},
+		},
+	})
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceReady,
+		Data: bus.ServiceReady{ServiceEvent: event, PID: 1234},
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Counts().Running == 1
+	}, testTimeout, testInterval)
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceBuildFailed,
+		Data: bus.ServiceBuildFailed{
+			ServiceEvent: event,
+			Output:       "./main.go:12:2: undefined: handler\n",
+			Error:        errors.New("build failed: ./main.go:12:2: undefined: handler"),
+		},
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Counts().BuildFailed == 1
+	}, testTimeout, testInterval)
+
+	svc, _ := s.Service("test-id-api")
+	assert.Equal(t, StatusBuildFailed, svc.Status)
+	assert.Equal(t, 1234, svc.PID)
+	assert.True(t, svc.HasProcess())
+	assert.Equal(t, "build failed: ./main.go:12:2: undefined: handler", svc.Error)
+	assert.Equal(t, "./main.go:12:2: undefined: handler\n", svc.BuildOutput)
+	assert.Equal(t, 0, s.Counts().Running)
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceStarting,
+		Data: bus.ServiceStarting{ServiceEvent: event, PID: 5678, Attempt: 1, MaxAttempts: 1},
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Counts().Starting == 1
+	}, testTimeout, testInterval)
+
+	svc, _ = s.Service("test-id-api")
+	assert.Empty(t, svc.Error)
+	assert.Empty(t, svc.BuildOutput)
+}
+
+func Test_Store_UnexpectedStopRecordsExitCode(t *testing.T) {
+	s, b := newTestStore(t, config.DefaultConfig())
+
+	event := bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}, Tier: "foundation"}
+
+	b.Publish(bus.Message{
+		Type: bus.EventProfileResolved,
+		Data: bus.ProfileResolved{
+			Profile: "default",
+			Tiers:   []bus.Tier{{Name: "foundation", Services: []bus.Service{event.Service}}},
+		},
+	})
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceStopped,
+		Data: bus.ServiceStopped{ServiceEvent: event, Unexpected: true, ExitCode: 2},
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Counts().Stopped == 1
+	}, testTimeout, testInterval)
+
+	svc, _ := s.Service("test-id-api")
+	require.NotNil(t, svc.ExitCode)
+	assert.Equal(t, 2, *svc.ExitCode)
+}
+
 func Test_Store_StaleLifecycleEventRejected(t *testing.T) {
 	s, b := newTestStore(t, config.DefaultConfig())
 
@@ -0,0 +1,132 @@
+package relay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"time"
+
+	"fuku/internal/config"
+)
+
+// Attacher connects relay clients to a running service's stdin and raw output
+type Attacher interface {
+	Attach(service string) (*Attachment, error)
+}
+
+// Attachment is a live connection to a running service. Output is closed when the service exits,
+// and Detach must be called once the client is done
+type Attachment struct {
+	TTY    bool
+	Stdin  io.Writer
+	Output <-chan []byte
+	Detach func()
+}
+
+// SetAttacher sets the attacher used to serve attach requests, must be called before Run
+func (s *Server) SetAttacher(attacher Attacher) {
+	s.attacher = attacher
+}
+
+// handleAttach connects the client to the requested service, forwarding its input to the service's stdin
+// and the service's output back until either side goes away
+func (s *Server) handleAttach(ctx context.Context, conn net.Conn, reader *bufio.Reader, clientID string, req AttachRequest) {
+	if s.attacher == nil {
+		s.send(conn, clientID, ErrorMessage{Type: MessageError, Message: "attach is not supported by this instance"})
+
+		return
+	}
+
+	attachment, err := s.attacher.Attach(req.Service)
+	if err != nil {
+		s.log.Debug().Err(err).Msgf("Client %s failed to attach to %s", clientID, req.Service)
+		s.send(conn, clientID, ErrorMessage{Type: MessageError, Message: err.Error()})
+
+		return
+	}
+
+	defer attachment.Detach()
+
+	s.log.Info().Msgf("Client %s attached to %s", clientID, req.Service)
+
+	if !s.send(conn, clientID, AttachedMessage{Type: MessageAttached, Service: req.Service, TTY: attachment.TTY}) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		defer cancel()
+
+		s.readInput(reader, attachment.Stdin, clientID)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info().Msgf("Client %s detached from %s", clientID, req.Service)
+
+			return
+		case data, ok := <-attachment.Output:
+			if !ok {
+				s.log.Info().Msgf("Service %s exited, closing attached client %s", req.Service, clientID)
+
+				return
+			}
+
+			if !s.send(conn, clientID, OutputMessage{Type: MessageOutput, Data: data}) {
+				return
+			}
+		}
+	}
+}
+
+// readInput writes input messages from an attached client to the service's stdin until the client disconnects
+func (s *Server) readInput(reader *bufio.Reader, stdin io.Writer, clientID string) {
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+
+		var msg InputMessage
+		if err := json.Unmarshal(line, &msg); err != nil || msg.Type != MessageInput {
+			continue
+		}
+
+		if _, err := stdin.Write(msg.Data); err != nil {
+			s.log.Debug().Err(err).Msgf("Failed to write input from %s", clientID)
+
+			return
+		}
+	}
+}
+
+// send writes a single message to the client, returning false once the client is gone
+func (s *Server) send(conn net.Conn, clientID string, msg any) bool {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		s.log.Error().Err(err).Msgf("Failed to marshal message for %s", clientID)
+
+		return false
+	}
+
+	data = append(data, '\n')
+
+	if err := conn.SetWriteDeadline(time.Now().Add(config.SocketWriteTimeout)); err != nil {
+		s.log.Debug().Err(err).Msgf("Client %s disconnected", clientID)
+
+		return false
+	}
+
+	if _, err := conn.Write(data); err != nil {
+		s.log.Debug().Err(err).Msgf("Client %s disconnected", clientID)
+
+		return false
+	}
+
+	return true
+}
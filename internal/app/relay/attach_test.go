@@ -0,0 +1,133 @@
+package relay
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+type testStdin struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *testStdin) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.Write(p)
+}
+
+func (w *testStdin) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.String()
+}
+
+type testAttacher struct {
+	stdin    *testStdin
+	output   chan []byte
+	detached atomic.Bool
+	err      error
+}
+
+func (a *testAttacher) Attach(service string) (*Attachment, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	return &Attachment{
+		TTY:    service == "api",
+		Stdin:  a.stdin,
+		Output: a.output,
+		Detach: func() { a.detached.Store(true) },
+	}, nil
+}
+
+func Test_Client_Attach(t *testing.T) {
+	srv := newTestServer(t)
+	attacher := &testAttacher{stdin: &testStdin{}, output: make(chan []byte, 1)}
+	srv.SetAttacher(attacher)
+
+	cancel := startTestServer(t, srv, uniqueProfile(t), []string{"api"})
+	defer srv.Stop()
+	defer cancel()
+
+	c := NewClient()
+	require.NoError(t, c.Connect(srv.SocketPath()))
+
+	defer c.Close()
+
+	attached, err := c.Attach("api")
+	require.NoError(t, err)
+	assert.Equal(t, AttachedMessage{Type: MessageAttached, Service: "api", TTY: true}, attached)
+
+	handler := &testHandler{}
+	streamDone := make(chan error, 1)
+
+	go func() {
+		streamDone <- c.Stream(t.Context(), handler)
+	}()
+
+	require.NoError(t, c.Input([]byte("break main.go:12\r")))
+	assert.Eventually(t, func() bool {
+		return attacher.stdin.String() == "break main.go:12\r"
+	}, time.Second, 10*time.Millisecond)
+
+	attacher.output <- []byte("(dlv) ")
+	assert.Eventually(t, func() bool {
+		return handler.getOutput() == "(dlv) "
+	}, time.Second, 10*time.Millisecond)
+
+	close(attacher.output)
+
+	select {
+	case err := <-streamDone:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("stream did not end after the service exited")
+	}
+
+	assert.Eventually(t, attacher.detached.Load, time.Second, 10*time.Millisecond)
+}
+
+func Test_Client_Attach_Fails(t *testing.T) {
+	tests := []struct {
+		name     string
+		attacher Attacher
+		message  string
+	}{
+		{name: "service not running", attacher: &testAttacher{err: errors.ErrServiceNotRunning}, message: "service is not running"},
+		{name: "attach not supported", attacher: nil, message: "attach is not supported by this instance"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t)
+			if tt.attacher != nil {
+				srv.SetAttacher(tt.attacher)
+			}
+
+			cancel := startTestServer(t, srv, uniqueProfile(t), []string{"api"})
+			defer srv.Stop()
+			defer cancel()
+
+			c := NewClient()
+			require.NoError(t, c.Connect(srv.SocketPath()))
+
+			defer c.Close()
+
+			_, err := c.Attach("api")
+			require.ErrorIs(t, err, errors.ErrAttachFailed)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
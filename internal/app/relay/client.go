@@ -7,27 +7,42 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
+	"sync"
 
 	"fuku/internal/app/errors"
+	"fuku/internal/app/registry"
 )
 
 // Handler processes messages received from the relay server
 type Handler interface {
 	HandleStatus(StatusMessage)
 	HandleLog(LogMessage)
+	HandleOutput(OutputMessage)
 }
 
 // Client connects to a running fuku instance and streams logs
 type Client interface {
 	Connect(socketPath string) error
 	Subscribe(services []string) error
+	Attach(service string) (AttachedMessage, error)
+	Input(data []byte) error
+	Start(service string) (registry.ServiceSnapshot, error)
+	Stop(service string) (registry.ServiceSnapshot, error)
+	Restart(service string) (registry.ServiceSnapshot, error)
+	Status() (InstanceStatus, error)
+	List() ([]registry.ServiceSnapshot, error)
+	Shutdown() (InstanceStatus, error)
 	Stream(ctx context.Context, handler Handler) error
 	Close() error
 }
 
 // client implements the Client interface
 type client struct {
-	conn net.Conn
+	conn     net.Conn
+	reader   *bufio.Reader
+	mu       sync.Mutex
+	requests uint64
 }
 
 // NewClient creates a new relay client
@@ -43,6 +58,7 @@ func (c *client) Connect(socketPath string) error {
 	}
 
 	c.conn = conn
+	c.reader = bufio.NewReader(conn)
 
 	return nil
 }
@@ -54,7 +70,158 @@ func (c *client) Subscribe(services []string) error {
 		Services: services,
 	}
 
-	data, err := json.Marshal(req)
+	return c.send(req)
+}
+
+// Attach asks the server to attach to a running service and waits for its answer
+func (c *client) Attach(service string) (AttachedMessage, error) {
+	if err := c.send(AttachRequest{Type: MessageAttach, Service: service}); err != nil {
+		return AttachedMessage{}, err
+	}
+
+	line, err := c.reader.ReadBytes('\n')
+	if err != nil {
+		return AttachedMessage{}, fmt.Errorf("%w: %w", errors.ErrFailedToReadSocket, err)
+	}
+
+	var envelope MessageEnvelope
+	if err := json.Unmarshal(line, &envelope); err != nil {
+		return AttachedMessage{}, fmt.Errorf("%w: %w", errors.ErrFailedToReadSocket, err)
+	}
+
+	if envelope.Type == MessageError {
+		var msg ErrorMessage
+		if err := json.Unmarshal(line, &msg); err != nil {
+			return AttachedMessage{}, fmt.Errorf("%w: %w", errors.ErrFailedToReadSocket, err)
+		}
+
+		return AttachedMessage{}, fmt.Errorf("%w: %s", errors.ErrAttachFailed, msg.Message)
+	}
+
+	var attached AttachedMessage
+	if err := json.Unmarshal(line, &attached); err != nil || attached.Type != MessageAttached {
+		return AttachedMessage{}, fmt.Errorf("%w: unexpected reply %s", errors.ErrAttachFailed, envelope.Type)
+	}
+
+	return attached, nil
+}
+
+// Input sends bytes for the attached service's stdin
+func (c *client) Input(data []byte) error {
+	return c.send(InputMessage{Type: MessageInput, Data: data})
+}
+
+// Start asks the server to start a service by name or ID, returning the service in its starting state
+func (c *client) Start(service string) (registry.ServiceSnapshot, error) {
+	return c.serviceRequest(MessageStart, service)
+}
+
+// Stop asks the server to stop a service by name or ID, returning the service in its stopping state
+func (c *client) Stop(service string) (registry.ServiceSnapshot, error) {
+	return c.serviceRequest(MessageStop, service)
+}
+
+// Restart asks the server to restart a service by name or ID, returning the service in its restarting state
+func (c *client) Restart(service string) (registry.ServiceSnapshot, error) {
+	return c.serviceRequest(MessageRestart, service)
+}
+
+// Status asks the server for the state of the instance
+func (c *client) Status() (InstanceStatus, error) {
+	resp, err := c.request(MessageInspect, "")
+	if err != nil {
+		return InstanceStatus{}, err
+	}
+
+	if resp.Status == nil {
+		return InstanceStatus{}, fmt.Errorf("%w: missing status", errors.ErrRequestFailed)
+	}
+
+	return *resp.Status, nil
+}
+
+// List asks the server for the state of the services
+func (c *client) List() ([]registry.ServiceSnapshot, error) {
+	resp, err := c.request(MessageList, "")
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]registry.ServiceSnapshot, 0, len(resp.Services))
+	for _, svc := range resp.Services {
+		services = append(services, svc.Snapshot())
+	}
+
+	return services, nil
+}
+
+// Shutdown asks the server to stop all services and exit, returning the state of the instance once it has begun stopping
+func (c *client) Shutdown() (InstanceStatus, error) {
+	resp, err := c.request(MessageShutdown, "")
+	if err != nil {
+		return InstanceStatus{}, err
+	}
+
+	if resp.Status == nil {
+		return InstanceStatus{}, fmt.Errorf("%w: missing status", errors.ErrRequestFailed)
+	}
+
+	return *resp.Status, nil
+}
+
+// serviceRequest sends a start, stop or restart request and returns the service from the reply
+func (c *client) serviceRequest(msgType MessageType, service string) (registry.ServiceSnapshot, error) {
+	resp, err := c.request(msgType, service)
+	if err != nil {
+		return registry.ServiceSnapshot{}, err
+	}
+
+	if resp.Service == nil {
+		return registry.ServiceSnapshot{}, fmt.Errorf("%w: missing service", errors.ErrRequestFailed)
+	}
+
+	return resp.Service.Snapshot(), nil
+}
+
+// request sends a control request and waits for the response with the same ID.
+// Requests are serialized, so a client may be shared between goroutines
+func (c *client) request(msgType MessageType, service string) (ControlResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests++
+	id := strconv.FormatUint(c.requests, 10)
+
+	if err := c.send(ControlRequest{Type: msgType, ID: id, Service: service}); err != nil {
+		return ControlResponse{}, err
+	}
+
+	for {
+		line, err := c.reader.ReadBytes('\n')
+		if err != nil {
+			return ControlResponse{}, fmt.Errorf("%w: %w", errors.ErrFailedToReadSocket, err)
+		}
+
+		var resp ControlResponse
+		if err := json.Unmarshal(line, &resp); err != nil {
+			return ControlResponse{}, fmt.Errorf("%w: %w", errors.ErrFailedToReadSocket, err)
+		}
+
+		if resp.Type != MessageResponse || resp.ID != id {
+			continue
+		}
+
+		if resp.Error != "" {
+			return ControlResponse{}, fmt.Errorf("%w: %s", errors.ErrRequestFailed, resp.Error)
+		}
+
+		return resp, nil
+	}
+}
+
+// send writes a single message to the server
+func (c *client) send(msg any) error {
+	data, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("%w: %w", errors.ErrFailedToMarshalMessage, err)
 	}
@@ -80,10 +247,12 @@ func (c *client) Stream(ctx context.Context, handler Handler) error {
 		}
 	}()
 
-	reader := bufio.NewReader(c.conn)
+	if c.reader == nil {
+		c.reader = bufio.NewReader(c.conn)
+	}
 
 	for {
-		line, err := reader.ReadBytes('\n')
+		line, err := c.reader.ReadBytes('\n')
 		if err != nil && (ctx.Err() != nil || err == io.EOF) {
 			return nil
 		}
@@ -113,6 +282,13 @@ func (c *client) Stream(ctx context.Context, handler Handler) error {
 			}
 
 			handler.HandleLog(msg)
+		case MessageOutput:
+			var msg OutputMessage
+			if err := json.Unmarshal(line, &msg); err != nil {
+				continue
+			}
+
+			handler.HandleOutput(msg)
 		}
 	}
 }
@@ -11,6 +11,7 @@ package relay
 
 import (
 	context "context"
+	registry "fuku/internal/app/registry"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -52,6 +53,18 @@ func (mr *MockHandlerMockRecorder) HandleLog(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLog", reflect.TypeOf((*MockHandler)(nil).HandleLog), arg0)
 }
 
+// HandleOutput mocks base method.
+func (m *MockHandler) HandleOutput(arg0 OutputMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleOutput", arg0)
+}
+
+// HandleOutput indicates an expected call of HandleOutput.
+func (mr *MockHandlerMockRecorder) HandleOutput(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleOutput", reflect.TypeOf((*MockHandler)(nil).HandleOutput), arg0)
+}
+
 // HandleStatus mocks base method.
 func (m *MockHandler) HandleStatus(arg0 StatusMessage) {
 	m.ctrl.T.Helper()
@@ -88,6 +101,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// Attach mocks base method.
+func (m *MockClient) Attach(service string) (AttachedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", service)
+	ret0, _ := ret[0].(AttachedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockClientMockRecorder) Attach(service any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockClient)(nil).Attach), service)
+}
+
 // Close mocks base method.
 func (m *MockClient) Close() error {
 	m.ctrl.T.Helper()
@@ -116,6 +144,110 @@ func (mr *MockClientMockRecorder) Connect(socketPath any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockClient)(nil).Connect), socketPath)
 }
 
+// Input mocks base method.
+func (m *MockClient) Input(data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Input", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Input indicates an expected call of Input.
+func (mr *MockClientMockRecorder) Input(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Input", reflect.TypeOf((*MockClient)(nil).Input), data)
+}
+
+// List mocks base method.
+func (m *MockClient) List() ([]registry.ServiceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]registry.ServiceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockClientMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockClient)(nil).List))
+}
+
+// Restart mocks base method.
+func (m *MockClient) Restart(service string) (registry.ServiceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restart", service)
+	ret0, _ := ret[0].(registry.ServiceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restart indicates an expected call of Restart.
+func (mr *MockClientMockRecorder) Restart(service any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restart", reflect.TypeOf((*MockClient)(nil).Restart), service)
+}
+
+// Shutdown mocks base method.
+func (m *MockClient) Shutdown() (InstanceStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown")
+	ret0, _ := ret[0].(InstanceStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockClientMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockClient)(nil).Shutdown))
+}
+
+// Start mocks base method.
+func (m *MockClient) Start(service string) (registry.ServiceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", service)
+	ret0, _ := ret[0].(registry.ServiceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockClientMockRecorder) Start(service any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockClient)(nil).Start), service)
+}
+
+// Status mocks base method.
+func (m *MockClient) Status() (InstanceStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(InstanceStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockClientMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockClient)(nil).Status))
+}
+
+// Stop mocks base method.
+func (m *MockClient) Stop(service string) (registry.ServiceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", service)
+	ret0, _ := ret[0].(registry.ServiceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockClientMockRecorder) Stop(service any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockClient)(nil).Stop), service)
+}
+
 // Stream mocks base method.
 func (m *MockClient) Stream(ctx context.Context, handler Handler) error {
 	m.ctrl.T.Helper()
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"fuku/internal/app/bus"
 	"fuku/internal/app/errors"
 	"fuku/internal/config"
 	"fuku/internal/config/logger"
@@ -22,6 +23,7 @@ type testHandler struct {
 	mu       sync.Mutex
 	statuses []StatusMessage
 	logs     []LogMessage
+	output   []byte
 }
 
 func (h *testHandler) HandleStatus(msg StatusMessage) {
@@ -38,6 +40,20 @@ func (h *testHandler) HandleLog(msg LogMessage) {
 	h.logs = append(h.logs, msg)
 }
 
+func (h *testHandler) HandleOutput(msg OutputMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.output = append(h.output, msg.Data...)
+}
+
+func (h *testHandler) getOutput() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return string(h.output)
+}
+
 func (h *testHandler) getStatuses() []StatusMessage {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -449,6 +465,7 @@ func Test_Client_Close_WithConnection(t *testing.T) {
 	log := logger.NewLoggerWithOutput(cfg, io.Discard)
 
 	srv := &Server{
+		bus:         bus.NoOp(),
 		bufferSize:  cfg.Logs.Buffer,
 		historySize: cfg.Logs.History,
 		hub:         NewHub(cfg.Logs.Buffer, cfg.Logs.History, log),
@@ -0,0 +1,189 @@
+package relay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/registry"
+	"fuku/internal/config"
+)
+
+// serviceCommand describes how a service control request maps onto the bus
+type serviceCommand struct {
+	command bus.MessageType
+	allowed func(registry.Status) bool
+	refused error
+	status  registry.Status
+}
+
+// serviceCommands maps the service control requests onto the bus commands the runner handles
+var serviceCommands = map[MessageType]serviceCommand{
+	MessageStart:   {command: bus.CommandStartService, allowed: registry.Status.IsStartable, refused: errors.ErrAPINotStartable, status: registry.StatusStarting},
+	MessageStop:    {command: bus.CommandStopService, allowed: registry.Status.IsStoppable, refused: errors.ErrAPINotRunning, status: registry.StatusStopping},
+	MessageRestart: {command: bus.CommandRestartService, allowed: registry.Status.IsRestartable, refused: errors.ErrAPINotRestartable, status: registry.StatusRestarting},
+}
+
+// SetStore sets the store used to serve control requests, must be called before Run
+func (s *Server) SetStore(store registry.Store) {
+	s.store = store
+}
+
+// isControl reports whether a message is a control request
+func isControl(msgType MessageType) bool {
+	//nolint:exhaustive // only control requests are matched
+	switch msgType {
+	case MessageStart, MessageStop, MessageRestart, MessageInspect, MessageList, MessageShutdown:
+		return true
+	default:
+		return false
+	}
+}
+
+// handleControl answers control requests until the client disconnects, or stops the instance on a shutdown request
+func (s *Server) handleControl(ctx context.Context, conn net.Conn, reader *bufio.Reader, clientID string, line []byte) {
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		var req ControlRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.log.Error().Err(err).Msgf("Failed to parse request from %s", clientID)
+
+			return
+		}
+
+		resp := s.control(clientID, req)
+		sent := s.send(conn, clientID, resp)
+
+		if req.Type == MessageShutdown && resp.Error == "" {
+			s.bus.Publish(bus.Message{Type: bus.CommandStopAll, Critical: true})
+
+			return
+		}
+
+		if !sent {
+			return
+		}
+
+		next, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+
+		line = next
+	}
+}
+
+// control serves a single control request
+func (s *Server) control(clientID string, req ControlRequest) ControlResponse {
+	resp := ControlResponse{Type: MessageResponse, ID: req.ID}
+
+	if s.store == nil {
+		resp.Error = "control requests are not supported by this instance"
+
+		return resp
+	}
+
+	//nolint:exhaustive // start, stop and restart are served by serviceCommand
+	switch req.Type {
+	case MessageInspect:
+		status := s.status()
+		resp.Status = &status
+	case MessageList:
+		services := s.store.Services()
+
+		resp.Services = make([]Service, 0, len(services))
+		for _, svc := range services {
+			resp.Services = append(resp.Services, newService(svc))
+		}
+	case MessageShutdown:
+		s.log.Info().Msgf("Client %s requested shutdown", clientID)
+
+		status := s.status()
+		resp.Status = &status
+	default:
+		cmd, ok := serviceCommands[req.Type]
+		if !ok {
+			resp.Error = "unknown request " + string(req.Type)
+
+			return resp
+		}
+
+		svc, err := s.serviceCommand(clientID, req, cmd)
+		if err != nil {
+			resp.Error = err.Error()
+
+			return resp
+		}
+
+		service := newService(svc)
+		resp.Service = &service
+	}
+
+	return resp
+}
+
+// status returns the current state of the instance
+func (s *Server) status() InstanceStatus {
+	return InstanceStatus{
+		Version: config.Version,
+		Profile: s.profile,
+		Phase:   s.store.Phase(),
+		PID:     os.Getpid(),
+		Uptime:  s.store.Uptime(),
+		Counts:  s.store.Counts(),
+	}
+}
+
+// serviceCommand publishes the bus command for a start, stop or restart request, applying the same checks
+// as the API, and returns the service in the state it is moving to
+func (s *Server) serviceCommand(clientID string, req ControlRequest, cmd serviceCommand) (registry.ServiceSnapshot, error) {
+	if s.store.Phase() != string(bus.PhaseRunning) {
+		return registry.ServiceSnapshot{}, errors.ErrAPINotAccepting
+	}
+
+	svc, found := s.findService(req.Service)
+	if !found {
+		return registry.ServiceSnapshot{}, errors.ErrAPIServiceNotFound
+	}
+
+	if !cmd.allowed(svc.Status) {
+		return registry.ServiceSnapshot{}, cmd.refused
+	}
+
+	s.log.Info().Msgf("Client %s requested %s of service '%s'", clientID, req.Type, svc.Name)
+
+	s.bus.Publish(bus.Message{
+		Type:     cmd.command,
+		Data:     bus.Service{ID: svc.ID, Name: svc.Name},
+		Critical: true,
+	})
+
+	svc.Status = cmd.status
+
+	return svc, nil
+}
+
+// findService looks a service up by name, falling back to its ID
+func (s *Server) findService(service string) (registry.ServiceSnapshot, bool) {
+	for _, svc := range s.store.Services() {
+		if svc.Name == service {
+			return svc, true
+		}
+	}
+
+	return s.store.Service(service)
+}
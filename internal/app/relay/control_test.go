@@ -0,0 +1,268 @@
+package relay
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/registry"
+	"fuku/internal/config"
+)
+
+func Test_IsControl(t *testing.T) {
+	for _, msgType := range []MessageType{MessageStart, MessageStop, MessageRestart, MessageInspect, MessageList, MessageShutdown} {
+		assert.True(t, isControl(msgType), msgType)
+	}
+
+	for _, msgType := range []MessageType{MessageSubscribe, MessageAttach, MessageInput, MessageResponse} {
+		assert.False(t, isControl(msgType), msgType)
+	}
+}
+
+func Test_Client_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	counts := registry.StatusCounts{Total: 2, Running: 1, Failed: 1}
+
+	mockStore := registry.NewMockStore(ctrl)
+	mockStore.EXPECT().Phase().Return(string(bus.PhaseRunning)).Times(2)
+	mockStore.EXPECT().Uptime().Return(time.Minute).Times(2)
+	mockStore.EXPECT().Counts().Return(counts).Times(2)
+
+	srv := newTestServer(t)
+	srv.SetStore(mockStore)
+
+	profile := uniqueProfile(t)
+	cancel := startTestServer(t, srv, profile, []string{"api", "web"})
+
+	defer srv.Stop()
+	defer cancel()
+
+	c := NewClient()
+	require.NoError(t, c.Connect(srv.SocketPath()))
+
+	defer c.Close()
+
+	expected := InstanceStatus{
+		Version: config.Version,
+		Profile: profile,
+		Phase:   string(bus.PhaseRunning),
+		PID:     os.Getpid(),
+		Uptime:  time.Minute,
+		Counts:  counts,
+	}
+
+	for range 2 {
+		status, err := c.Status()
+		require.NoError(t, err)
+		assert.Equal(t, expected, status)
+	}
+}
+
+func Test_Client_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	services := []registry.ServiceSnapshot{
+		{ID: "id-api", Name: "api", Tier: "platform", Status: registry.StatusRunning, PID: 100, Memory: 1024, LifecycleSeq: 7, WatchSeq: 3},
+		{ID: "id-web", Name: "web", Tier: "edge", Status: registry.StatusFailed, Error: "exit status 1", LifecycleSeq: 9},
+	}
+
+	mockStore := registry.NewMockStore(ctrl)
+	mockStore.EXPECT().Services().Return(services)
+
+	srv := newTestServer(t)
+	srv.SetStore(mockStore)
+
+	cancel := startTestServer(t, srv, uniqueProfile(t), []string{"api", "web"})
+	defer srv.Stop()
+	defer cancel()
+
+	c := NewClient()
+	require.NoError(t, c.Connect(srv.SocketPath()))
+
+	defer c.Close()
+
+	list, err := c.List()
+	require.NoError(t, err)
+	assert.Equal(t, services, list)
+}
+
+func Test_Client_NoStore(t *testing.T) {
+	srv := newTestServer(t)
+
+	cancel := startTestServer(t, srv, uniqueProfile(t), []string{"api"})
+	defer srv.Stop()
+	defer cancel()
+
+	c := NewClient()
+	require.NoError(t, c.Connect(srv.SocketPath()))
+
+	defer c.Close()
+
+	_, err := c.Status()
+	require.ErrorIs(t, err, errors.ErrRequestFailed)
+	assert.Contains(t, err.Error(), "control requests are not supported by this instance")
+}
+
+func Test_Client_ServiceCommands(t *testing.T) {
+	api := registry.ServiceSnapshot{ID: "id-api", Name: "api", Tier: "platform", Status: registry.StatusRunning}
+	web := registry.ServiceSnapshot{ID: "id-web", Name: "web", Tier: "edge", Status: registry.StatusStopped}
+
+	tests := []struct {
+		name    string
+		phase   bus.Phase
+		request func(Client) (registry.ServiceSnapshot, error)
+		command bus.MessageType
+		service registry.ServiceSnapshot
+		error   error
+	}{
+		{
+			name:    "stop by name",
+			phase:   bus.PhaseRunning,
+			request: func(c Client) (registry.ServiceSnapshot, error) { return c.Stop("api") },
+			command: bus.CommandStopService,
+			service: registry.ServiceSnapshot{ID: "id-api", Name: "api", Tier: "platform", Status: registry.StatusStopping},
+		},
+		{
+			name:    "restart by ID",
+			phase:   bus.PhaseRunning,
+			request: func(c Client) (registry.ServiceSnapshot, error) { return c.Restart("id-api") },
+			command: bus.CommandRestartService,
+			service: registry.ServiceSnapshot{ID: "id-api", Name: "api", Tier: "platform", Status: registry.StatusRestarting},
+		},
+		{
+			name:    "start",
+			phase:   bus.PhaseRunning,
+			request: func(c Client) (registry.ServiceSnapshot, error) { return c.Start("web") },
+			command: bus.CommandStartService,
+			service: registry.ServiceSnapshot{ID: "id-web", Name: "web", Tier: "edge", Status: registry.StatusStarting},
+		},
+		{
+			name:    "start a running service",
+			phase:   bus.PhaseRunning,
+			request: func(c Client) (registry.ServiceSnapshot, error) { return c.Start("api") },
+			error:   errors.ErrAPINotStartable,
+		},
+		{
+			name:    "stop a stopped service",
+			phase:   bus.PhaseRunning,
+			request: func(c Client) (registry.ServiceSnapshot, error) { return c.Stop("web") },
+			error:   errors.ErrAPINotRunning,
+		},
+		{
+			name:    "unknown service",
+			phase:   bus.PhaseRunning,
+			request: func(c Client) (registry.ServiceSnapshot, error) { return c.Restart("db") },
+			error:   errors.ErrAPIServiceNotFound,
+		},
+		{
+			name:    "not running",
+			phase:   bus.PhaseStartup,
+			request: func(c Client) (registry.ServiceSnapshot, error) { return c.Restart("api") },
+			error:   errors.ErrAPINotAccepting,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := registry.NewMockStore(ctrl)
+			mockStore.EXPECT().Phase().Return(string(tt.phase))
+			mockStore.EXPECT().Services().Return([]registry.ServiceSnapshot{api, web}).AnyTimes()
+			mockStore.EXPECT().Service("id-api").Return(api, true).AnyTimes()
+			mockStore.EXPECT().Service("db").Return(registry.ServiceSnapshot{}, false).AnyTimes()
+
+			b := bus.NewBus(config.DefaultConfig(), nil, nil)
+			defer b.Close()
+
+			srv := newTestServer(t)
+			srv.bus = b
+			srv.SetStore(mockStore)
+
+			cancel := startTestServer(t, srv, uniqueProfile(t), []string{"api", "web"})
+			defer srv.Stop()
+			defer cancel()
+
+			msgs := b.Subscribe(t.Context())
+
+			c := NewClient()
+			require.NoError(t, c.Connect(srv.SocketPath()))
+
+			defer c.Close()
+
+			svc, err := tt.request(c)
+			if tt.error != nil {
+				require.ErrorIs(t, err, errors.ErrRequestFailed)
+				assert.Contains(t, err.Error(), tt.error.Error())
+
+				select {
+				case msg := <-msgs:
+					t.Fatalf("unexpected %s on the bus", msg.Type)
+				case <-time.After(50 * time.Millisecond):
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.service, svc)
+
+			select {
+			case msg := <-msgs:
+				assert.Equal(t, tt.command, msg.Type)
+				assert.Equal(t, bus.Service{ID: tt.service.ID, Name: tt.service.Name}, msg.Data)
+			case <-time.After(time.Second):
+				t.Fatalf("request did not publish %s", tt.command)
+			}
+		})
+	}
+}
+
+func Test_Client_Shutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := registry.NewMockStore(ctrl)
+	mockStore.EXPECT().Phase().Return(string(bus.PhaseRunning))
+	mockStore.EXPECT().Uptime().Return(time.Minute)
+	mockStore.EXPECT().Counts().Return(registry.StatusCounts{})
+
+	b := bus.NewBus(config.DefaultConfig(), nil, nil)
+	defer b.Close()
+
+	srv := newTestServer(t)
+	srv.bus = b
+	srv.SetStore(mockStore)
+
+	cancel := startTestServer(t, srv, uniqueProfile(t), []string{"api"})
+	defer srv.Stop()
+	defer cancel()
+
+	msgs := b.Subscribe(t.Context())
+
+	c := NewClient()
+	require.NoError(t, c.Connect(srv.SocketPath()))
+
+	defer c.Close()
+
+	status, err := c.Shutdown()
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), status.PID)
+
+	select {
+	case msg := <-msgs:
+		assert.Equal(t, bus.CommandStopAll, msg.Type)
+	case <-time.After(time.Second):
+		t.Fatal("shutdown did not publish a stop all command")
+	}
+}
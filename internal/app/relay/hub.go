@@ -2,6 +2,7 @@ package relay
 
 import (
 	"context"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -40,13 +41,20 @@ func (c *ClientConn) SetSubscription(services []string) {
 	}
 }
 
-// ShouldReceive returns true if client should receive logs for the given service
+// ShouldReceive returns true if client should receive logs for the given service,
+// including hook output tagged as "<service>/<hook>"
 func (c *ClientConn) ShouldReceive(service string) bool {
 	if len(c.Services) == 0 {
 		return true
 	}
 
-	return c.Services[service]
+	if c.Services[service] {
+		return true
+	}
+
+	name, _, tagged := strings.Cut(service, "/")
+
+	return tagged && c.Services[name]
 }
 
 // ringBuffer is a fixed-size circular buffer for log message history
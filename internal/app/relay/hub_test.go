@@ -105,6 +105,18 @@ func Test_ClientConn_ShouldReceive(t *testing.T) {
 			check:    "db",
 			expected: false,
 		},
+		{
+			name:     "hook output of subscribed service",
+			services: []string{"api"},
+			check:    "api/before_start",
+			expected: true,
+		},
+		{
+			name:     "hook output of unsubscribed service",
+			services: []string{"api"},
+			check:    "db/after_stop",
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,5 +1,11 @@
 package relay
 
+import (
+	"time"
+
+	"fuku/internal/app/registry"
+)
+
 // MessageType represents the type of message in the wire protocol
 type MessageType string
 
@@ -11,6 +17,30 @@ const (
 	MessageLog MessageType = "log"
 	// MessageStatus is sent from server to client after subscribe with connection metadata
 	MessageStatus MessageType = "status"
+	// MessageAttach is sent from client to server to attach to a service's stdin and output
+	MessageAttach MessageType = "attach"
+	// MessageAttached is sent from server to client once the client is attached
+	MessageAttached MessageType = "attached"
+	// MessageInput is sent from an attached client to server with input for the service
+	MessageInput MessageType = "input"
+	// MessageOutput is sent from server to an attached client with raw service output
+	MessageOutput MessageType = "output"
+	// MessageError is sent from server to client when a request cannot be served
+	MessageError MessageType = "error"
+	// MessageStart is sent from client to server to start a service
+	MessageStart MessageType = "start"
+	// MessageStop is sent from client to server to stop a service
+	MessageStop MessageType = "stop"
+	// MessageRestart is sent from client to server to restart a service
+	MessageRestart MessageType = "restart"
+	// MessageInspect is sent from client to server to ask for the state of the instance
+	MessageInspect MessageType = "inspect"
+	// MessageList is sent from client to server to list the services
+	MessageList MessageType = "list"
+	// MessageShutdown is sent from client to server to stop all services and exit
+	MessageShutdown MessageType = "shutdown"
+	// MessageResponse is sent from server to client in reply to a control request
+	MessageResponse MessageType = "response"
 )
 
 // SubscribeRequest is sent from client to server to subscribe to log streams
@@ -34,6 +64,100 @@ type StatusMessage struct {
 	Services []string    `json:"services"`
 }
 
+// AttachRequest is sent from client to server to attach to a running service
+type AttachRequest struct {
+	Type    MessageType `json:"type"`
+	Service string      `json:"service"`
+}
+
+// AttachedMessage is sent from server to client once attached, telling whether the service runs under a terminal
+type AttachedMessage struct {
+	Type    MessageType `json:"type"`
+	Service string      `json:"service"`
+	TTY     bool        `json:"tty"`
+}
+
+// InputMessage is sent from an attached client to server with bytes for the service's stdin
+type InputMessage struct {
+	Type MessageType `json:"type"`
+	Data []byte      `json:"data"`
+}
+
+// OutputMessage is sent from server to an attached client with raw service output
+type OutputMessage struct {
+	Type MessageType `json:"type"`
+	Data []byte      `json:"data"`
+}
+
+// ErrorMessage is sent from server to client when a request cannot be served
+type ErrorMessage struct {
+	Type    MessageType `json:"type"`
+	Message string      `json:"message"`
+}
+
+// ControlRequest is sent from client to server to query or control the instance.
+// The server answers every request with a ControlResponse carrying the same ID
+type ControlRequest struct {
+	Type    MessageType `json:"type"`
+	ID      string      `json:"id"`
+	Service string      `json:"service,omitempty"` // name or ID, for start, stop and restart
+}
+
+// ControlResponse is sent from server to client in reply to the control request with the same ID
+type ControlResponse struct {
+	Type     MessageType     `json:"type"`
+	ID       string          `json:"id"`
+	Error    string          `json:"error,omitempty"`
+	Status   *InstanceStatus `json:"status,omitempty"`   // inspect and shutdown
+	Service  *Service        `json:"service,omitempty"`  // start, stop and restart
+	Services []Service       `json:"services,omitempty"` // list
+}
+
+// Service is a service snapshot as sent over the socket. Besides the fields the snapshot serializes, it carries
+// the store's bookkeeping, which the remote UI orders updates by and service commands wait on to settle
+type Service struct {
+	registry.ServiceSnapshot
+	AttemptStartedAt time.Time `json:"attempt_started_at"`
+	LifecycleAt      time.Time `json:"lifecycle_at"`
+	LifecycleSeq     uint64    `json:"lifecycle_seq"`
+	WatchAt          time.Time `json:"watch_at"`
+	WatchSeq         uint64    `json:"watch_seq"`
+}
+
+// newService wraps a snapshot for the socket
+func newService(s registry.ServiceSnapshot) Service {
+	return Service{
+		ServiceSnapshot:  s,
+		AttemptStartedAt: s.AttemptStartedAt,
+		LifecycleAt:      s.LifecycleAt,
+		LifecycleSeq:     s.LifecycleSeq,
+		WatchAt:          s.WatchAt,
+		WatchSeq:         s.WatchSeq,
+	}
+}
+
+// Snapshot returns the snapshot with its bookkeeping restored
+func (s Service) Snapshot() registry.ServiceSnapshot {
+	snapshot := s.ServiceSnapshot
+	snapshot.AttemptStartedAt = s.AttemptStartedAt
+	snapshot.LifecycleAt = s.LifecycleAt
+	snapshot.LifecycleSeq = s.LifecycleSeq
+	snapshot.WatchAt = s.WatchAt
+	snapshot.WatchSeq = s.WatchSeq
+
+	return snapshot
+}
+
+// InstanceStatus describes a running instance
+type InstanceStatus struct {
+	Version string                `json:"version"`
+	Profile string                `json:"profile"`
+	Phase   string                `json:"phase"`
+	PID     int                   `json:"pid"`
+	Uptime  time.Duration         `json:"uptime"`
+	Counts  registry.StatusCounts `json:"counts"`
+}
+
 // MessageEnvelope is used for type-based message dispatching
 type MessageEnvelope struct {
 	Type MessageType `json:"type"`
@@ -3,9 +3,12 @@ package relay
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/registry"
 )
 
 func Test_MessageType_Constants(t *testing.T) {
@@ -29,6 +32,66 @@ func Test_MessageType_Constants(t *testing.T) {
 			msgType:  MessageStatus,
 			expected: "status",
 		},
+		{
+			name:     "attach",
+			msgType:  MessageAttach,
+			expected: "attach",
+		},
+		{
+			name:     "attached",
+			msgType:  MessageAttached,
+			expected: "attached",
+		},
+		{
+			name:     "input",
+			msgType:  MessageInput,
+			expected: "input",
+		},
+		{
+			name:     "output",
+			msgType:  MessageOutput,
+			expected: "output",
+		},
+		{
+			name:     "error",
+			msgType:  MessageError,
+			expected: "error",
+		},
+		{
+			name:     "start",
+			msgType:  MessageStart,
+			expected: "start",
+		},
+		{
+			name:     "stop",
+			msgType:  MessageStop,
+			expected: "stop",
+		},
+		{
+			name:     "restart",
+			msgType:  MessageRestart,
+			expected: "restart",
+		},
+		{
+			name:     "inspect",
+			msgType:  MessageInspect,
+			expected: "inspect",
+		},
+		{
+			name:     "list",
+			msgType:  MessageList,
+			expected: "list",
+		},
+		{
+			name:     "shutdown",
+			msgType:  MessageShutdown,
+			expected: "shutdown",
+		},
+		{
+			name:     "response",
+			msgType:  MessageResponse,
+			expected: "response",
+		},
 	}
 
 	for _, tt := range tests {
@@ -166,6 +229,92 @@ func Test_StatusMessage_MarshalUnmarshal(t *testing.T) {
 	}
 }
 
+func Test_ControlRequest_MarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  ControlRequest
+		expected string
+	}{
+		{
+			name:     "service command",
+			message:  ControlRequest{Type: MessageRestart, ID: "1", Service: "api"},
+			expected: `{"type":"restart","id":"1","service":"api"}`,
+		},
+		{
+			name:     "instance query",
+			message:  ControlRequest{Type: MessageInspect, ID: "2"},
+			expected: `{"type":"inspect","id":"2"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.message)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+
+			var decoded ControlRequest
+
+			err = json.Unmarshal(data, &decoded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.message, decoded)
+		})
+	}
+}
+
+func Test_ControlResponse_MarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  ControlResponse
+		expected string
+	}{
+		{
+			name:     "error",
+			message:  ControlResponse{Type: MessageResponse, ID: "1", Error: "service not found"},
+			expected: `{"type":"response","id":"1","error":"service not found"}`,
+		},
+		{
+			name: "status",
+			message: ControlResponse{Type: MessageResponse, ID: "2", Status: &InstanceStatus{
+				Version: "0.17.0",
+				Profile: "core",
+				Phase:   "running",
+				PID:     42,
+				Uptime:  time.Second,
+				Counts:  registry.StatusCounts{Total: 1, Running: 1},
+			}},
+			expected: `{"type":"response","id":"2","status":{"version":"0.17.0","profile":"core","phase":"running","pid":42,"uptime":1000000000,` +
+				`"counts":{"total":1,"starting":0,"running":1,"stopping":0,"restarting":0,"stopped":0,"failed":0,"crash_loop":0,"completed":0,"build_failed":0}}}`,
+		},
+		{
+			name: "service",
+			message: ControlResponse{Type: MessageResponse, ID: "3", Service: &Service{
+				ServiceSnapshot: registry.ServiceSnapshot{ID: "id-api", Name: "api", Tier: "platform", Status: registry.StatusStopping},
+				LifecycleSeq:    7,
+				WatchSeq:        3,
+			}},
+			expected: `{"type":"response","id":"3","service":{"id":"id-api","name":"api","tier":"platform","status":"stopping","watching":false,` +
+				`"pid":0,"cpu":0,"memory":0,"processes":0,"attempt":0,"max_attempts":0,"restarts":0,"start_time":"0001-01-01T00:00:00Z",` +
+				`"attempt_started_at":"0001-01-01T00:00:00Z","lifecycle_at":"0001-01-01T00:00:00Z","lifecycle_seq":7,` +
+				`"watch_at":"0001-01-01T00:00:00Z","watch_seq":3}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.message)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+
+			var decoded ControlResponse
+
+			err = json.Unmarshal(data, &decoded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.message, decoded)
+		})
+	}
+}
+
 func Test_MessageEnvelope_Unmarshal(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -13,6 +13,7 @@ import (
 
 	"fuku/internal/app/bus"
 	"fuku/internal/app/errors"
+	"fuku/internal/app/registry"
 	"fuku/internal/config"
 	"fuku/internal/config/logger"
 )
@@ -35,6 +36,8 @@ type Server struct {
 	historySize int
 	listener    net.Listener
 	hub         Hub
+	attacher    Attacher
+	store       registry.Store
 	running     atomic.Bool
 	wg          sync.WaitGroup
 	connID      atomic.Int64
@@ -108,10 +111,6 @@ func (s *Server) activate(ctx context.Context, data bus.ProfileResolved) {
 		}
 	}
 
-	if err := Cleanup(config.SocketDir); err != nil {
-		s.log.Warn().Err(err).Msg("Socket cleanup failed, continuing startup")
-	}
-
 	if err := s.start(ctx); err != nil {
 		s.log.Warn().Err(err).Msg("Failed to start logs server, continuing without it")
 	}
@@ -125,7 +124,20 @@ func (s *Server) Broadcast(service, message string) {
 }
 
 func (s *Server) start(ctx context.Context) error {
-	s.socketPath = SocketPathForProfile(config.SocketDir, s.profile)
+	socketDir, err := SocketDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(socketDir, 0o700); err != nil {
+		return fmt.Errorf("%w %s: %w", errors.ErrFailedToListenSocket, socketDir, err)
+	}
+
+	if err := Cleanup(socketDir); err != nil {
+		s.log.Warn().Err(err).Msg("Socket cleanup failed, continuing startup")
+	}
+
+	s.socketPath = SocketPathForProfile(socketDir, s.profile)
 
 	conn, err := net.DialTimeout("unix", s.socketPath, config.SocketDialTimeout)
 	if err == nil {
@@ -147,6 +159,12 @@ func (s *Server) start(ctx context.Context) error {
 	s.running.Store(true)
 	s.log.Info().Msgf("Server listening on %s", s.socketPath)
 
+	s.bus.Publish(bus.Message{
+		Type:     bus.EventRelayStarted,
+		Data:     bus.RelayStarted{Socket: s.socketPath},
+		Critical: true,
+	})
+
 	ctx, cancel := context.WithCancel(ctx)
 	s.cancel = cancel
 
@@ -225,6 +243,32 @@ func (s *Server) handleConnection(ctx context.Context, conn net.Conn) {
 		return
 	}
 
+	var envelope MessageEnvelope
+	if err := json.Unmarshal(line, &envelope); err != nil {
+		s.log.Error().Err(err).Msgf("Failed to parse request from %s", clientID)
+
+		return
+	}
+
+	if isControl(envelope.Type) {
+		s.handleControl(ctx, conn, reader, clientID, line)
+
+		return
+	}
+
+	if envelope.Type == MessageAttach {
+		var req AttachRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.log.Error().Err(err).Msgf("Failed to parse attach request from %s", clientID)
+
+			return
+		}
+
+		s.handleAttach(ctx, conn, reader, clientID, req)
+
+		return
+	}
+
 	var req SubscribeRequest
 	if err := json.Unmarshal(line, &req); err != nil {
 		s.log.Error().Err(err).Msgf("Failed to parse subscribe request from %s", clientID)
@@ -34,6 +34,7 @@ func newTestServer(t *testing.T) *Server {
 	log := logger.NewLoggerWithOutput(cfg, io.Discard)
 
 	return &Server{
+		bus:         bus.NoOp(),
 		bufferSize:  cfg.Logs.Buffer,
 		historySize: cfg.Logs.History,
 		hub:         NewHub(cfg.Logs.Buffer, cfg.Logs.History, log),
@@ -89,6 +90,8 @@ func Test_Server_Run_ActivatesOnProfileResolved(t *testing.T) {
 	ctx, cancel := context.WithCancel(t.Context())
 	s.Subscribe(ctx)
 
+	events := b.Subscribe(ctx)
+
 	tierServices := []bus.Service{{ID: "test-id", Name: "api"}}
 	b.Publish(bus.Message{
 		Type: bus.EventProfileResolved,
@@ -113,6 +116,21 @@ func Test_Server_Run_ActivatesOnProfileResolved(t *testing.T) {
 
 	assert.Equal(t, "test", s.profile)
 
+	timeout := time.After(time.Second)
+
+	for started := false; !started; {
+		select {
+		case msg := <-events:
+			if data, ok := msg.Data.(bus.RelayStarted); ok {
+				assert.Equal(t, s.SocketPath(), data.Socket)
+
+				started = true
+			}
+		case <-timeout:
+			t.Fatal("relay started event was not published")
+		}
+	}
+
 	cancel()
 	s.Stop()
 }
@@ -197,7 +215,11 @@ func Test_Server_Broadcast_NotRunning(t *testing.T) {
 
 func Test_Server_Start_ActiveSocket_ReturnsError(t *testing.T) {
 	profile := uniqueProfile(t)
-	socketPath := SocketPathForProfile(config.SocketDir, profile)
+	socketDir, err := SocketDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(socketDir, 0o700))
+
+	socketPath := SocketPathForProfile(socketDir, profile)
 
 	listener, err := net.Listen("unix", socketPath)
 	require.NoError(t, err)
@@ -216,9 +238,13 @@ func Test_Server_Start_ActiveSocket_ReturnsError(t *testing.T) {
 
 func Test_Server_Start_RecoverFromStaleSocket(t *testing.T) {
 	profile := uniqueProfile(t)
-	socketPath := SocketPathForProfile(config.SocketDir, profile)
+	socketDir, err := SocketDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(socketDir, 0o700))
+
+	socketPath := SocketPathForProfile(socketDir, profile)
 
-	err := os.WriteFile(socketPath, []byte("stale"), 0600)
+	err = os.WriteFile(socketPath, []byte("stale"), 0600)
 	require.NoError(t, err)
 
 	defer os.Remove(socketPath)
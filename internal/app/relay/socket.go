@@ -11,6 +11,17 @@ import (
 	"fuku/internal/config"
 )
 
+// SocketDir returns the directory holding the sockets of the current project's instances, so instances
+// of different projects running the same profile never share a socket
+func SocketDir() (string, error) {
+	id, err := config.ProjectID()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrSocketSearchFailed, err)
+	}
+
+	return filepath.Join(config.SocketDir, config.SocketPrefix+id), nil
+}
+
 // SocketPathForProfile constructs the socket path for a given profile
 func SocketPathForProfile(socketDir, profile string) string {
 	return filepath.Join(socketDir, fmt.Sprintf("%s%s%s", config.SocketPrefix, profile, config.SocketSuffix))
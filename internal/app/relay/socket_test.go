@@ -62,6 +62,23 @@ func Test_SocketPathForProfile(t *testing.T) {
 	}
 }
 
+func Test_SocketDir(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	dir, err := SocketDir()
+	require.NoError(t, err)
+
+	id, err := config.ProjectID()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(config.SocketDir, config.SocketPrefix+id), dir)
+
+	t.Chdir(t.TempDir())
+
+	other, err := SocketDir()
+	require.NoError(t, err)
+	assert.NotEqual(t, dir, other)
+}
+
 func Test_FindSocket_ByProfile(t *testing.T) {
 	//nolint:usetesting // socket path length exceeds macOS limit with t.TempDir
 	tmpDir, err := os.MkdirTemp("/tmp", "fuku-test-")
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 
 	"fuku/internal/app/relay"
 	"fuku/internal/app/ui/components"
@@ -144,9 +145,9 @@ func (l *Log) Theme() components.Theme {
 	return l.theme
 }
 
-// FormatJSON formats a service log line as JSON
+// FormatJSON formats a service log line as JSON, without terminal escape codes
 func FormatJSON(service, message string) string {
-	return fmt.Sprintf(`{"service":%q,"message":%q}`+"\n", service, message)
+	return fmt.Sprintf(`{"service":%q,"message":%q}`+"\n", service, ansi.Strip(message))
 }
 
 // FormatMessage formats a service log message based on format type
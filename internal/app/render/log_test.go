@@ -167,6 +167,16 @@ func Test_FormatJSON(t *testing.T) {
 	assert.True(t, strings.HasSuffix(result, "\n"))
 }
 
+func Test_FormatJSON_StripsEscapeCodes(t *testing.T) {
+	result := FormatJSON("api", "\x1b[32mready\x1b[0m on :8080")
+
+	var parsed map[string]string
+
+	err := json.Unmarshal([]byte(result), &parsed)
+	require.NoError(t, err)
+	assert.Equal(t, "ready on :8080", parsed["message"])
+}
+
 func Test_hashString(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -58,6 +58,7 @@ type logEntry struct {
 	Component string `json:"component"`
 	Message   string `json:"message"`
 	Service   string `json:"service"`
+	Error     string `json:"error"`
 }
 
 // writeJSON outputs raw JSON
@@ -84,6 +85,10 @@ func (w *Writer) writeConsole(p []byte) int {
 	}
 
 	message := entry.Message
+	if entry.Error != "" {
+		message = fmt.Sprintf("%s: %s", message, entry.Error)
+	}
+
 	if entry.Component != "" {
 		message = fmt.Sprintf("[%s] %s", entry.Component, message)
 	}
@@ -83,6 +83,23 @@ func Test_Writer_Write_EnabledConsoleFormatValidJSON(t *testing.T) {
 	assert.Contains(t, output, "server started")
 }
 
+func Test_Writer_Write_ConsoleIncludesError(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Logging.Format = logger.ConsoleFormat
+	log := NewLog(false)
+
+	var buf bytes.Buffer
+
+	w := NewWriter(cfg, log, &buf)
+	w.SetEnabled(true)
+
+	input := `{"level":"error","component":"DAEMON","error":"fuku instance already running 'core'","message":"Failed to start detached fuku"}`
+	_, err := w.Write([]byte(input))
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "[DAEMON] Failed to start detached fuku: fuku instance already running 'core'")
+}
+
 func Test_Writer_Write_EnabledConsoleFormatInvalidJSON(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Logging.Format = logger.ConsoleFormat
@@ -0,0 +1,114 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+)
+
+const (
+	// buildStage tags build output as "<service>/build"
+	buildStage = "build"
+	// buildOutputLimit caps how much of the build output is kept for a failed build
+	buildOutputLimit = 16 * 1024
+)
+
+// build runs the service's build command, if one is configured, while any previous process keeps serving.
+// A failure is published together with the tail of the build output
+func (s *service) build(ctx context.Context, event bus.ServiceEvent, cfg *config.Service) error {
+	if cfg.Build == "" {
+		return nil
+	}
+
+	name := event.Service.Name
+	output := &outputTail{limit: buildOutputLimit}
+	startedAt := time.Now()
+
+	err := s.execBuild(ctx, event, cfg, output)
+	if err == nil {
+		s.log.Info().Msgf("Built service '%s' in %s", name, time.Since(startedAt))
+
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	err = fmt.Errorf("%w: %s", errors.ErrBuildFailed, buildSummary(output.String(), err))
+
+	s.log.Error().Err(err).Msgf("Build failed for service '%s'", name)
+	s.bus.Publish(bus.Message{
+		Type: bus.EventServiceBuildFailed,
+		Data: bus.ServiceBuildFailed{
+			ServiceEvent: event,
+			Output:       output.String(),
+			Duration:     time.Since(startedAt),
+			Error:        err,
+		},
+		Critical: true,
+	})
+
+	return err
+}
+
+// execBuild publishes the build start and runs the build command in the service directory and environment
+func (s *service) execBuild(ctx context.Context, event bus.ServiceEvent, cfg *config.Service, output *outputTail) error {
+	dir, env, err := s.commandEnv(event.Service.Name, cfg)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info().Msgf("Building service '%s': %s", event.Service.Name, cfg.Build)
+	s.bus.Publish(bus.Message{
+		Type:     bus.EventServiceBuilding,
+		Data:     bus.ServiceBuilding{ServiceEvent: event, Command: cfg.Build},
+		Critical: true,
+	})
+
+	return s.execTagged(ctx, event.Service.Name, buildStage, cfg.Build, config.BuildTimeout, dir, env, output)
+}
+
+// buildSummary returns the last line of the build output, which usually names the error, or the exit error
+func buildSummary(output string, err error) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return last
+	}
+
+	return err.Error()
+}
+
+// outputTail keeps the last bytes written to it, up to a fixed limit
+type outputTail struct {
+	mu    sync.Mutex
+	limit int
+	buf   []byte
+}
+
+// Write appends p, dropping the oldest bytes once the limit is exceeded
+func (t *outputTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.buf = append(t.buf, p...)
+	if over := len(t.buf) - t.limit; over > 0 {
+		t.buf = t.buf[over:]
+	}
+
+	return len(p), nil
+}
+
+// String returns the kept output
+func (t *outputTail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return string(t.buf)
+}
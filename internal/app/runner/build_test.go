@@ -0,0 +1,175 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/registry"
+	"fuku/internal/app/relay"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func Test_Build(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   string
+		output  []string
+		error   error
+		summary string
+	}{
+		{
+			name:   "success streams tagged output",
+			build:  "echo compiled $(basename $PWD); touch bin",
+			output: []string{"compiled api"},
+		},
+		{
+			name:    "failure publishes the compiler output",
+			build:   "echo './main.go:12:2: undefined: handler' >&2; exit 1",
+			output:  []string{"./main.go:12:2: undefined: handler"},
+			error:   errors.ErrBuildFailed,
+			summary: "build failed: ./main.go:12:2: undefined: handler",
+		},
+		{
+			name:    "silent failure reports the exit status",
+			build:   "exit 2",
+			error:   errors.ErrBuildFailed,
+			summary: "build failed: exit status 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := filepath.Join(t.TempDir(), "api")
+			require.NoError(t, os.Mkdir(dir, 0755))
+
+			cfg := config.DefaultConfig()
+			cfg.Services["api"] = &config.Service{Dir: dir, Build: tt.build}
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Info().Return(nil).AnyTimes()
+			mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			var output []string
+
+			mockBroadcaster := relay.NewMockBroadcaster(ctrl)
+			mockBroadcaster.EXPECT().Broadcast("api/build", gomock.Any()).Do(func(_, line string) {
+				output = append(output, line)
+			}).AnyTimes()
+
+			b := bus.NewBus(cfg, nil, nil)
+			defer b.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			msgs := b.Subscribe(ctx)
+
+			s := &service{cfg: cfg, bus: b, broadcaster: mockBroadcaster, log: mockLog}
+			event := bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}, Tier: "platform"}
+
+			err := s.build(ctx, event, cfg.Services["api"])
+			assert.Equal(t, tt.output, output)
+
+			building := <-msgs
+			require.Equal(t, bus.EventServiceBuilding, building.Type)
+			assert.Equal(t, bus.ServiceBuilding{ServiceEvent: event, Command: tt.build}, building.Data)
+
+			if tt.error == nil {
+				require.NoError(t, err)
+				assert.FileExists(t, filepath.Join(dir, "bin"))
+
+				return
+			}
+
+			require.ErrorIs(t, err, tt.error)
+			assert.Equal(t, tt.summary, err.Error())
+
+			failed := <-msgs
+			require.Equal(t, bus.EventServiceBuildFailed, failed.Type)
+
+			data, ok := failed.Data.(bus.ServiceBuildFailed)
+			require.True(t, ok)
+			assert.Equal(t, event, data.ServiceEvent)
+			assert.Equal(t, strings.Join(tt.output, "\n"), strings.TrimSpace(data.Output))
+			assert.ErrorIs(t, data.Error, errors.ErrBuildFailed)
+		})
+	}
+}
+
+func Test_Build_NotConfigured(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{Dir: "api"}
+
+	s := &service{cfg: cfg, bus: bus.NoOp()}
+	event := bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}}
+
+	assert.NoError(t, s.build(context.Background(), event, cfg.Services["api"]))
+}
+
+func Test_Restart_BuildFailureKeepsProcess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{Dir: dir, Command: "touch restarted", Build: "exit 1"}
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+	mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+	mockGuard := NewMockGuard(ctrl)
+	mockGuard.EXPECT().Lock("test-id-api").Return(true)
+	mockGuard.EXPECT().Unlock("test-id-api")
+
+	// The registry is never consulted: the running process is neither stopped nor replaced
+	mockRegistry := registry.NewMockRegistry(ctrl)
+
+	s := &service{
+		cfg:      cfg,
+		registry: mockRegistry,
+		guard:    mockGuard,
+		bus:      bus.NoOp(),
+		log:      mockLog,
+	}
+
+	s.Restart(context.Background(), bus.Service{ID: "test-id-api", Name: "api"})
+
+	assert.NoFileExists(t, filepath.Join(dir, "restarted"))
+}
+
+func Test_BuildSummary(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+
+	assert.Equal(t, "undefined: handler", buildSummary("compiling\n  undefined: handler  \n\n", exitErr))
+	assert.Equal(t, "exit status 1", buildSummary("", exitErr))
+}
+
+func Test_OutputTail(t *testing.T) {
+	tail := &outputTail{limit: 8}
+
+	n, err := tail.Write([]byte("hello "))
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+
+	_, err = tail.Write([]byte("world\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "o world\n", tail.String())
+}
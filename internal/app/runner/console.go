@@ -0,0 +1,146 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/app/process"
+	"fuku/internal/app/relay"
+)
+
+// consoleBufferSize is how many output chunks an attached client may fall behind before chunks are dropped
+const consoleBufferSize = 256
+
+// console holds a running service's stdin and fans its raw output out to attached clients
+type console struct {
+	stdin     io.Writer
+	tty       bool
+	mu        sync.Mutex
+	listeners map[chan []byte]struct{}
+	closed    bool
+}
+
+// newConsole creates a console writing input to stdin
+func newConsole(stdin io.Writer, tty bool) *console {
+	return &console{
+		stdin:     stdin,
+		tty:       tty,
+		listeners: make(map[chan []byte]struct{}),
+	}
+}
+
+// tap returns a reader that copies everything read from src to attached clients
+func (c *console) tap(src io.Reader) io.Reader {
+	return io.TeeReader(src, c)
+}
+
+// Write sends a copy of the output to every attached client, dropping it for clients that fell behind
+func (c *console) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.listeners) == 0 {
+		return len(p), nil
+	}
+
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+
+	for listener := range c.listeners {
+		select {
+		case listener <- chunk:
+		default:
+		}
+	}
+
+	return len(p), nil
+}
+
+// attach registers a new client, or returns false once the service has exited
+func (c *console) attach() (*relay.Attachment, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, false
+	}
+
+	output := make(chan []byte, consoleBufferSize)
+	c.listeners[output] = struct{}{}
+
+	return &relay.Attachment{
+		TTY:    c.tty,
+		Stdin:  c.stdin,
+		Output: output,
+		Detach: func() { c.detach(output) },
+	}, true
+}
+
+// detach removes a client, closing its output
+func (c *console) detach(output chan []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.listeners[output]; ok {
+		delete(c.listeners, output)
+		close(output)
+	}
+}
+
+// close detaches every client once the service has exited
+func (c *console) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+
+	for output := range c.listeners {
+		delete(c.listeners, output)
+		close(output)
+	}
+}
+
+// Attach connects a relay client to a running service's stdin and raw output
+func (s *service) Attach(name string) (*relay.Attachment, error) {
+	s.consolesMu.Lock()
+	c, ok := s.consoles[name]
+	s.consolesMu.Unlock()
+
+	if ok && c.stdin == nil {
+		return nil, fmt.Errorf("%w: %s, set 'tty: true' or 'stdin: true' to attach", errors.ErrServiceNotAttachable, name)
+	}
+
+	if ok {
+		if attachment, ok := c.attach(); ok {
+			return attachment, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errors.ErrServiceNotRunning, name)
+}
+
+// openConsole makes the process's console available for attaching until the process exits
+func (s *service) openConsole(name string, c *console, proc process.Process) {
+	s.consolesMu.Lock()
+	if s.consoles == nil {
+		s.consoles = make(map[string]*console)
+	}
+
+	s.consoles[name] = c
+	s.consolesMu.Unlock()
+
+	go func() {
+		<-proc.Done()
+
+		c.close()
+
+		s.consolesMu.Lock()
+		defer s.consolesMu.Unlock()
+
+		if s.consoles[name] == c {
+			delete(s.consoles, name)
+		}
+	}()
+}
@@ -0,0 +1,151 @@
+package runner
+
+import (
+	"context"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/lifecycle"
+	"fuku/internal/app/relay"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func Test_Console(t *testing.T) {
+	c := newConsole(nil, true)
+
+	n, err := c.Write([]byte("dropped"))
+	require.NoError(t, err)
+	assert.Equal(t, 7, n)
+
+	first, ok := c.attach()
+	require.True(t, ok)
+	assert.True(t, first.TTY)
+
+	second, ok := c.attach()
+	require.True(t, ok)
+
+	//nolint:errcheck // console writes never fail
+	c.Write([]byte("(dlv) "))
+
+	assert.Equal(t, []byte("(dlv) "), <-first.Output)
+	assert.Equal(t, []byte("(dlv) "), <-second.Output)
+
+	first.Detach()
+	first.Detach()
+
+	_, open := <-first.Output
+	assert.False(t, open)
+
+	c.close()
+
+	_, open = <-second.Output
+	assert.False(t, open)
+
+	_, ok = c.attach()
+	assert.False(t, ok)
+}
+
+func Test_Attach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{Dir: t.TempDir(), Command: `read line; echo "got $line"; sleep 0.2`, Stdin: true}
+
+	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+	mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+	mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(cmd *exec.Cmd, _ string, _ *config.Limits) {
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	})
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+	mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+	mockBroadcaster := relay.NewMockBroadcaster(ctrl)
+	mockBroadcaster.EXPECT().Broadcast(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s := &service{cfg: cfg, lifecycle: mockLifecycle, bus: bus.NoOp(), broadcaster: mockBroadcaster, consoles: make(map[string]*console), log: mockLog}
+
+	_, err := s.Attach("api")
+	require.ErrorIs(t, err, errors.ErrServiceNotRunning)
+
+	proc, err := s.doStart(context.Background(), "platform", bus.Service{ID: "test-id-api", Name: "api"}, cfg.Services["api"], startAttempt{number: 1, max: 1})
+	require.NoError(t, err)
+
+	attachment, err := s.Attach("api")
+	require.NoError(t, err)
+	assert.False(t, attachment.TTY)
+
+	defer attachment.Detach()
+
+	_, err = attachment.Stdin.Write([]byte("hello\n"))
+	require.NoError(t, err)
+
+	var output []byte
+
+	for chunk := range attachment.Output {
+		output = append(output, chunk...)
+	}
+
+	assert.Equal(t, "got hello\n", string(output))
+
+	select {
+	case <-proc.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("service did not exit")
+	}
+
+	assert.Eventually(t, func() bool {
+		_, err := s.Attach("api")
+
+		return errors.Is(err, errors.ErrServiceNotRunning)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_Attach_WithoutStdin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{Dir: t.TempDir(), Command: "cat; sleep 0.5"}
+
+	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+	mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+	mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(cmd *exec.Cmd, _ string, _ *config.Limits) {
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	})
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+	mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+	mockBroadcaster := relay.NewMockBroadcaster(ctrl)
+	mockBroadcaster.EXPECT().Broadcast(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s := &service{cfg: cfg, lifecycle: mockLifecycle, bus: bus.NoOp(), broadcaster: mockBroadcaster, consoles: make(map[string]*console), log: mockLog}
+
+	proc, err := s.doStart(context.Background(), "platform", bus.Service{ID: "test-id-api", Name: "api"}, cfg.Services["api"], startAttempt{number: 1, max: 1})
+	require.NoError(t, err)
+
+	_, err = s.Attach("api")
+	require.ErrorIs(t, err, errors.ErrServiceNotAttachable)
+
+	// stdin is /dev/null, so cat reads EOF at once instead of blocking
+	select {
+	case <-proc.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("service did not exit")
+	}
+}
@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/charmbracelet/x/term"
+
+	"fuku/internal/app/errors"
+)
+
+// execSignals are the signals Exec passes on to the command instead of exiting
+var execSignals = []os.Signal{syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGHUP}
+
+// Exec runs a command in a service's directory with the environment fuku builds for the service,
+// connected to fuku's stdin, stdout and stderr, and returns the command's exit code.
+// Signals fuku receives are passed on to the command. When stdin is a terminal the command shares
+// fuku's foreground process group, so Ctrl-C and Ctrl-\ already reach it and are not sent twice
+func (r *runner) Exec(ctx context.Context, name string, args []string) (int, error) {
+	dir, env, err := r.service.Environment(name)
+	if err != nil {
+		return 1, err
+	}
+
+	//nolint:gosec // the command is given by the user on the command line
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// fx shuts the app down on SIGINT and SIGTERM, which would end the command with it,
+	// so the signals are taken over while the command runs
+	signal.Reset(execSignals...)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, execSignals...)
+
+	defer signal.Stop(signals)
+
+	if err := cmd.Start(); err != nil {
+		return 1, fmt.Errorf("%w: %w", errors.ErrFailedToStartCommand, err)
+	}
+
+	r.log.Debug().Msgf("Running '%s' for service '%s' (PID: %d)", cmd.String(), name, cmd.Process.Pid)
+
+	interactive := term.IsTerminal(os.Stdin.Fd())
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	for {
+		select {
+		case sig := <-signals:
+			if interactive && (sig == syscall.SIGINT || sig == syscall.SIGQUIT) {
+				continue
+			}
+
+			_ = cmd.Process.Signal(sig)
+		case err := <-done:
+			return execExitCode(cmd, err)
+		}
+	}
+}
+
+// execExitCode returns the exit code of a finished command, using 128 plus the signal number
+// for a command killed by a signal, as shells do
+func execExitCode(cmd *exec.Cmd, err error) (int, error) {
+	if cmd.ProcessState == nil {
+		return 1, err
+	}
+
+	if status, ok := cmd.ProcessState.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal()), nil
+	}
+
+	return cmd.ProcessState.ExitCode(), nil
+}
@@ -0,0 +1,95 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/config/logger"
+)
+
+func Test_Exec(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+		error    error
+	}{
+		{name: "success", args: []string{"true"}},
+		{name: "exit code is passed through", args: []string{"sh", "-c", "exit 3"}, exitCode: 3},
+		{name: "killed by a signal", args: []string{"sh", "-c", "kill -TERM $$"}, exitCode: 143},
+		{name: "command not found", args: []string{"fuku-no-such-command"}, exitCode: 1, error: errors.ErrFailedToStartCommand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := t.TempDir()
+
+			mockService := NewMockService(ctrl)
+			mockService.EXPECT().Environment("api").Return(dir, append(os.Environ(), "DB_NAME=fuku"), nil)
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Debug().Return(nil).AnyTimes()
+
+			r := &runner{service: mockService, log: mockLog}
+
+			exitCode, err := r.Exec(context.Background(), "api", tt.args)
+			assert.Equal(t, tt.exitCode, exitCode)
+
+			if tt.error != nil {
+				require.ErrorIs(t, err, tt.error)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_Exec_RunsInServiceDirectory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Environment("api").Return(dir, append(os.Environ(), "DB_NAME=fuku"), nil)
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Debug().Return(nil).AnyTimes()
+
+	r := &runner{service: mockService, log: mockLog}
+
+	exitCode, err := r.Exec(context.Background(), "api", []string{"sh", "-c", "pwd > out; echo $DB_NAME >> out"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+
+	out, err := os.ReadFile(filepath.Join(dir, "out"))
+	require.NoError(t, err)
+	assert.Equal(t, dir+"\nfuku\n", string(out))
+}
+
+func Test_Exec_ServiceNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Environment("db").Return("", nil, errors.ErrServiceNotFound)
+
+	r := &runner{service: mockService, log: logger.NewMockLogger(ctrl)}
+
+	exitCode, err := r.Exec(context.Background(), "db", []string{"true"})
+	require.ErrorIs(t, err, errors.ErrServiceNotFound)
+	assert.Equal(t, 1, exitCode)
+}
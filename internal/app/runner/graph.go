@@ -0,0 +1,185 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+)
+
+// startNode tracks a single service while the startup graph is being executed
+type startNode struct {
+	ref      bus.Service
+	tier     *tierProgress
+	deps     []*startNode
+	explicit bool
+	done     chan struct{}
+	failed   bool
+}
+
+// tierProgress tracks how many services of a tier are still starting
+type tierProgress struct {
+	name      string
+	index     int
+	policy    config.Tier
+	slots     chan struct{}
+	services  []string
+	remaining int
+	failed    []string
+	started   bool
+	startedAt time.Time
+	ctx       context.Context //nolint:containedctx // tier deadline shared by the tier's starting services
+	cancel    context.CancelFunc
+}
+
+// startGraph holds the dependency graph for a startup run
+type startGraph struct {
+	mu      sync.Mutex
+	nodes   []*startNode
+	tiers   []*tierProgress
+	aborted string
+}
+
+// buildStartGraph links each service to its depends_on entries, or to every service
+// in earlier tiers when it declares none, so tiers keep acting as coarse ordering
+func (r *runner) buildStartGraph(tiers []bus.Tier) *startGraph {
+	graph := &startGraph{}
+	byName := make(map[string]*startNode)
+
+	var earlier []*startNode
+
+	for _, tier := range tiers {
+		if len(tier.Services) == 0 {
+			continue
+		}
+
+		progress := &tierProgress{
+			name:      tier.Name,
+			index:     len(graph.tiers),
+			policy:    r.cfg.TierPolicy(tier.Name),
+			services:  serviceNames(tier.Services),
+			remaining: len(tier.Services),
+		}
+
+		if progress.policy.Concurrency > 0 {
+			progress.slots = make(chan struct{}, progress.policy.Concurrency)
+		}
+
+		graph.tiers = append(graph.tiers, progress)
+
+		current := make([]*startNode, 0, len(tier.Services))
+
+		for _, ref := range tier.Services {
+			node := &startNode{
+				ref:  ref,
+				tier: progress,
+				done: make(chan struct{}),
+			}
+
+			byName[ref.Name] = node
+			current = append(current, node)
+		}
+
+		for _, node := range current {
+			cfg, exists := r.cfg.Services[node.ref.Name]
+			if exists && len(cfg.DependsOn) > 0 {
+				node.explicit = true
+
+				for _, dep := range cfg.DependsOn {
+					if depNode, ok := byName[dep]; ok && depNode != node {
+						node.deps = append(node.deps, depNode)
+					}
+				}
+
+				continue
+			}
+
+			node.deps = earlier
+		}
+
+		graph.nodes = append(graph.nodes, current...)
+		earlier = append(earlier[:len(earlier):len(earlier)], current...)
+	}
+
+	return graph
+}
+
+// awaitDependencies blocks until all dependencies finish and returns why the node must be skipped, if at all.
+// A failed dependency skips the node when it was named in depends_on or its tier uses on_failure: skip-dependents.
+func (g *startGraph) awaitDependencies(ctx context.Context, node *startNode) error {
+	for _, dep := range node.deps {
+		select {
+		case <-dep.done:
+		case <-ctx.Done():
+			return nil
+		}
+
+		if dep.failed && (node.explicit || dep.tier.policy.OnFailure == config.OnFailureSkipDependents) {
+			return fmt.Errorf("%w: '%s'", errors.ErrDependencyFailed, dep.ref.Name)
+		}
+	}
+
+	if tier := g.abortedTier(); tier != "" {
+		return fmt.Errorf("%w: startup aborted after tier '%s' failed", errors.ErrTierFailed, tier)
+	}
+
+	return nil
+}
+
+// beginTier marks a tier as started, arming its timeout, and reports whether this call started it
+func (g *startGraph) beginTier(ctx context.Context, progress *tierProgress) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if progress.started {
+		return false
+	}
+
+	progress.started = true
+	progress.startedAt = time.Now()
+	progress.ctx, progress.cancel = ctx, func() {}
+
+	if progress.policy.Timeout > 0 {
+		progress.ctx, progress.cancel = context.WithTimeout(ctx, progress.policy.Timeout)
+	}
+
+	return true
+}
+
+// finishService records a service result and reports whether its tier has completed
+func (g *startGraph) finishService(progress *tierProgress, name string, ok bool) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !ok {
+		progress.failed = append(progress.failed, name)
+	}
+
+	progress.remaining--
+
+	if progress.remaining > 0 {
+		return false
+	}
+
+	if progress.cancel != nil {
+		progress.cancel()
+	}
+
+	if len(progress.failed) > 0 && progress.policy.OnFailure == config.OnFailureAbort && g.aborted == "" {
+		g.aborted = progress.name
+	}
+
+	return true
+}
+
+// abortedTier returns the name of the tier that aborted startup, if any
+func (g *startGraph) abortedTier() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.aborted
+}
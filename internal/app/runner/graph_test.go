@@ -0,0 +1,420 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/worker"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func testTiers() []bus.Tier {
+	return []bus.Tier{
+		{Name: "foundation", Services: []bus.Service{{ID: "id-db", Name: "db"}, {ID: "id-cache", Name: "cache"}}},
+		{Name: "platform", Services: []bus.Service{{ID: "id-api", Name: "api"}}},
+		{Name: "edge", Services: []bus.Service{{ID: "id-web", Name: "web"}}},
+	}
+}
+
+func Test_BuildStartGraph(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Services["db"] = &config.Service{}
+	cfg.Services["cache"] = &config.Service{}
+	cfg.Services["api"] = &config.Service{DependsOn: []string{"db"}}
+	cfg.Services["web"] = &config.Service{}
+
+	r := &runner{cfg: cfg}
+	graph := r.buildStartGraph(testTiers())
+
+	require.Len(t, graph.nodes, 4)
+	require.Len(t, graph.tiers, 3)
+
+	deps := make(map[string][]string)
+	explicit := make(map[string]bool)
+
+	for _, node := range graph.nodes {
+		names := make([]string, 0, len(node.deps))
+		for _, dep := range node.deps {
+			names = append(names, dep.ref.Name)
+		}
+
+		deps[node.ref.Name] = names
+		explicit[node.ref.Name] = node.explicit
+	}
+
+	assert.Empty(t, deps["db"])
+	assert.Empty(t, deps["cache"])
+	assert.Equal(t, []string{"db"}, deps["api"])
+	assert.Equal(t, []string{"db", "cache", "api"}, deps["web"])
+	assert.True(t, explicit["api"])
+	assert.False(t, explicit["web"])
+}
+
+func Test_StartAllTiers_DependencyOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["db"] = &config.Service{}
+	cfg.Services["cache"] = &config.Service{}
+	cfg.Services["api"] = &config.Service{DependsOn: []string{"db"}}
+	cfg.Services["web"] = &config.Service{}
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+
+	apiStarted := make(chan struct{})
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Start(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, svc bus.Service) error {
+			if svc.Name == "cache" {
+				<-apiStarted
+			}
+
+			mu.Lock()
+			order = append(order, svc.Name)
+			mu.Unlock()
+
+			if svc.Name == "api" {
+				close(apiStarted)
+			}
+
+			return nil
+		}).Times(4)
+
+	mockWorkerPool := worker.NewMockPool(ctrl)
+	mockWorkerPool.EXPECT().Acquire(gomock.Any()).Return(nil).AnyTimes()
+	mockWorkerPool.EXPECT().Release().AnyTimes()
+
+	r := &runner{
+		cfg:     cfg,
+		service: mockService,
+		worker:  mockWorkerPool,
+		bus:     bus.NoOp(),
+		log:     mockLog,
+	}
+
+	r.startAllTiers(context.Background(), testTiers())
+
+	require.Len(t, order, 4)
+	assert.Less(t, indexOf(order, "db"), indexOf(order, "api"))
+	assert.Less(t, indexOf(order, "api"), indexOf(order, "cache"), "api should not wait for unrelated foundation services")
+	assert.Equal(t, "web", order[3], "services without depends_on wait for all earlier tiers")
+}
+
+func Test_StartAllTiers_DependencyFailedSkipsDependent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["db"] = &config.Service{}
+	cfg.Services["cache"] = &config.Service{}
+	cfg.Services["api"] = &config.Service{DependsOn: []string{"db"}}
+	cfg.Services["web"] = &config.Service{}
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+	mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Start(gomock.Any(), "foundation", bus.Service{ID: "id-db", Name: "db"}).Return(errors.ErrMaxRetriesExceeded)
+	mockService.EXPECT().Start(gomock.Any(), "foundation", bus.Service{ID: "id-cache", Name: "cache"}).Return(nil)
+	mockService.EXPECT().Start(gomock.Any(), "edge", bus.Service{ID: "id-web", Name: "web"}).Return(nil)
+
+	mockWorkerPool := worker.NewMockPool(ctrl)
+	mockWorkerPool.EXPECT().Acquire(gomock.Any()).Return(nil).AnyTimes()
+	mockWorkerPool.EXPECT().Release().AnyTimes()
+
+	b := bus.NewBus(cfg, nil, nil)
+	defer b.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs := b.Subscribe(ctx)
+
+	r := &runner{
+		cfg:     cfg,
+		service: mockService,
+		worker:  mockWorkerPool,
+		bus:     b,
+		log:     mockLog,
+	}
+
+	r.startAllTiers(ctx, testTiers())
+
+	var failed *bus.ServiceFailed
+
+	for failed == nil {
+		msg := <-msgs
+		if data, ok := msg.Data.(bus.ServiceFailed); ok && msg.Type == bus.EventServiceFailed {
+			failed = &data
+		}
+	}
+
+	assert.Equal(t, "api", failed.Service.Name)
+	assert.ErrorIs(t, failed.Error, errors.ErrDependencyFailed)
+}
+
+func indexOf(items []string, target string) int {
+	for i, item := range items {
+		if item == target {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func Test_StartAllTiers_TierPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    config.Tier
+		explicit  bool
+		started   []string
+		skipped   []string
+		error     error
+		skipError error
+	}{
+		{
+			name:    "continue starts later tiers after a failure",
+			policy:  config.Tier{Name: "foundation", OnFailure: config.OnFailureContinue},
+			started: []string{"db", "cache", "api", "web"},
+		},
+		{
+			name:      "abort skips all later tiers",
+			policy:    config.Tier{Name: "foundation", OnFailure: config.OnFailureAbort},
+			started:   []string{"db", "cache"},
+			skipped:   []string{"api", "web"},
+			error:     errors.ErrTierFailed,
+			skipError: errors.ErrTierFailed,
+		},
+		{
+			name:      "skip-dependents skips implicit dependents",
+			policy:    config.Tier{Name: "foundation", OnFailure: config.OnFailureSkipDependents},
+			started:   []string{"db", "cache"},
+			skipped:   []string{"api", "web"},
+			skipError: errors.ErrDependencyFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cfg := config.DefaultConfig()
+			cfg.Tiers = []config.Tier{tt.policy}
+			cfg.Services["db"] = &config.Service{}
+			cfg.Services["cache"] = &config.Service{}
+			cfg.Services["api"] = &config.Service{}
+			cfg.Services["web"] = &config.Service{}
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Info().Return(nil).AnyTimes()
+			mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			var (
+				mu      sync.Mutex
+				started []string
+			)
+
+			mockService := NewMockService(ctrl)
+			mockService.EXPECT().Start(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, svc bus.Service) error {
+					mu.Lock()
+					started = append(started, svc.Name)
+					mu.Unlock()
+
+					if svc.Name == "cache" {
+						return errors.ErrMaxRetriesExceeded
+					}
+
+					return nil
+				}).AnyTimes()
+
+			mockWorkerPool := worker.NewMockPool(ctrl)
+			mockWorkerPool.EXPECT().Acquire(gomock.Any()).Return(nil).AnyTimes()
+			mockWorkerPool.EXPECT().Release().AnyTimes()
+
+			b := bus.NewBus(cfg, nil, nil)
+			defer b.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			msgs := b.Subscribe(ctx)
+
+			r := &runner{
+				cfg:     cfg,
+				service: mockService,
+				worker:  mockWorkerPool,
+				bus:     b,
+				log:     mockLog,
+			}
+
+			err := r.startAllTiers(ctx, testTiers())
+			if tt.error != nil {
+				assert.ErrorIs(t, err, tt.error)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.ElementsMatch(t, tt.started, started)
+
+			skipped := make(map[string]error)
+
+			for len(skipped) < len(tt.skipped) {
+				msg := <-msgs
+				if data, ok := msg.Data.(bus.ServiceFailed); ok && msg.Type == bus.EventServiceFailed && data.Service.Name != "cache" {
+					skipped[data.Service.Name] = data.Error
+				}
+			}
+
+			for _, name := range tt.skipped {
+				assert.ErrorIs(t, skipped[name], tt.skipError, name)
+			}
+		})
+	}
+}
+
+func Test_StartAllTiers_TierConcurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Tiers = []config.Tier{{Name: "foundation", Concurrency: 1}}
+	cfg.Services["db"] = &config.Service{}
+	cfg.Services["cache"] = &config.Service{}
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+
+	var (
+		mu      sync.Mutex
+		running int
+		peak    int
+	)
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Start(gomock.Any(), "foundation", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, _ bus.Service) error {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			return nil
+		}).Times(2)
+
+	mockWorkerPool := worker.NewMockPool(ctrl)
+	mockWorkerPool.EXPECT().Acquire(gomock.Any()).Return(nil).AnyTimes()
+	mockWorkerPool.EXPECT().Release().AnyTimes()
+
+	r := &runner{
+		cfg:     cfg,
+		service: mockService,
+		worker:  mockWorkerPool,
+		bus:     bus.NoOp(),
+		log:     mockLog,
+	}
+
+	require.NoError(t, r.startAllTiers(context.Background(), testTiers()[:1]))
+	assert.Equal(t, 1, peak)
+}
+
+func Test_StartAllTiers_TierTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		err  func(ctx context.Context) error
+	}{
+		{name: "start cut short", err: func(ctx context.Context) error { return ctx.Err() }},
+		{name: "start failed after the deadline", err: func(context.Context) error {
+			return fmt.Errorf("%w: %w", errors.ErrMaxRetriesExceeded, errors.ErrReadinessTimeout)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cfg := config.DefaultConfig()
+			cfg.Tiers = []config.Tier{{Name: "foundation", Timeout: 20 * time.Millisecond}}
+			cfg.Services["db"] = &config.Service{}
+			cfg.Services["cache"] = &config.Service{}
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Info().Return(nil).AnyTimes()
+			mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			mockService := NewMockService(ctrl)
+			mockService.EXPECT().Start(gomock.Any(), "foundation", bus.Service{ID: "id-db", Name: "db"}).Return(nil)
+			mockService.EXPECT().Start(gomock.Any(), "foundation", bus.Service{ID: "id-cache", Name: "cache"}).DoAndReturn(
+				func(ctx context.Context, _ string, _ bus.Service) error {
+					<-ctx.Done()
+
+					return tt.err(ctx)
+				})
+
+			mockWorkerPool := worker.NewMockPool(ctrl)
+			mockWorkerPool.EXPECT().Acquire(gomock.Any()).Return(nil).AnyTimes()
+			mockWorkerPool.EXPECT().Release().AnyTimes()
+
+			b := bus.NewBus(cfg, nil, nil)
+			defer b.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			msgs := b.Subscribe(ctx)
+
+			r := &runner{
+				cfg:     cfg,
+				service: mockService,
+				worker:  mockWorkerPool,
+				bus:     b,
+				log:     mockLog,
+			}
+
+			require.NoError(t, r.startAllTiers(ctx, testTiers()[:1]))
+
+			var failed *bus.ServiceFailed
+
+			for failed == nil {
+				msg := <-msgs
+				if data, ok := msg.Data.(bus.ServiceFailed); ok && msg.Type == bus.EventServiceFailed {
+					failed = &data
+				}
+			}
+
+			assert.Equal(t, "cache", failed.Service.Name)
+			assert.ErrorIs(t, failed.Error, errors.ErrTierTimeout)
+		})
+	}
+}
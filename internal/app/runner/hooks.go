@@ -0,0 +1,116 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+)
+
+// commandWaitDelay bounds how long a finished or killed hook or build may keep its output pipes open
+const commandWaitDelay = time.Second
+
+// runHook runs a service's hook for the given stage in the service directory and environment.
+// Output is logged and broadcast as "<service>/<stage>", and the start and finish are published
+// on the bus. A failure is returned only when the hook's on_error is fail
+func (s *service) runHook(ctx context.Context, event bus.ServiceEvent, stage, dir string, env []string) error {
+	hook, ok := s.cfg.HookPolicy(event.Service.Name, stage)
+	if !ok {
+		return nil
+	}
+
+	s.log.Info().Msgf("Running %s hook for service '%s': %s", stage, event.Service.Name, hook.Command)
+	s.bus.Publish(bus.Message{
+		Type:     bus.EventHookStarted,
+		Data:     bus.HookStarted{ServiceEvent: event, Hook: stage, Command: hook.Command},
+		Critical: true,
+	})
+
+	startedAt := time.Now()
+	err := s.execHook(ctx, event.Service.Name, stage, hook, dir, env)
+
+	s.bus.Publish(bus.Message{
+		Type:     bus.EventHookFinished,
+		Data:     bus.HookFinished{ServiceEvent: event, Hook: stage, Duration: time.Since(startedAt), Error: err},
+		Critical: true,
+	})
+
+	if err == nil {
+		return nil
+	}
+
+	if hook.OnError == config.HookOnErrorWarn {
+		s.log.Warn().Err(err).Msgf("Ignoring failed %s hook for service '%s'", stage, event.Service.Name)
+
+		return nil
+	}
+
+	s.log.Error().Err(err).Msgf("Hook %s failed for service '%s'", stage, event.Service.Name)
+
+	return err
+}
+
+// execHook runs the hook command, wrapping any failure as a hook failure
+func (s *service) execHook(ctx context.Context, name, stage string, hook config.Hook, dir string, env []string) error {
+	if err := s.execTagged(ctx, name, stage, hook.Command, hook.Timeout, dir, env, io.Discard); err != nil {
+		return fmt.Errorf("%w: %s: %w", errors.ErrHookFailed, stage, err)
+	}
+
+	return nil
+}
+
+// execTagged runs a shell command in its own process group, killing the group once the timeout elapses.
+// Output is logged and broadcast as "<service>/<stage>" and copied to out
+func (s *service) execTagged(ctx context.Context, name, stage, command string, timeout time.Duration, dir string, env []string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	//nolint:gosec // hook and build commands come from the user's own config file
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = commandWaitDelay
+
+	tag := name + "/" + stage
+
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
+
+	done := make(chan struct{}, 2)
+
+	go func() {
+		s.teeTagged(stdoutReader, out, name, tag, "STDOUT")
+		done <- struct{}{}
+	}()
+
+	go func() {
+		s.teeTagged(stderrReader, out, name, tag, "STDERR")
+		done <- struct{}{}
+	}()
+
+	err := cmd.Run()
+
+	stdoutWriter.Close()
+	stderrWriter.Close()
+
+	<-done
+	<-done
+
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+
+	return err
+}
@@ -0,0 +1,296 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/lifecycle"
+	"fuku/internal/app/process"
+	"fuku/internal/app/readiness"
+	"fuku/internal/app/registry"
+	"fuku/internal/app/relay"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func Test_RunHook(t *testing.T) {
+	tests := []struct {
+		name   string
+		hook   *config.Hook
+		output []string
+		failed bool
+		error  error
+	}{
+		{
+			name:   "success streams tagged output",
+			hook:   &config.Hook{Command: "echo migrated $DB_NAME from $(basename $PWD)"},
+			output: []string{"migrated fuku from api"},
+		},
+		{
+			name:   "failure with on_error fail",
+			hook:   &config.Hook{Command: "exit 2"},
+			failed: true,
+			error:  errors.ErrHookFailed,
+		},
+		{
+			name:   "failure with on_error warn",
+			hook:   &config.Hook{Command: "exit 2", OnError: config.HookOnErrorWarn},
+			failed: true,
+		},
+		{
+			name:   "timeout kills the hook",
+			hook:   &config.Hook{Command: "sleep 10", Timeout: 100 * time.Millisecond},
+			failed: true,
+			error:  errors.ErrHookFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := filepath.Join(t.TempDir(), "api")
+			require.NoError(t, os.Mkdir(dir, 0755))
+
+			cfg := config.DefaultConfig()
+			cfg.Services["api"] = &config.Service{Dir: dir, Hooks: &config.Hooks{BeforeStart: tt.hook}}
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Info().Return(nil).AnyTimes()
+			mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			var output []string
+
+			mockBroadcaster := relay.NewMockBroadcaster(ctrl)
+			mockBroadcaster.EXPECT().Broadcast("api/before_start", gomock.Any()).Do(func(_, line string) {
+				output = append(output, line)
+			}).AnyTimes()
+
+			b := bus.NewBus(cfg, nil, nil)
+			defer b.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			msgs := b.Subscribe(ctx)
+
+			s := &service{cfg: cfg, bus: b, broadcaster: mockBroadcaster, log: mockLog}
+			event := bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}, Tier: "platform"}
+
+			err := s.runHook(ctx, event, config.HookBeforeStart, dir, append(os.Environ(), "DB_NAME=fuku"))
+			if tt.error != nil {
+				require.ErrorIs(t, err, tt.error)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.output, output)
+
+			started := <-msgs
+			require.Equal(t, bus.EventHookStarted, started.Type)
+			assert.Equal(t, bus.HookStarted{ServiceEvent: event, Hook: config.HookBeforeStart, Command: tt.hook.Command}, started.Data)
+
+			finished := <-msgs
+			require.Equal(t, bus.EventHookFinished, finished.Type)
+
+			data, ok := finished.Data.(bus.HookFinished)
+			require.True(t, ok)
+			assert.Equal(t, config.HookBeforeStart, data.Hook)
+			assert.Equal(t, tt.failed, data.Error != nil)
+		})
+	}
+}
+
+func Test_RunHook_NotConfigured(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{Dir: "api"}
+
+	s := &service{cfg: cfg, bus: bus.NoOp()}
+	event := bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}}
+
+	assert.NoError(t, s.runHook(context.Background(), event, config.HookAfterReady, "api", nil))
+}
+
+func Test_Start_BeforeStartHook(t *testing.T) {
+	tests := []struct {
+		name     string
+		hook     string
+		runs     int
+		attempts []int
+		error    error
+	}{
+		{
+			name:     "runs once across retries",
+			hook:     "echo run >> hook.log",
+			runs:     1,
+			attempts: []int{1, 2, 3},
+			error:    errors.ErrReadinessTimeout,
+		},
+		{
+			name:     "failed hook runs again until it succeeds",
+			hook:     "echo run >> hook.log; test -f ok || { touch ok; exit 1; }",
+			runs:     2,
+			attempts: []int{2, 3},
+			error:    errors.ErrReadinessTimeout,
+		},
+		{
+			name:  "failing hook never launches the service",
+			hook:  "echo run >> hook.log; exit 1",
+			runs:  3,
+			error: errors.ErrHookFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := t.TempDir()
+
+			cfg := config.DefaultConfig()
+			cfg.Services["api"] = &config.Service{
+				Dir:       dir,
+				Command:   "sleep 60",
+				Readiness: &config.Readiness{Type: config.TypeHTTP, URL: "http://127.0.0.1:1/health"},
+				Hooks:     &config.Hooks{BeforeStart: &config.Hook{Command: tt.hook}},
+			}
+			cfg.Retry = config.Retry{Attempts: 3, Backoff: time.Millisecond}
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Info().Return(nil).AnyTimes()
+			mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+			mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+			mockLifecycle.EXPECT().Terminate(gomock.Any(), gomock.Any()).DoAndReturn(func(proc process.Process, _ config.Stop) error {
+				return proc.Cmd().Process.Kill()
+			}).AnyTimes()
+
+			mockReadiness := readiness.NewMockReadiness(ctrl)
+			mockReadiness.EXPECT().Check(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(
+				func(_ context.Context, _ bus.Service, _ *config.Service, proc process.Process) {
+					proc.SignalReady(errors.ErrReadinessTimeout)
+				}).AnyTimes()
+
+			mockBroadcaster := relay.NewMockBroadcaster(ctrl)
+			mockBroadcaster.EXPECT().Broadcast(gomock.Any(), gomock.Any()).AnyTimes()
+
+			b := bus.NewBus(cfg, nil, nil)
+			defer b.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			msgs := b.Subscribe(ctx)
+
+			s := &service{
+				cfg:         cfg,
+				lifecycle:   mockLifecycle,
+				readiness:   mockReadiness,
+				bus:         b,
+				broadcaster: mockBroadcaster,
+				log:         mockLog,
+			}
+
+			err := s.Start(ctx, "platform", bus.Service{ID: "test-id-api", Name: "api"})
+			require.ErrorIs(t, err, errors.ErrMaxRetriesExceeded)
+			assert.ErrorIs(t, err, tt.error)
+
+			var attempts []int
+
+			for msg := range msgs {
+				if data, ok := msg.Data.(bus.ServiceStarting); ok {
+					attempts = append(attempts, data.Attempt)
+				}
+
+				if msg.Type == bus.EventServiceFailed {
+					break
+				}
+			}
+
+			assert.Equal(t, tt.attempts, attempts)
+
+			log, err := os.ReadFile(filepath.Join(dir, "hook.log"))
+			require.NoError(t, err)
+			assert.Equal(t, strings.Repeat("run\n", tt.runs), string(log))
+		})
+	}
+}
+
+func Test_Relaunch_SkipsBuildAndBeforeStartHook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{
+		Dir:     dir,
+		Tier:    "platform",
+		Command: "sleep 60",
+		Build:   "touch built",
+		Hooks:   &config.Hooks{BeforeStart: &config.Hook{Command: "touch hook.log"}},
+	}
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+	mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+	mockGuard := NewMockGuard(ctrl)
+	mockGuard.EXPECT().Lock("test-id-api").Return(true)
+	mockGuard.EXPECT().Unlock("test-id-api")
+
+	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+	mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+
+	mockReadiness := readiness.NewMockReadiness(ctrl)
+	mockBroadcaster := relay.NewMockBroadcaster(ctrl)
+	mockBroadcaster.EXPECT().Broadcast(gomock.Any(), gomock.Any()).AnyTimes()
+
+	var proc process.Process
+
+	mockRegistry := registry.NewMockRegistry(ctrl)
+	mockRegistry.EXPECT().Get("test-id-api").Return(registry.Lookup{Exists: false})
+	mockRegistry.EXPECT().Add("platform", bus.Service{ID: "test-id-api", Name: "api"}, gomock.Any()).Do(
+		func(_ string, _ bus.Service, p process.Process) {
+			proc = p
+		})
+	mockRegistry.EXPECT().Remove("test-id-api", gomock.Any()).Return(registry.RemoveResult{}).AnyTimes()
+
+	s := &service{
+		cfg:         cfg,
+		lifecycle:   mockLifecycle,
+		readiness:   mockReadiness,
+		registry:    mockRegistry,
+		guard:       mockGuard,
+		bus:         bus.NoOp(),
+		broadcaster: mockBroadcaster,
+		log:         mockLog,
+	}
+
+	s.Relaunch(context.Background(), bus.Service{ID: "test-id-api", Name: "api"})
+
+	require.NotNil(t, proc)
+	require.NoError(t, proc.Cmd().Process.Kill())
+	<-proc.Done()
+
+	assert.NoFileExists(t, filepath.Join(dir, "built"))
+	assert.NoFileExists(t, filepath.Join(dir, "hook.log"))
+}
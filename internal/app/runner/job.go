@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/process"
+	"fuku/internal/config"
+)
+
+// completeJob waits for a job to exit successfully, runs its after_ready hook and publishes its completion
+func (s *service) completeJob(ctx context.Context, event bus.ServiceEvent, cfg *config.Service, proc process.Process, startedAt time.Time) error {
+	if err := s.waitForJob(ctx, proc, cfg); err != nil {
+		return err
+	}
+
+	cmd := proc.Cmd()
+	if err := s.runHook(ctx, event, config.HookAfterReady, cmd.Dir, cmd.Env); err != nil {
+		return err
+	}
+
+	duration := time.Since(startedAt)
+
+	s.log.Info().Msgf("Job '%s' completed in %s", event.Service.Name, duration)
+	s.bus.Publish(bus.Message{
+		Type: bus.EventServiceCompleted,
+		Data: bus.ServiceCompleted{
+			ServiceEvent: event,
+			StartedAt:    startedAt,
+			Duration:     duration,
+		},
+		Critical: true,
+	})
+
+	return nil
+}
+
+// waitForJob waits for a job to exit with code 0 within its timeout, terminating it on timeout or cancellation
+func (s *service) waitForJob(ctx context.Context, proc process.Process, cfg *config.Service) error {
+	timeout := cfg.JobTimeout()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-proc.Done():
+		if err := proc.Err(); err != nil {
+			return fmt.Errorf("%w: %w", errors.ErrJobFailed, err)
+		}
+
+		if code := exitCode(proc); code != 0 {
+			return fmt.Errorf("%w with exit code %d", errors.ErrJobFailed, code)
+		}
+
+		return nil
+	case <-timer.C:
+		_ = s.lifecycle.Terminate(proc, s.cfg.StopPolicy(proc.Name()))
+
+		return fmt.Errorf("%w after %s", errors.ErrJobTimeout, timeout)
+	case <-ctx.Done():
+		_ = s.lifecycle.Terminate(proc, s.cfg.StopPolicy(proc.Name()))
+
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
+	"fuku/internal/app/lifecycle"
+	"fuku/internal/app/process"
+	"fuku/internal/app/registry"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func Test_Start_Job(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		timeout  time.Duration
+		expected bus.MessageType
+		error    error
+	}{
+		{name: "clean exit completes", command: "sleep 0.1", expected: bus.EventServiceCompleted},
+		{name: "non-zero exit fails", command: "exit 4", expected: bus.EventServiceFailed, error: errors.ErrJobFailed},
+		{name: "timeout kills the job", command: "sleep 10", timeout: 100 * time.Millisecond, expected: bus.EventServiceFailed, error: errors.ErrJobTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cfg := config.DefaultConfig()
+			cfg.Services["migrate"] = &config.Service{
+				Dir:     t.TempDir(),
+				Type:    config.ServiceTypeJob,
+				Command: tt.command,
+				Timeout: tt.timeout,
+			}
+			cfg.Retry.Attempts = 1
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Info().Return(nil).AnyTimes()
+			mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+			mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+			mockLifecycle.EXPECT().Terminate(gomock.Any(), gomock.Any()).DoAndReturn(func(proc process.Process, _ config.Stop) error {
+				return proc.Cmd().Process.Kill()
+			}).AnyTimes()
+
+			b := bus.NewBus(cfg, nil, nil)
+			defer b.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			msgs := b.Subscribe(ctx)
+
+			reg := registry.NewRegistry()
+			s := &service{
+				cfg:       cfg,
+				lifecycle: mockLifecycle,
+				registry:  reg,
+				bus:       b,
+				log:       mockLog,
+			}
+
+			err := s.Start(ctx, "foundation", bus.Service{ID: "test-id-migrate", Name: "migrate"})
+			if tt.error != nil {
+				require.ErrorIs(t, err, tt.error)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.False(t, reg.Get("test-id-migrate").Exists)
+
+			for msg := range msgs {
+				switch data := msg.Data.(type) {
+				case bus.ServiceCompleted:
+					assert.Equal(t, tt.expected, msg.Type)
+					assert.Equal(t, "foundation", data.Tier)
+					assert.Positive(t, data.Duration)
+				case bus.ServiceFailed:
+					assert.Equal(t, tt.expected, msg.Type)
+					assert.ErrorIs(t, data.Error, tt.error)
+				default:
+					continue
+				}
+
+				return
+			}
+
+			t.Fatal("expected a completion or failure event")
+		})
+	}
+}
+
+func Test_WaitForJob_Cancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["migrate"] = &config.Service{Type: config.ServiceTypeJob}
+
+	done := make(chan struct{})
+
+	mockProc := process.NewMockProcess(ctrl)
+	mockProc.EXPECT().Done().Return(done).AnyTimes()
+	mockProc.EXPECT().Name().Return("migrate").AnyTimes()
+
+	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+	mockLifecycle.EXPECT().Terminate(mockProc, gomock.Any()).Return(nil)
+
+	s := &service{cfg: cfg, lifecycle: mockLifecycle}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.waitForJob(ctx, mockProc, cfg.Services["migrate"])
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -8,6 +8,8 @@ import (
 	"fuku/internal/app/preflight"
 	"fuku/internal/app/readiness"
 	"fuku/internal/app/registry"
+	"fuku/internal/app/relay"
+	"fuku/internal/app/session"
 	"fuku/internal/app/worker"
 )
 
@@ -18,10 +20,23 @@ var Module = fx.Options(
 	preflight.Module,
 	readiness.Module,
 	registry.Module,
+	session.Module,
 	worker.Module,
 	fx.Provide(
 		NewGuard,
 		NewService,
 		NewRunner,
 	),
+	fx.Invoke(registerAttacher),
+	fx.Invoke(registerStore),
 )
+
+// registerAttacher lets relay clients attach to the services started by this runner
+func registerAttacher(server *relay.Server, service Service) {
+	server.SetAttacher(service)
+}
+
+// registerStore lets relay clients read the state of the services started by this runner
+func registerStore(server *relay.Server, store registry.Store) {
+	server.SetStore(store)
+}
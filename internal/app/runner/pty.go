@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"fuku/internal/app/errors"
+)
+
+const (
+	// ptyCols and ptyRows size the pseudo-terminal given to tty services
+	ptyCols = 120
+	ptyRows = 40
+	// ptyTerm is set as TERM for tty services when the environment does not already name a terminal
+	ptyTerm = "xterm-256color"
+	// ptyDrainDelay is how long output left in the terminal may still be read after the service exits
+	ptyDrainDelay = time.Second
+)
+
+// terminal is the pseudo-terminal a tty service runs under. Its master side carries the service's
+// merged stdout and stderr
+type terminal struct {
+	master *os.File
+	slave  *os.File
+}
+
+// openTerminal allocates a pseudo-terminal with a fixed window size
+func openTerminal() (*terminal, error) {
+	master, slave, err := openPTY()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := unix.IoctlSetWinsize(int(slave.Fd()), unix.TIOCSWINSZ, &unix.Winsize{Row: ptyRows, Col: ptyCols}); err != nil {
+		master.Close()
+		slave.Close()
+
+		return nil, err
+	}
+
+	return &terminal{master: master, slave: slave}, nil
+}
+
+// attach connects the command's standard streams to the terminal and makes it the command's
+// controlling terminal. A new session is also a new process group, so group signals keep working
+func (t *terminal) attach(cmd *exec.Cmd) {
+	cmd.Stdin = t.slave
+	cmd.Stdout = t.slave
+	cmd.Stderr = t.slave
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	cmd.SysProcAttr.Setpgid = false
+	cmd.SysProcAttr.Setsid = true
+	cmd.SysProcAttr.Setctty = true
+	cmd.SysProcAttr.Ctty = 0
+
+	hasTerm := slices.ContainsFunc(cmd.Env, func(kv string) bool {
+		return strings.HasPrefix(kv, "TERM=") && kv != "TERM=" && kv != "TERM=dumb"
+	})
+	if !hasTerm {
+		cmd.Env = append(cmd.Env, "TERM="+ptyTerm)
+	}
+}
+
+// started releases the parent's copy of the slave side once the command holds its own
+func (t *terminal) started() {
+	t.slave.Close()
+}
+
+// closeAfter closes the terminal once done is closed and the remaining output had time to drain
+func (t *terminal) closeAfter(done <-chan struct{}) {
+	<-done
+	time.Sleep(ptyDrainDelay)
+	t.Close()
+}
+
+// Read reads the service output, reporting the end of output once every slave side is closed
+func (t *terminal) Read(p []byte) (int, error) {
+	n, err := t.master.Read(p)
+	if err != nil && (errors.Is(err, syscall.EIO) || errors.Is(err, os.ErrClosed)) {
+		return n, io.EOF
+	}
+
+	return n, err
+}
+
+// Write sends input to the service as if typed on its terminal
+func (t *terminal) Write(p []byte) (int, error) {
+	return t.master.Write(p)
+}
+
+// Close closes both sides of the terminal
+func (t *terminal) Close() error {
+	t.slave.Close()
+
+	return t.master.Close()
+}
@@ -0,0 +1,56 @@
+package runner
+
+import (
+	"bytes"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// ptyNameSize is the buffer size the kernel expects for TIOCPTYGNAME
+const ptyNameSize = 128
+
+// openPTY opens a new pseudo-terminal pair. The master is non-blocking so closing it interrupts pending reads
+func openPTY() (*os.File, *os.File, error) {
+	fd, err := unix.Open("/dev/ptmx", unix.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	master := os.NewFile(uintptr(fd), "/dev/ptmx")
+
+	if err := unix.IoctlSetInt(fd, unix.TIOCPTYGRANT, 0); err != nil {
+		master.Close()
+
+		return nil, nil, err
+	}
+
+	if err := unix.IoctlSetInt(fd, unix.TIOCPTYUNLK, 0); err != nil {
+		master.Close()
+
+		return nil, nil, err
+	}
+
+	name := make([]byte, ptyNameSize)
+
+	//nolint:gosec // TIOCPTYGNAME writes the slave path into the buffer, which outlives the call
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), uintptr(unix.TIOCPTYGNAME), uintptr(unsafe.Pointer(&name[0]))); errno != 0 {
+		master.Close()
+
+		return nil, nil, errno
+	}
+
+	if i := bytes.IndexByte(name, 0); i >= 0 {
+		name = name[:i]
+	}
+
+	slave, err := os.OpenFile(string(name), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+
+		return nil, nil, err
+	}
+
+	return master, slave, nil
+}
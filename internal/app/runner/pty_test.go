@@ -0,0 +1,106 @@
+package runner
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/lifecycle"
+	"fuku/internal/app/relay"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func Test_DoStart_TTY(t *testing.T) {
+	tests := []struct {
+		name     string
+		tty      bool
+		expected []string
+	}{
+		{name: "runs under a pseudo-terminal", tty: true, expected: []string{"\x1b[32mtty xterm-256color\x1b[0m", "100%"}},
+		{name: "runs with pipes by default", tty: false, expected: []string{"pipe", "100%"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			t.Setenv("TERM", "dumb")
+
+			cfg := config.DefaultConfig()
+			cfg.Services["api"] = &config.Service{
+				Dir:     t.TempDir(),
+				Command: `if test -t 1; then printf '\033[32mtty %s\033[0m\n' "$TERM"; else echo pipe; fi; printf '10%%\r50%%\r100%%\n'`,
+				TTY:     tt.tty,
+			}
+
+			mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+			mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+			mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(cmd *exec.Cmd, _ string, _ *config.Limits) {
+				cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+			})
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Info().Return(nil).AnyTimes()
+			mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			var (
+				mu     sync.Mutex
+				output []string
+			)
+
+			mockBroadcaster := relay.NewMockBroadcaster(ctrl)
+			mockBroadcaster.EXPECT().Broadcast("api", gomock.Any()).Do(func(_, line string) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				output = append(output, line)
+			}).AnyTimes()
+
+			s := &service{cfg: cfg, lifecycle: mockLifecycle, bus: bus.NoOp(), broadcaster: mockBroadcaster, log: mockLog}
+
+			proc, err := s.doStart(context.Background(), "platform", bus.Service{ID: "test-id-api", Name: "api"}, cfg.Services["api"], startAttempt{number: 1, max: 1})
+			require.NoError(t, err)
+
+			select {
+			case <-proc.Done():
+			case <-time.After(5 * time.Second):
+				t.Fatal("service did not exit")
+			}
+
+			assert.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+
+				return len(output) == len(tt.expected)
+			}, 5*time.Second, 10*time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func Test_Terminal_ReadAfterClose(t *testing.T) {
+	term, err := openTerminal()
+	require.NoError(t, err)
+
+	term.started()
+	require.NoError(t, term.Close())
+
+	_, err = term.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+}
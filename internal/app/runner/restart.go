@@ -0,0 +1,141 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"fuku/internal/config"
+)
+
+// restartDecision describes what happens after a service crashed
+type restartDecision struct {
+	restart   bool
+	crashLoop bool
+	restarts  int
+	delay     time.Duration
+}
+
+// restartHistory tracks automatic restarts per service to detect crash loops
+type restartHistory struct {
+	mu     sync.Mutex
+	recent map[string][]time.Time
+	total  map[string]int
+}
+
+// decide applies the restart policy to a crash, recording the restart when one is due.
+// Restarts older than the policy window are forgotten; once max_restarts restarts happened
+// inside the window the service is in a crash loop and its recent history is cleared
+func (h *restartHistory) decide(id string, policy config.Restart, exitCode int, now time.Time) restartDecision {
+	if !policy.Restarts(exitCode) {
+		return restartDecision{}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.recent == nil {
+		h.recent = make(map[string][]time.Time)
+		h.total = make(map[string]int)
+	}
+
+	var recent []time.Time
+
+	for _, at := range h.recent[id] {
+		if now.Sub(at) < policy.Window {
+			recent = append(recent, at)
+		}
+	}
+
+	if len(recent) >= policy.MaxRestarts {
+		delete(h.recent, id)
+
+		return restartDecision{crashLoop: true, restarts: h.total[id]}
+	}
+
+	h.recent[id] = append(recent, now)
+	h.total[id]++
+
+	return restartDecision{
+		restart:  true,
+		restarts: h.total[id],
+		delay:    policy.Delay(len(h.recent[id])),
+	}
+}
+
+// pendingRestarts tracks automatic restarts waiting out their backoff so user commands can call them off
+type pendingRestarts struct {
+	mu      sync.Mutex
+	next    uint64
+	pending map[string]pendingRestart
+}
+
+// pendingRestart is a scheduled restart and the function that cancels its wait
+type pendingRestart struct {
+	token  uint64
+	cancel context.CancelFunc
+}
+
+// add registers a restart of id, replacing an earlier one, and returns the context that ends when
+// the restart is called off together with the token that claims it
+func (p *pendingRestarts) add(ctx context.Context, id string) (context.Context, uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pending == nil {
+		p.pending = make(map[string]pendingRestart)
+	}
+
+	if previous, ok := p.pending[id]; ok {
+		previous.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p.next++
+	p.pending[id] = pendingRestart{token: p.next, cancel: cancel}
+
+	return ctx, p.next
+}
+
+// take claims the restart of id and reports whether it is still due
+func (p *pendingRestarts) take(id string, token uint64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	restart, ok := p.pending[id]
+	if !ok || restart.token != token {
+		return false
+	}
+
+	restart.cancel()
+	delete(p.pending, id)
+
+	return true
+}
+
+// cancel calls off the pending restart of id and reports whether there was one
+func (p *pendingRestarts) cancel(id string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	restart, ok := p.pending[id]
+	if !ok {
+		return false
+	}
+
+	restart.cancel()
+	delete(p.pending, id)
+
+	return true
+}
+
+// cancelAll calls off every pending restart
+func (p *pendingRestarts) cancelAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, restart := range p.pending {
+		restart.cancel()
+		delete(p.pending, id)
+	}
+}
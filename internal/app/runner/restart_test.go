@@ -0,0 +1,45 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fuku/internal/config"
+)
+
+func Test_RestartHistory_Decide(t *testing.T) {
+	policy := config.Restart{
+		Policy:      config.RestartOnFailure,
+		MaxRestarts: 2,
+		Window:      time.Minute,
+		Backoff:     time.Second,
+		MaxBackoff:  10 * time.Second,
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		id       string
+		exitCode int
+		at       time.Time
+		expected restartDecision
+	}{
+		{name: "clean exit is not restarted", id: "api", exitCode: 0, at: now, expected: restartDecision{}},
+		{name: "first crash restarts", id: "api", exitCode: 1, at: now, expected: restartDecision{restart: true, restarts: 1, delay: time.Second}},
+		{name: "second crash backs off", id: "api", exitCode: 1, at: now.Add(time.Second), expected: restartDecision{restart: true, restarts: 2, delay: 2 * time.Second}},
+		{name: "third crash in window is a crash loop", id: "api", exitCode: 1, at: now.Add(2 * time.Second), expected: restartDecision{crashLoop: true, restarts: 2}},
+		{name: "history is cleared after a crash loop", id: "api", exitCode: 1, at: now.Add(3 * time.Second), expected: restartDecision{restart: true, restarts: 3, delay: time.Second}},
+		{name: "services are tracked separately", id: "web", exitCode: 1, at: now, expected: restartDecision{restart: true, restarts: 1, delay: time.Second}},
+		{name: "crashes outside the window are forgotten", id: "api", exitCode: 1, at: now.Add(2 * time.Minute), expected: restartDecision{restart: true, restarts: 4, delay: time.Second}},
+	}
+
+	var history restartHistory
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, history.decide(tt.id, policy, tt.exitCode, tt.at))
+		})
+	}
+}
@@ -19,15 +19,20 @@ import (
 	"fuku/internal/app/errors"
 	"fuku/internal/app/preflight"
 	"fuku/internal/app/registry"
+	"fuku/internal/app/session"
 	"fuku/internal/app/worker"
 	"fuku/internal/config"
 	"fuku/internal/config/logger"
 )
 
+// Confirm is shown the processes a stop found and reports whether to kill them, or an error when nobody can decide
+type Confirm func(targets []preflight.Result) (bool, error)
+
 // Runner defines the interface for service orchestration
 type Runner interface {
 	Run(ctx context.Context, profile string) error
-	Stop(ctx context.Context, profile string) error
+	Stop(ctx context.Context, profile string, confirm Confirm) error
+	Exec(ctx context.Context, name string, args []string) (int, error)
 }
 
 // RunnerParams contains dependencies for creating a Runner
@@ -53,6 +58,7 @@ type runner struct {
 	service   Service
 	worker    worker.Pool
 	bus       bus.Bus
+	restarts  pendingRestarts
 	log       logger.Logger
 }
 
@@ -74,6 +80,10 @@ func NewRunner(p RunnerParams) Runner {
 func (r *runner) Run(ctx context.Context, profile string) error {
 	startupStart := time.Now()
 
+	for _, warning := range r.cfg.Warnings() {
+		r.log.Warn().Msg(warning)
+	}
+
 	r.bus.Publish(bus.Message{
 		Type:     bus.EventPhaseChanged,
 		Data:     bus.PhaseChanged{Phase: bus.PhaseStartup},
@@ -103,6 +113,10 @@ func (r *runner) Run(ctx context.Context, profile string) error {
 
 	services := r.collectServiceNames(tierData)
 
+	for _, finding := range r.cfg.LintServices(services) {
+		r.log.Warn().Msg(finding)
+	}
+
 	if len(services) == 0 {
 		r.log.Warn().Msgf("No services found for profile '%s'. Nothing to run.", profile)
 		r.bus.Publish(bus.Message{
@@ -176,17 +190,22 @@ func (r *runner) Run(ctx context.Context, profile string) error {
 
 // runStartupPhase handles the service startup phase
 func (r *runner) runStartupPhase(ctx context.Context, cancel context.CancelFunc, tiers []bus.Tier, sigChan chan os.Signal, msgChan <-chan bus.Message) error {
-	startupDone := make(chan struct{}, 1)
+	startupDone := make(chan error, 1)
 
 	go func() {
-		r.startAllTiers(ctx, tiers)
-
-		startupDone <- struct{}{}
+		startupDone <- r.startAllTiers(ctx, tiers)
 	}()
 
 	for {
 		select {
-		case <-startupDone:
+		case err := <-startupDone:
+			if err != nil {
+				r.log.Error().Err(err).Msg("Startup aborted, shutting down services...")
+				r.shutdown()
+
+				return err
+			}
+
 			r.log.Info().Msg("Startup phase complete, waiting for signals...")
 
 			return nil
@@ -222,6 +241,7 @@ func (r *runner) runStartupPhase(ctx context.Context, cancel context.CancelFunc,
 
 			if msg.Type == bus.CommandStopAll {
 				r.log.Info().Msg("Received StopAll command during startup, shutting down services...")
+				r.restarts.cancelAll()
 				cancel()
 				<-startupDone
 				r.shutdown()
@@ -229,6 +249,12 @@ func (r *runner) runStartupPhase(ctx context.Context, cancel context.CancelFunc,
 				return fmt.Errorf("%w: StopAll command", errors.ErrStartupInterrupted)
 			}
 
+			if msg.Type == bus.EventServiceBackoff {
+				r.scheduleRestart(ctx, msg)
+
+				continue
+			}
+
 			r.log.Debug().Msgf("Handling message during startup: %v", msg.Type)
 			r.handleCommand(ctx, msg)
 		}
@@ -286,16 +312,51 @@ func (r *runner) handleMessage(ctx context.Context, msg bus.Message) bool {
 			}(data.Service, data.ChangedFiles)
 		}
 
+		return false
+	case bus.EventServiceBackoff:
+		r.scheduleRestart(ctx, msg)
+
 		return false
 	default:
 		return r.handleCommand(ctx, msg)
 	}
 }
 
+// scheduleRestart starts a crashed service again once its restart backoff has elapsed,
+// unless a stop, start or restart of the service calls it off in the meantime
+func (r *runner) scheduleRestart(ctx context.Context, msg bus.Message) {
+	data, ok := msg.Data.(bus.ServiceBackoff)
+	if !ok {
+		return
+	}
+
+	waitCtx, token := r.restarts.add(ctx, data.Service.ID)
+
+	go func() {
+		select {
+		case <-time.After(data.Delay):
+		case <-waitCtx.Done():
+			return
+		}
+
+		if err := r.worker.Acquire(waitCtx); err != nil {
+			return
+		}
+		defer r.worker.Release()
+
+		if !r.restarts.take(data.Service.ID, token) {
+			return
+		}
+
+		r.service.Relaunch(ctx, data.Service)
+	}()
+}
+
 // handleCommand processes a command and returns true if shutdown requested
 func (r *runner) handleCommand(ctx context.Context, msg bus.Message) bool {
 	if msg.Type == bus.CommandStopAll {
 		r.log.Info().Msg("Received StopAll command, shutting down all services...")
+		r.restarts.cancelAll()
 
 		return true
 	}
@@ -308,16 +369,26 @@ func (r *runner) handleCommand(ctx context.Context, msg bus.Message) bool {
 	//nolint:exhaustive // only handling command types
 	switch msg.Type {
 	case bus.CommandStopService:
+		r.cancelRestart(data)
 		r.service.Stop(data.ID)
 	case bus.CommandStartService:
+		r.cancelRestart(data)
 		go r.runWithWorker(ctx, data, r.service.Resume)
 	case bus.CommandRestartService:
+		r.cancelRestart(data)
 		go r.runWithWorker(ctx, data, r.service.Restart)
 	}
 
 	return false
 }
 
+// cancelRestart calls off a pending automatic restart, as a user command takes precedence over it
+func (r *runner) cancelRestart(svc bus.Service) {
+	if r.restarts.cancel(svc.ID) {
+		r.log.Info().Msgf("Cancelled pending automatic restart of service '%s'", svc.Name)
+	}
+}
+
 // runWithWorker acquires a worker slot before running a service action
 func (r *runner) runWithWorker(ctx context.Context, svc bus.Service, action func(context.Context, bus.Service)) {
 	if err := r.worker.Acquire(ctx); err != nil {
@@ -330,82 +401,144 @@ func (r *runner) runWithWorker(ctx context.Context, svc bus.Service, action func
 	action(ctx, svc)
 }
 
-// startAllTiers starts services tier by tier
-func (r *runner) startAllTiers(ctx context.Context, tiers []bus.Tier) {
-	for tierIdx, tier := range tiers {
-		if len(tier.Services) == 0 {
-			continue
-		}
+// startAllTiers starts every service as soon as its dependencies are ready and
+// returns an error when a tier with on_failure: abort failed
+func (r *runner) startAllTiers(ctx context.Context, tiers []bus.Tier) error {
+	graph := r.buildStartGraph(tiers)
+
+	var wg sync.WaitGroup
+
+	for _, node := range graph.nodes {
+		wg.Add(1)
+
+		go func(node *startNode) {
+			defer wg.Done()
+			defer close(node.done)
+
+			ok := r.startNode(ctx, graph, node)
+
+			node.failed = !ok
+
+			if graph.finishService(node.tier, node.ref.Name, ok) {
+				r.completeTier(node.tier)
+			}
+		}(node)
+	}
+
+	wg.Wait()
+
+	if tier := graph.abortedTier(); tier != "" {
+		return fmt.Errorf("%w: '%s'", errors.ErrTierFailed, tier)
+	}
 
-		names := serviceNames(tier.Services)
-		r.log.Info().Msgf("Starting tier '%s' (%d/%d) with services: %v", tier.Name, tierIdx+1, len(tiers), names)
+	return nil
+}
+
+// startNode waits for a service's dependencies and starts it under its tier policy, returning true on success
+func (r *runner) startNode(ctx context.Context, graph *startGraph, node *startNode) bool {
+	if err := graph.awaitDependencies(ctx, node); err != nil {
+		r.log.Error().Err(err).Msgf("Skipping service '%s'", node.ref.Name)
+		r.publishServiceFailed(node, err)
+
+		return false
+	}
+
+	if graph.beginTier(ctx, node.tier) {
+		r.log.Info().Msgf("Starting tier '%s' (%d/%d) with services: %v", node.tier.name, node.tier.index+1, len(graph.tiers), node.tier.services)
 		r.bus.Publish(bus.Message{
 			Type:     bus.EventTierStarting,
-			Data:     bus.TierStarting{Name: tier.Name},
+			Data:     bus.TierStarting{Name: node.tier.name},
 			Critical: true,
 		})
+	}
 
-		tierStart := time.Now()
-		failed := r.startTier(ctx, tier.Name, tier.Services)
+	tierCtx := node.tier.ctx
 
-		if len(failed) > 0 {
-			r.log.Warn().Msgf("Tier '%s' partially failed: %d/%d services failed: %v", tier.Name, len(failed), len(tier.Services), failed)
-		} else {
-			r.log.Info().Msgf("Tier '%s' started successfully, all services ready", tier.Name)
-			r.bus.Publish(bus.Message{
-				Type: bus.EventTierReady,
-				Data: bus.TierReady{
-					Name:         tier.Name,
-					Duration:     time.Since(tierStart),
-					ServiceCount: len(tier.Services),
-				},
-				Critical: true,
-			})
+	if node.tier.slots != nil {
+		select {
+		case node.tier.slots <- struct{}{}:
+			defer func() { <-node.tier.slots }()
+		case <-tierCtx.Done():
+			r.handleTierTimeout(ctx, node)
+
+			return false
 		}
 	}
-}
 
-// startTier starts all services in a tier concurrently
-func (r *runner) startTier(ctx context.Context, tier string, services []bus.Service) []string {
-	failedChan := make(chan string, len(services))
+	if err := r.startService(tierCtx, node.tier.name, node.ref); err != nil {
+		if errors.Is(tierCtx.Err(), context.DeadlineExceeded) {
+			r.handleTierTimeout(ctx, node)
+		}
 
-	var wg sync.WaitGroup
+		return false
+	}
 
-	for _, ref := range services {
-		wg.Add(1)
+	return true
+}
 
-		go func(ref bus.Service) {
-			defer wg.Done()
+// handleTierTimeout reports a service that did not become ready before its tier timeout
+func (r *runner) handleTierTimeout(ctx context.Context, node *startNode) {
+	if ctx.Err() != nil {
+		return
+	}
 
-			if err := r.worker.Acquire(ctx); err != nil {
-				r.log.Error().Err(err).Msgf("Failed to acquire worker for service '%s'", ref.Name)
-				r.bus.Publish(bus.Message{
-					Type:     bus.EventServiceFailed,
-					Data:     bus.ServiceFailed{ServiceEvent: bus.ServiceEvent{Service: ref, Tier: tier}, Error: fmt.Errorf("%w: %w", errors.ErrFailedToAcquireWorker, err)},
-					Critical: true,
-				})
+	err := fmt.Errorf("%w: tier '%s' exceeded %s", errors.ErrTierTimeout, node.tier.name, node.tier.policy.Timeout)
 
-				failedChan <- ref.Name
+	r.log.Error().Err(err).Msgf("Service '%s' did not start", node.ref.Name)
+	r.publishServiceFailed(node, err)
+}
 
-				return
-			}
-			defer r.worker.Release()
+// publishServiceFailed publishes a failure event for a service that the runner did not start
+func (r *runner) publishServiceFailed(node *startNode, err error) {
+	r.bus.Publish(bus.Message{
+		Type:     bus.EventServiceFailed,
+		Data:     bus.ServiceFailed{ServiceEvent: bus.ServiceEvent{Service: node.ref, Tier: node.tier.name}, Error: err},
+		Critical: true,
+	})
+}
 
-			if err := r.service.Start(ctx, tier, ref); err != nil {
-				failedChan <- ref.Name
-			}
-		}(ref)
+// completeTier reports the outcome of a tier once all of its services have finished starting
+func (r *runner) completeTier(progress *tierProgress) {
+	if len(progress.failed) > 0 {
+		r.log.Warn().Msgf("Tier '%s' partially failed: %d/%d services failed: %v", progress.name, len(progress.failed), len(progress.services), progress.failed)
+
+		return
 	}
 
-	wg.Wait()
-	close(failedChan)
+	r.log.Info().Msgf("Tier '%s' started successfully, all services ready", progress.name)
+	r.bus.Publish(bus.Message{
+		Type: bus.EventTierReady,
+		Data: bus.TierReady{
+			Name:         progress.name,
+			Duration:     time.Since(progress.startedAt),
+			ServiceCount: len(progress.services),
+		},
+		Critical: true,
+	})
+}
 
-	failed := make([]string, 0, len(services))
-	for name := range failedChan {
-		failed = append(failed, name)
+// startService acquires a worker and starts a single service, returning the start error
+func (r *runner) startService(ctx context.Context, tier string, ref bus.Service) error {
+	if err := r.worker.Acquire(ctx); err != nil {
+		err = fmt.Errorf("%w: %w", errors.ErrFailedToAcquireWorker, err)
+
+		// a done context is a tier timeout or shutdown, which the caller reports
+		if ctx.Err() != nil {
+			return err
+		}
+
+		r.log.Error().Err(err).Msgf("Failed to acquire worker for service '%s'", ref.Name)
+		r.bus.Publish(bus.Message{
+			Type:     bus.EventServiceFailed,
+			Data:     bus.ServiceFailed{ServiceEvent: bus.ServiceEvent{Service: ref, Tier: tier}, Error: err},
+			Critical: true,
+		})
+
+		return err
 	}
+	defer r.worker.Release()
 
-	return failed
+	return r.service.Start(ctx, tier, ref)
 }
 
 // shutdown stops all services in reverse order and returns the count of stopped services
@@ -425,8 +558,8 @@ func (r *runner) shutdown() int {
 	return len(entries)
 }
 
-// Stop resolves a profile and kills any processes running in service directories
-func (r *runner) Stop(ctx context.Context, profile string) error {
+// Stop resolves a profile and kills the processes started for its services once confirm agrees
+func (r *runner) Stop(ctx context.Context, profile string, confirm Confirm) error {
 	tiers, err := r.discovery.Resolve(profile)
 	if err != nil {
 		return fmt.Errorf("failed to resolve profile: %w", err)
@@ -439,14 +572,74 @@ func (r *runner) Stop(ctx context.Context, profile string) error {
 		return nil
 	}
 
-	dirs := r.resolveServiceDirs(services)
-	if _, err := r.preflight.Cleanup(ctx, dirs); err != nil {
-		r.log.Warn().Err(err).Msg("Preflight cleanup failed during stop")
+	targets, manifest, err := r.stopTargets(profile, r.resolveServiceDirs(services))
+	if err != nil {
+		return err
+	}
+
+	ok, err := confirm(targets)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	killed := r.preflight.Kill(ctx, targets)
+	r.log.Info().Msgf("Stopped %d process(es) for profile '%s'", len(killed), profile)
+
+	if manifest != nil && manifest.Stale() {
+		if err := session.Discard(profile); err != nil {
+			r.log.Warn().Err(err).Msg("Failed to remove stale session file")
+		}
 	}
 
 	return nil
 }
 
+// stopTargets returns the process groups recorded in the profile's session file,
+// or without one, the processes found by scanning for the services' tags
+func (r *runner) stopTargets(profile string, dirs map[string]string) ([]preflight.Result, *session.Manifest, error) {
+	manifest, err := session.Load(profile)
+	if err == nil {
+		return sessionTargets(manifest), manifest, nil
+	}
+
+	if !errors.Is(err, errors.ErrSessionNotFound) {
+		r.log.Warn().Err(err).Msg("Falling back to a process scan")
+	}
+
+	targets, err := r.preflight.Find(dirs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to scan processes: %w", err)
+	}
+
+	return targets, nil, nil
+}
+
+// sessionTargets converts the still running services of a manifest into process groups to kill
+func sessionTargets(manifest *session.Manifest) []preflight.Result {
+	running := manifest.Running()
+	targets := make([]preflight.Result, 0, len(running))
+
+	for _, svc := range running {
+		name := ""
+		if len(svc.Command) > 0 {
+			name = filepath.Base(svc.Command[0])
+		}
+
+		targets = append(targets, preflight.Result{
+			Service: svc.Name,
+			Name:    name,
+			PID:     int32(svc.PGID), // #nosec G115 -- PGID was a valid PID when recorded
+			Match:   preflight.MatchSession,
+		})
+	}
+
+	return targets
+}
+
 // buildTiers converts discovery tiers to bus tiers with assigned UUIDs
 func (r *runner) buildTiers(tiers []discovery.Tier) []bus.Tier {
 	result := make([]bus.Tier, len(tiers))
@@ -40,6 +40,21 @@ func (m *MockRunner) EXPECT() *MockRunnerMockRecorder {
 	return m.recorder
 }
 
+// Exec mocks base method.
+func (m *MockRunner) Exec(ctx context.Context, name string, args []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", ctx, name, args)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockRunnerMockRecorder) Exec(ctx, name, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockRunner)(nil).Exec), ctx, name, args)
+}
+
 // Run mocks base method.
 func (m *MockRunner) Run(ctx context.Context, profile string) error {
 	m.ctrl.T.Helper()
@@ -55,15 +70,15 @@ func (mr *MockRunnerMockRecorder) Run(ctx, profile any) *gomock.Call {
 }
 
 // Stop mocks base method.
-func (m *MockRunner) Stop(ctx context.Context, profile string) error {
+func (m *MockRunner) Stop(ctx context.Context, profile string, confirm Confirm) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Stop", ctx, profile)
+	ret := m.ctrl.Call(m, "Stop", ctx, profile, confirm)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Stop indicates an expected call of Stop.
-func (mr *MockRunnerMockRecorder) Stop(ctx, profile any) *gomock.Call {
+func (mr *MockRunnerMockRecorder) Stop(ctx, profile, confirm any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockRunner)(nil).Stop), ctx, profile)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockRunner)(nil).Stop), ctx, profile, confirm)
 }
@@ -1,12 +1,17 @@
 package runner
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -17,6 +22,7 @@ import (
 	"fuku/internal/app/preflight"
 	"fuku/internal/app/process"
 	"fuku/internal/app/registry"
+	"fuku/internal/app/session"
 	"fuku/internal/app/worker"
 	"fuku/internal/config"
 	"fuku/internal/config/logger"
@@ -197,6 +203,65 @@ func Test_Run_SuccessfulStart(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_Run_LintsOnlyProfileServices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{Dir: "/nonexistent/api"}
+	cfg.Services["web"] = &config.Service{Dir: "/nonexistent/web"}
+
+	var buf bytes.Buffer
+
+	warnings := zerolog.New(&buf)
+
+	mockLog := logger.NewMockLogger(ctrl)
+	componentLog := logger.NewMockLogger(ctrl)
+
+	mockLog.EXPECT().WithComponent("RUNNER").Return(componentLog)
+	componentLog.EXPECT().Info().Return(nil).AnyTimes()
+	componentLog.EXPECT().Warn().DoAndReturn(warnings.Warn).AnyTimes()
+	componentLog.EXPECT().Error().Return(nil).AnyTimes()
+	componentLog.EXPECT().Debug().Return(nil).AnyTimes()
+
+	mockDiscovery := discovery.NewMockDiscovery(ctrl)
+	mockDiscovery.EXPECT().Resolve("api").Return([]discovery.Tier{{Name: "platform", Services: []string{"api"}}}, nil)
+
+	mockPreflight := preflight.NewMockPreflight(ctrl)
+	mockPreflight.EXPECT().Cleanup(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Start(gomock.Any(), "platform", gomock.Any()).Return(nil)
+	mockService.EXPECT().Stop(gomock.Any()).AnyTimes()
+
+	mockWorkerPool := worker.NewMockPool(ctrl)
+	mockWorkerPool.EXPECT().Acquire(gomock.Any()).AnyTimes()
+	mockWorkerPool.EXPECT().Release().AnyTimes()
+
+	mockRegistry := registry.NewMockRegistry(ctrl)
+	mockRegistry.EXPECT().SnapshotReverse().Return([]registry.ProcessEntry{}).AnyTimes()
+	mockRegistry.EXPECT().Detach(gomock.Any()).AnyTimes()
+	mockRegistry.EXPECT().Wait().AnyTimes()
+
+	r := NewRunner(RunnerParams{
+		Config:    cfg,
+		Discovery: mockDiscovery,
+		Registry:  mockRegistry,
+		Preflight: mockPreflight,
+		Service:   mockService,
+		Worker:    mockWorkerPool,
+		Bus:       bus.NoOp(),
+		Logger:    mockLog,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, r.Run(ctx, "api"))
+	assert.Contains(t, buf.String(), "service api: dir '/nonexistent/api' does not exist")
+	assert.NotContains(t, buf.String(), "service web")
+}
+
 func Test_Run_NoServices_ExitsGracefully(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -575,7 +640,7 @@ func Test_RunServicePhase_CommandChannelClosed(t *testing.T) {
 	r.runServicePhase(ctx, cancel, sigChan, commandChan)
 }
 
-func Test_StartTier_Success(t *testing.T) {
+func Test_StartService_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -603,12 +668,12 @@ func Test_StartTier_Success(t *testing.T) {
 
 	ctx := context.Background()
 
-	failedServices := r.startTier(ctx, "platform", []bus.Service{{ID: "test-id-api", Name: "api"}})
+	err := r.startService(ctx, "platform", bus.Service{ID: "test-id-api", Name: "api"})
 
-	assert.Empty(t, failedServices)
+	require.NoError(t, err)
 }
 
-func Test_StartTier_AcquireError(t *testing.T) {
+func Test_StartService_AcquireError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -634,13 +699,12 @@ func Test_StartTier_AcquireError(t *testing.T) {
 
 	ctx := context.Background()
 
-	failedServices := r.startTier(ctx, "platform", []bus.Service{{ID: "test-id-api", Name: "api"}})
+	err := r.startService(ctx, "platform", bus.Service{ID: "test-id-api", Name: "api"})
 
-	assert.Len(t, failedServices, 1)
-	assert.Contains(t, failedServices, "api")
+	require.ErrorIs(t, err, errors.ErrFailedToAcquireWorker)
 }
 
-func Test_StartTier_ServiceStartupError(t *testing.T) {
+func Test_StartService_ServiceStartupError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -668,10 +732,9 @@ func Test_StartTier_ServiceStartupError(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	failedServices := r.startTier(ctx, "platform", []bus.Service{{ID: "test-id-api", Name: "api"}})
+	err := r.startService(ctx, "platform", bus.Service{ID: "test-id-api", Name: "api"})
 
-	assert.Len(t, failedServices, 1)
-	assert.Contains(t, failedServices, "api")
+	require.ErrorIs(t, err, errors.ErrServiceNotFound)
 }
 
 func Test_RunStartupPhase_SignalDuringStartup(t *testing.T) {
@@ -1022,47 +1085,279 @@ func Test_HandleMessage_WatchTriggered(t *testing.T) {
 	}
 }
 
+func Test_HandleMessage_ServiceBackoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		cancel     bool
+		command    bus.MessageType
+		relaunched bool
+	}{
+		{name: "relaunches service after delay", relaunched: true},
+		{name: "cancelled context skips restart", cancel: true},
+		{name: "stop during backoff cancels restart", command: bus.CommandStopService},
+		{name: "stop all during backoff cancels restart", command: bus.CommandStopAll},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := bus.Service{ID: "test-id-api", Name: "api"}
+			releaseCalled := make(chan struct{})
+
+			mockService := NewMockService(ctrl)
+			mockWorkerPool := worker.NewMockPool(ctrl)
+			mockLog := logger.NewMockLogger(ctrl)
+
+			if tt.relaunched {
+				mockService.EXPECT().Relaunch(gomock.Any(), svc)
+				mockWorkerPool.EXPECT().Acquire(gomock.Any()).Return(nil)
+				mockWorkerPool.EXPECT().Release().Do(func() {
+					close(releaseCalled)
+				})
+			}
+
+			if tt.command != "" {
+				mockLog.EXPECT().Info().Return(nil)
+			}
+
+			if tt.command == bus.CommandStopService {
+				mockService.EXPECT().Stop(svc.ID)
+			}
+
+			r := &runner{
+				cfg:      config.DefaultConfig(),
+				service:  mockService,
+				worker:   mockWorkerPool,
+				registry: registry.NewMockRegistry(ctrl),
+				bus:      bus.NoOp(),
+				log:      mockLog,
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tt.cancel {
+				cancel()
+			}
+
+			msg := bus.Message{
+				Type: bus.EventServiceBackoff,
+				Data: bus.ServiceBackoff{ServiceEvent: bus.ServiceEvent{Service: svc, Tier: "platform"}, ExitCode: 1, Restarts: 1, Delay: 10 * time.Millisecond},
+			}
+
+			assert.False(t, r.handleMessage(ctx, msg))
+
+			if tt.command != "" {
+				assert.Equal(t, tt.command == bus.CommandStopAll, r.handleMessage(ctx, bus.Message{Type: tt.command, Data: svc}))
+			}
+
+			select {
+			case <-releaseCalled:
+				assert.True(t, tt.relaunched)
+			case <-time.After(200 * time.Millisecond):
+				assert.False(t, tt.relaunched, "Relaunch was not called")
+			}
+		})
+	}
+}
+
+func Test_PendingRestarts(t *testing.T) {
+	var restarts pendingRestarts
+
+	firstCtx, first := restarts.add(context.Background(), "api")
+	_, second := restarts.add(context.Background(), "api")
+
+	assert.Error(t, firstCtx.Err(), "a newer restart replaces the pending one")
+	assert.False(t, restarts.take("api", first))
+	assert.True(t, restarts.take("api", second))
+	assert.False(t, restarts.take("api", second), "a restart is claimed once")
+
+	ctx, token := restarts.add(context.Background(), "web")
+	assert.True(t, restarts.cancel("web"))
+	assert.Error(t, ctx.Err())
+	assert.False(t, restarts.take("web", token))
+	assert.False(t, restarts.cancel("web"))
+}
+
 func Test_Stop(t *testing.T) {
+	targets := []preflight.Result{{Service: "api", Name: "node", PID: 100, Match: preflight.MatchTag}}
+
+	tests := []struct {
+		name    string
+		confirm bool
+		err     error
+		kills   int
+	}{
+		{name: "confirmed kills the targets", confirm: true, kills: 1},
+		{name: "declined kills nothing", confirm: false},
+		{name: "unconfirmable fails the stop", err: errors.ErrStopNotConfirmed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+			cfg := config.DefaultConfig()
+			cfg.Services["api"] = &config.Service{Dir: "api"}
+			cfg.Services["web"] = &config.Service{Dir: "web"}
+
+			mockLog := logger.NewMockLogger(ctrl)
+			componentLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().WithComponent("RUNNER").Return(componentLog)
+			componentLog.EXPECT().Info().Return(nil).AnyTimes()
+			componentLog.EXPECT().Warn().Return(nil).AnyTimes()
+
+			mockDiscovery := discovery.NewMockDiscovery(ctrl)
+			mockDiscovery.EXPECT().Resolve("test").Return([]discovery.Tier{
+				{Name: "platform", Services: []string{"api", "web"}},
+			}, nil)
+
+			mockRegistry := registry.NewMockRegistry(ctrl)
+			mockPreflight := preflight.NewMockPreflight(ctrl)
+			mockPreflight.EXPECT().Find(gomock.Len(2)).Return(targets, nil)
+			mockPreflight.EXPECT().Kill(gomock.Any(), targets).Return(targets).Times(tt.kills)
+
+			mockService := NewMockService(ctrl)
+			mockWorkerPool := worker.NewMockPool(ctrl)
+			mockBus := bus.NoOp()
+
+			r := NewRunner(RunnerParams{
+				Config:    cfg,
+				Discovery: mockDiscovery,
+				Registry:  mockRegistry,
+				Preflight: mockPreflight,
+				Service:   mockService,
+				Worker:    mockWorkerPool,
+				Bus:       mockBus,
+				Logger:    mockLog,
+			})
+
+			var confirmed []preflight.Result
+
+			err := r.Stop(context.Background(), "test", func(found []preflight.Result) (bool, error) {
+				confirmed = found
+				return tt.confirm, tt.err
+			})
+
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, targets, confirmed)
+		})
+	}
+}
+
+func Test_Stop_ScanFails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
 	cfg := config.DefaultConfig()
 	cfg.Services["api"] = &config.Service{Dir: "api"}
-	cfg.Services["web"] = &config.Service{Dir: "web"}
 
 	mockLog := logger.NewMockLogger(ctrl)
 	componentLog := logger.NewMockLogger(ctrl)
 	mockLog.EXPECT().WithComponent("RUNNER").Return(componentLog)
-	componentLog.EXPECT().Info().Return(nil).AnyTimes()
-	componentLog.EXPECT().Warn().Return(nil).AnyTimes()
 
 	mockDiscovery := discovery.NewMockDiscovery(ctrl)
 	mockDiscovery.EXPECT().Resolve("test").Return([]discovery.Tier{
-		{Name: "platform", Services: []string{"api", "web"}},
+		{Name: "platform", Services: []string{"api"}},
 	}, nil)
 
-	mockRegistry := registry.NewMockRegistry(ctrl)
 	mockPreflight := preflight.NewMockPreflight(ctrl)
-	mockPreflight.EXPECT().Cleanup(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockPreflight.EXPECT().Find(gomock.Any()).Return(nil, errors.New("permission denied"))
 
-	mockService := NewMockService(ctrl)
-	mockWorkerPool := worker.NewMockPool(ctrl)
-	mockBus := bus.NoOp()
+	r := NewRunner(RunnerParams{
+		Config:    cfg,
+		Discovery: mockDiscovery,
+		Registry:  registry.NewMockRegistry(ctrl),
+		Preflight: mockPreflight,
+		Service:   NewMockService(ctrl),
+		Worker:    worker.NewMockPool(ctrl),
+		Bus:       bus.NoOp(),
+		Logger:    mockLog,
+	})
+
+	err := r.Stop(context.Background(), "test", func([]preflight.Result) (bool, error) {
+		t.Fatal("confirm must not be called when the scan fails")
+		return false, nil
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to scan processes")
+}
+
+func Test_Stop_SessionFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	startedAt := time.Now()
+	cmd := exec.Command("sleep", "10")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	require.NoError(t, cmd.Start())
+
+	defer func() {
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		_ = cmd.Wait()
+	}()
+
+	path, err := session.Path("test")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+
+	data, err := json.Marshal(session.Manifest{
+		Profile: "test",
+		PID:     0,
+		Services: map[string]session.Service{
+			"api": {Name: "api", PID: cmd.Process.Pid, PGID: cmd.Process.Pid, StartedAt: startedAt, Command: []string{"/bin/sleep", "10"}},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{Dir: "api"}
+
+	mockLog := logger.NewMockLogger(ctrl)
+	componentLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().WithComponent("RUNNER").Return(componentLog)
+	componentLog.EXPECT().Info().Return(nil).AnyTimes()
+
+	mockDiscovery := discovery.NewMockDiscovery(ctrl)
+	mockDiscovery.EXPECT().Resolve("test").Return([]discovery.Tier{
+		{Name: "platform", Services: []string{"api"}},
+	}, nil)
+
+	targets := []preflight.Result{{Service: "api", Name: "sleep", PID: int32(cmd.Process.Pid), Match: preflight.MatchSession}}
+
+	mockPreflight := preflight.NewMockPreflight(ctrl)
+	mockPreflight.EXPECT().Kill(gomock.Any(), targets).Return(targets)
 
 	r := NewRunner(RunnerParams{
 		Config:    cfg,
 		Discovery: mockDiscovery,
-		Registry:  mockRegistry,
+		Registry:  registry.NewMockRegistry(ctrl),
 		Preflight: mockPreflight,
-		Service:   mockService,
-		Worker:    mockWorkerPool,
-		Bus:       mockBus,
+		Service:   NewMockService(ctrl),
+		Worker:    worker.NewMockPool(ctrl),
+		Bus:       bus.NoOp(),
 		Logger:    mockLog,
 	})
 
-	err := r.Stop(context.Background(), "test")
+	err = r.Stop(context.Background(), "test", func(found []preflight.Result) (bool, error) {
+		assert.Equal(t, targets, found)
+		return true, nil
+	})
 
 	require.NoError(t, err)
+	assert.NoFileExists(t, path)
 }
 
 func Test_Stop_ProfileNotFound(t *testing.T) {
@@ -1095,7 +1390,7 @@ func Test_Stop_ProfileNotFound(t *testing.T) {
 		Logger:    mockLog,
 	})
 
-	err := r.Stop(context.Background(), "nonexistent")
+	err := r.Stop(context.Background(), "nonexistent", nil)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to resolve profile")
@@ -1131,7 +1426,7 @@ func Test_Stop_NoServices(t *testing.T) {
 		Logger:    mockLog,
 	})
 
-	err := r.Stop(context.Background(), "empty")
+	err := r.Stop(context.Background(), "empty", nil)
 
 	require.NoError(t, err)
 }
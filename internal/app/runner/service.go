@@ -7,18 +7,20 @@ import (
 	"fmt"
 	"io"
 	"net"
-	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/charmbracelet/x/ansi"
 	"go.uber.org/fx"
 
 	"fuku/internal/app/bus"
 	"fuku/internal/app/errors"
 	"fuku/internal/app/lifecycle"
+	"fuku/internal/app/preflight"
 	"fuku/internal/app/process"
 	"fuku/internal/app/readiness"
 	"fuku/internal/app/registry"
@@ -30,19 +32,23 @@ import (
 const (
 	streamBufferSize = 64 * 1024
 	maxLineSize      = 4 * 1024 * 1024
-
-	schemeHTTP  = "http"
-	schemeHTTPS = "https"
-	portHTTP    = "80"
-	portHTTPS   = "443"
 )
 
+// startAttempt identifies which start attempt of a service is running
+type startAttempt struct {
+	number int
+	max    int
+}
+
 // Service handles individual service lifecycle
 type Service interface {
 	Start(ctx context.Context, tier string, svc bus.Service) error
 	Stop(id string)
 	Restart(ctx context.Context, svc bus.Service)
 	Resume(ctx context.Context, svc bus.Service)
+	Relaunch(ctx context.Context, svc bus.Service)
+	Attach(name string) (*relay.Attachment, error)
+	Environment(name string) (dir string, env []string, err error)
 }
 
 // ServiceParams contains dependencies for creating a Service
@@ -67,6 +73,9 @@ type service struct {
 	guard       Guard
 	bus         bus.Bus
 	broadcaster relay.Broadcaster
+	restarts    restartHistory
+	consolesMu  sync.Mutex
+	consoles    map[string]*console
 	log         logger.Logger
 }
 
@@ -80,46 +89,94 @@ func NewService(p ServiceParams) Service {
 		guard:       p.Guard,
 		bus:         p.Bus,
 		broadcaster: p.Broadcaster,
+		consoles:    make(map[string]*console),
 		log:         p.Logger.WithComponent("SERVICE"),
 	}
 }
 
-// Start starts a service with retry logic
+// Start builds a service and starts it, retrying start failures according to the service's retry policy
 func (s *service) Start(ctx context.Context, tier string, svc bus.Service) error {
+	return s.start(ctx, tier, svc, false)
+}
+
+// start starts a service with retries. Unless relaunch is set, the service is built first and the
+// before_start hook runs on each attempt until it succeeds once, so retries after that do not repeat it.
+// A relaunch by the restart policy reuses the build and skips the hook
+func (s *service) start(ctx context.Context, tier string, svc bus.Service, relaunch bool) error {
 	cfg := s.cfg.Services[svc.Name]
+	policy := s.cfg.RetryPolicy(svc.Name)
+	event := bus.ServiceEvent{Service: svc, Tier: tier}
 
-	var lastErr error
+	if !relaunch {
+		if err := s.build(ctx, event, cfg); err != nil {
+			return err
+		}
+	}
+
+	beforeStart := !relaunch
+
+	var (
+		lastErr  error
+		attempts int
+	)
 
-	for attempt := 1; attempt <= s.cfg.Retry.Attempts; attempt++ {
+	for attempt := 1; attempt <= policy.Attempts; attempt++ {
 		if attempt > 1 {
-			s.log.Info().Msgf("Retrying service '%s' (attempt %d/%d)", svc.Name, attempt, s.cfg.Retry.Attempts)
+			delay := policy.Delay(attempt - 1)
+			s.log.Info().Msgf("Retrying service '%s' in %s (attempt %d/%d)", svc.Name, delay, attempt, policy.Attempts)
 
 			select {
-			case <-time.After(s.cfg.Retry.Backoff):
+			case <-time.After(delay):
 			case <-ctx.Done():
 				return ctx.Err()
 			}
 		}
 
-		proc, err := s.doStart(ctx, tier, svc, cfg)
-		if err != nil {
-			lastErr = err
+		attempts = attempt
+
+		var (
+			proc process.Process
+			err  error
+		)
 
-			continue
+		if beforeStart {
+			err = s.runBeforeStart(ctx, event, cfg)
+			beforeStart = err != nil
 		}
 
-		s.registry.Add(tier, svc, proc)
-		s.watchForExit(svc.ID, proc)
+		if err == nil {
+			proc, err = s.doStart(ctx, tier, svc, cfg, startAttempt{number: attempt, max: policy.Attempts})
+		}
 
-		return nil
+		if err == nil {
+			if !cfg.IsJob() {
+				s.registry.Add(tier, svc, proc)
+				s.watchForExit(svc.ID, proc)
+			}
+
+			return nil
+		}
+
+		lastErr = err
+
+		// once the start context is done, by a tier timeout or shutdown, the caller reports the outcome
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		}
+
+		if class := retryClass(err); !policy.Retries(class) {
+			s.log.Warn().Err(err).Msgf("Not retrying service '%s' after %s failure", svc.Name, class)
+
+			break
+		}
 	}
 
-	err := fmt.Errorf("%w after %d attempts: %w", errors.ErrMaxRetriesExceeded, s.cfg.Retry.Attempts, lastErr)
+	err := fmt.Errorf("%w after %d attempts: %w", errors.ErrMaxRetriesExceeded, attempts, lastErr)
 	s.log.Error().Err(err).Msgf("Failed to start service '%s'", svc.Name)
 	s.bus.Publish(bus.Message{
 		Type: bus.EventServiceFailed,
 		Data: bus.ServiceFailed{
-			ServiceEvent: bus.ServiceEvent{Service: svc, Tier: tier},
+			ServiceEvent: event,
 			Error:        err,
 		},
 		Critical: true,
@@ -146,7 +203,7 @@ func (s *service) Stop(id string) {
 		Critical: true,
 	})
 
-	s.doStop(id, lookup.Proc)
+	s.doStop(id, lookup.Name, lookup.Proc)
 
 	s.log.Info().Msgf("Service '%s' stopped", lookup.Name)
 	s.bus.Publish(bus.Message{
@@ -158,7 +215,8 @@ func (s *service) Stop(id string) {
 	})
 }
 
-// Restart restarts a service with guard protection
+// Restart restarts a service with guard protection. A configured build runs first and the running
+// process is only replaced once it succeeds
 func (s *service) Restart(ctx context.Context, svc bus.Service) {
 	if !s.guard.Lock(svc.ID) {
 		s.log.Info().Msgf("Service '%s' restart already in progress, skipping", svc.Name)
@@ -182,6 +240,10 @@ func (s *service) Restart(ctx context.Context, svc bus.Service) {
 		return
 	}
 
+	if err := s.build(ctx, bus.ServiceEvent{Service: svc, Tier: tier}, cfg); err != nil {
+		return
+	}
+
 	s.log.Info().Msgf("Restarting service '%s'", svc.Name)
 
 	s.bus.Publish(bus.Message{
@@ -194,10 +256,16 @@ func (s *service) Restart(ctx context.Context, svc bus.Service) {
 
 	if lookup := s.registry.Get(svc.ID); lookup.Exists {
 		s.log.Info().Msgf("Stopping service '%s' before restart", svc.Name)
-		s.doStop(svc.ID, lookup.Proc)
+		s.doStop(svc.ID, svc.Name, lookup.Proc)
+	}
+
+	err := s.runBeforeStart(ctx, bus.ServiceEvent{Service: svc, Tier: tier}, cfg)
+
+	var proc process.Process
+	if err == nil {
+		proc, err = s.doStart(ctx, tier, svc, cfg, startAttempt{number: 1, max: 1})
 	}
 
-	proc, err := s.doStart(ctx, tier, svc, cfg)
 	if err != nil {
 		s.log.Error().Err(err).Msgf("Failed to restart service '%s'", svc.Name)
 		s.bus.Publish(bus.Message{
@@ -212,12 +280,27 @@ func (s *service) Restart(ctx context.Context, svc bus.Service) {
 		return
 	}
 
+	if cfg.IsJob() {
+		return
+	}
+
 	s.registry.Add(tier, svc, proc)
 	s.watchForExit(svc.ID, proc)
 }
 
 // Resume starts a stopped or failed service with guard protection
 func (s *service) Resume(ctx context.Context, svc bus.Service) {
+	s.resume(ctx, svc, false)
+}
+
+// Relaunch starts a service again after its restart policy restarted it. Unlike Resume, the build
+// and the before_start hook already ran for the start that launched it and are not run again
+func (s *service) Relaunch(ctx context.Context, svc bus.Service) {
+	s.resume(ctx, svc, true)
+}
+
+// resume starts a service that is not registered with guard protection
+func (s *service) resume(ctx context.Context, svc bus.Service, relaunch bool) {
 	if !s.guard.Lock(svc.ID) {
 		s.log.Info().Msgf("Service '%s' start already in progress, skipping", svc.Name)
 
@@ -246,13 +329,13 @@ func (s *service) Resume(ctx context.Context, svc bus.Service) {
 		return
 	}
 
-	//nolint:errcheck // errors published to bus internally by Start
-	s.Start(ctx, tier, svc)
+	//nolint:errcheck // errors published to bus internally by start
+	s.start(ctx, tier, svc, relaunch)
 }
 
-// doStart creates, starts, and waits for a service to be ready
-func (s *service) doStart(ctx context.Context, tier string, svc bus.Service, cfg *config.Service) (process.Process, error) {
-	serviceDir, envFile, err := s.resolvePaths(svc.Name, cfg.Dir)
+// doStart creates, starts, and waits for a service to be ready, or for a job to complete
+func (s *service) doStart(ctx context.Context, tier string, svc bus.Service, cfg *config.Service, attempt startAttempt) (process.Process, error) {
+	serviceDir, env, err := s.commandEnv(svc.Name, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -263,22 +346,34 @@ func (s *service) doStart(ctx context.Context, tier string, svc bus.Service, cfg
 
 	cmd := buildCommand(cfg.Command)
 	cmd.Dir = serviceDir
+	cmd.Env = env
 
-	cmd.Env = append(os.Environ(), "ENV_FILE="+envFile)
+	event := bus.ServiceEvent{Service: svc, Tier: tier}
 
-	stdoutPipe, err := cmd.StdoutPipe()
+	stdoutPipe, stderrPipe, term, err := s.outputPipes(cmd, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("%w (stdout): %w", errors.ErrFailedToCreatePipe, err)
+		return nil, err
 	}
 
-	stderrPipe, err := cmd.StderrPipe()
+	stdin, err := inputPipe(cmd, cfg, term)
 	if err != nil {
-		return nil, fmt.Errorf("%w (stderr): %w", errors.ErrFailedToCreatePipe, err)
+		return nil, err
 	}
 
-	s.lifecycle.Configure(cmd)
+	s.lifecycle.Configure(cmd, svc.Name, cfg.Limits)
+
+	if term != nil {
+		term.attach(cmd)
+	}
 
 	if err := cmd.Start(); err != nil {
+		if term != nil {
+			term.Close()
+		}
+
+		//nolint:errcheck // the command never ran, so it cannot have run out of memory
+		s.lifecycle.Release(cmd)
+
 		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToStartCommand, err)
 	}
 
@@ -288,19 +383,43 @@ func (s *service) doStart(ctx context.Context, tier string, svc bus.Service, cfg
 	s.bus.Publish(bus.Message{
 		Type: bus.EventServiceStarting,
 		Data: bus.ServiceStarting{
-			ServiceEvent: bus.ServiceEvent{Service: svc, Tier: tier},
+			ServiceEvent: event,
 			PID:          cmd.Process.Pid,
-			Attempt:      1,
+			Attempt:      attempt.number,
+			MaxAttempts:  attempt.max,
 			StartedAt:    startedAt,
 		},
 		Critical: true,
 	})
 
-	proc := s.setupStreams(svc.Name, cmd, stdoutPipe, stderrPipe)
+	console := newConsole(stdin, term != nil)
+	proc := s.setupStreams(svc.Name, cmd, console.tap(stdoutPipe), console.tap(stderrPipe))
+	s.openConsole(svc.Name, console, proc)
+
+	if term != nil {
+		term.started()
+
+		go term.closeAfter(proc.Done())
+	}
+
 	s.setupReadinessCheck(ctx, svc, cfg, proc)
 
+	if cfg.IsJob() {
+		if err := s.completeJob(ctx, event, cfg, proc, startedAt); err != nil {
+			return nil, err
+		}
+
+		return proc, nil
+	}
+
 	if err := s.waitForReady(ctx, proc, cfg); err != nil {
-		_ = s.lifecycle.Terminate(proc, config.ShutdownTimeout)
+		_ = s.lifecycle.Terminate(proc, s.cfg.StopPolicy(svc.Name))
+
+		return nil, err
+	}
+
+	if err := s.runHook(ctx, event, config.HookAfterReady, serviceDir, cmd.Env); err != nil {
+		_ = s.lifecycle.Terminate(proc, s.cfg.StopPolicy(svc.Name))
 
 		return nil, err
 	}
@@ -308,7 +427,7 @@ func (s *service) doStart(ctx context.Context, tier string, svc bus.Service, cfg
 	s.bus.Publish(bus.Message{
 		Type: bus.EventServiceReady,
 		Data: bus.ServiceReady{
-			ServiceEvent: bus.ServiceEvent{Service: svc, Tier: tier},
+			ServiceEvent: event,
 			PID:          cmd.Process.Pid,
 			StartedAt:    startedAt,
 			Duration:     time.Since(startedAt),
@@ -319,14 +438,46 @@ func (s *service) doStart(ctx context.Context, tier string, svc bus.Service, cfg
 	return proc, nil
 }
 
-// doStop terminates a running process
-func (s *service) doStop(id string, proc process.Process) {
+// runBeforeStart runs the service's before_start hook in the service directory and environment
+func (s *service) runBeforeStart(ctx context.Context, event bus.ServiceEvent, cfg *config.Service) error {
+	if _, ok := s.cfg.HookPolicy(event.Service.Name, config.HookBeforeStart); !ok {
+		return nil
+	}
+
+	serviceDir, env, err := s.commandEnv(event.Service.Name, cfg)
+	if err != nil {
+		return err
+	}
+
+	return s.runHook(ctx, event, config.HookBeforeStart, serviceDir, env)
+}
+
+// doStop terminates a running process using the service's stop policy, running its stop hooks around it
+func (s *service) doStop(id, name string, proc process.Process) {
 	s.registry.Detach(id)
 
-	_ = s.lifecycle.Terminate(proc, config.ShutdownTimeout)
+	s.runStopHook(id, name, config.HookBeforeStop, proc)
+
+	_ = s.lifecycle.Terminate(proc, s.cfg.StopPolicy(name))
 	<-proc.Done()
 
 	s.registry.Remove(id, proc)
+
+	s.runStopHook(id, name, config.HookAfterStop, proc)
+}
+
+// runStopHook runs a stop hook in the directory and environment of the stopped process.
+// Failures are logged by runHook and never block stopping
+func (s *service) runStopHook(id, name, stage string, proc process.Process) {
+	if _, ok := s.cfg.HookPolicy(name, stage); !ok {
+		return
+	}
+
+	_, tier := s.getConfig(name)
+	event := bus.ServiceEvent{Service: bus.Service{ID: id, Name: name}, Tier: tier}
+
+	//nolint:errcheck // stop hook failures are logged and do not stop the service from stopping
+	s.runHook(context.Background(), event, stage, proc.Cmd().Dir, proc.Cmd().Env)
 }
 
 // resolvePaths validates and returns absolute paths for service directory and env file
@@ -354,8 +505,90 @@ func (s *service) resolvePaths(name, dir string) (serviceDir, envFile string, er
 	return serviceDir, envFile, nil
 }
 
+// Environment resolves the directory and environment of a service for a command run on the user's behalf.
+// It leaves out the session tags, so fuku does not mistake the command for a leftover service process
+func (s *service) Environment(name string) (string, []string, error) {
+	cfg, ok := s.cfg.Services[name]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: '%s'", errors.ErrServiceNotFound, name)
+	}
+
+	return s.serviceEnv(name, cfg)
+}
+
+// commandEnv resolves the directory and full environment that a service's commands run with
+func (s *service) commandEnv(name string, cfg *config.Service) (string, []string, error) {
+	serviceDir, environ, err := s.serviceEnv(name, cfg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return serviceDir, append(environ, preflight.Tags(name)...), nil
+}
+
+// serviceEnv resolves the service directory and the environment built from the env files and config
+func (s *service) serviceEnv(name string, cfg *config.Service) (string, []string, error) {
+	serviceDir, envFile, err := s.resolvePaths(name, cfg.Dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	env, err := s.cfg.Environ(cfg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	environ := append(os.Environ(), "ENV_FILE="+envFile)
+
+	return serviceDir, append(environ, env...), nil
+}
+
+// outputPipes returns the readers for the command's stdout and stderr. A tty service gets a
+// pseudo-terminal whose merged output is read as stdout, leaving stderr empty
+func (s *service) outputPipes(cmd *exec.Cmd, cfg *config.Service) (stdout, stderr io.ReadCloser, term *terminal, err error) {
+	if cfg.TTY {
+		term, err = openTerminal()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: %w", errors.ErrFailedToOpenTerminal, err)
+		}
+
+		return term, io.NopCloser(strings.NewReader("")), term, nil
+	}
+
+	stdout, err = cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w (stdout): %w", errors.ErrFailedToCreatePipe, err)
+	}
+
+	stderr, err = cmd.StderrPipe()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w (stderr): %w", errors.ErrFailedToCreatePipe, err)
+	}
+
+	return stdout, stderr, nil, nil
+}
+
+// inputPipe returns the writer for the command's stdin, which is the terminal itself for a tty service.
+// Services that do not opt into input keep /dev/null as stdin and get no writer
+func inputPipe(cmd *exec.Cmd, cfg *config.Service, term *terminal) (io.Writer, error) {
+	if term != nil {
+		return term, nil
+	}
+
+	if !cfg.Stdin {
+		return nil, nil
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w (stdin): %w", errors.ErrFailedToCreatePipe, err)
+	}
+
+	return stdin, nil
+}
+
 // setupStreams creates process handle and starts stream goroutines
-func (s *service) setupStreams(name string, cmd *exec.Cmd, stdoutPipe, stderrPipe io.ReadCloser) process.Process {
+func (s *service) setupStreams(name string, cmd *exec.Cmd, stdoutPipe, stderrPipe io.Reader) process.Process {
 	stdoutReader, stdoutWriter := io.Pipe()
 	stderrReader, stderrWriter := io.Pipe()
 
@@ -376,6 +609,11 @@ func (s *service) setupStreams(name string, cmd *exec.Cmd, stdoutPipe, stderrPip
 			s.log.Error().Err(err).Msgf("Service '%s' exited with error", name)
 		}
 
+		if err := s.lifecycle.Release(cmd); err != nil {
+			s.log.Error().Err(err).Msgf("Service '%s' was killed", name)
+			proc.Fail(err)
+		}
+
 		stdoutWriter.Close()
 		stderrWriter.Close()
 	}()
@@ -436,7 +674,7 @@ func (s *service) waitForReady(ctx context.Context, proc process.Process, cfg *c
 	}
 }
 
-// watchForExit monitors process and handles unexpected exits
+// watchForExit monitors process and handles unexpected exits according to the restart policy
 func (s *service) watchForExit(id string, proc process.Process) {
 	go func() {
 		<-proc.Done()
@@ -447,24 +685,64 @@ func (s *service) watchForExit(id string, proc process.Process) {
 		}
 
 		svc := bus.Service{ID: id, Name: result.Name}
+		event := bus.ServiceEvent{Service: svc, Tier: result.Tier}
+		code := exitCode(proc)
+
+		s.log.Info().Msgf("Service '%s' exited unexpectedly with code %d", result.Name, code)
 
-		s.log.Info().Msgf("Service '%s' exited unexpectedly", result.Name)
+		decision := s.restarts.decide(id, s.cfg.RestartPolicy(result.Name), code, time.Now())
 
-		if s.isWatched(result.Name) {
+		switch {
+		case decision.crashLoop:
+			s.log.Error().Msgf("Service '%s' is crash-looping after %d restarts, not restarting", result.Name, decision.restarts)
+			s.bus.Publish(bus.Message{
+				Type: bus.EventServiceCrashLoop,
+				Data: bus.ServiceCrashLoop{
+					ServiceEvent: event,
+					ExitCode:     code,
+					Restarts:     decision.restarts,
+				},
+				Critical: true,
+			})
+		case decision.restart:
+			s.log.Info().Msgf("Restarting service '%s' in %s (restart %d)", result.Name, decision.delay, decision.restarts)
+			s.bus.Publish(bus.Message{
+				Type: bus.EventServiceBackoff,
+				Data: bus.ServiceBackoff{
+					ServiceEvent: event,
+					ExitCode:     code,
+					Restarts:     decision.restarts,
+					Delay:        decision.delay,
+				},
+				Critical: true,
+			})
+		case proc.Err() != nil:
 			s.bus.Publish(bus.Message{
 				Type: bus.EventServiceFailed,
 				Data: bus.ServiceFailed{
-					ServiceEvent: bus.ServiceEvent{Service: svc, Tier: result.Tier},
+					ServiceEvent: event,
+					Error:        proc.Err(),
+					ExitCode:     code,
+				},
+				Critical: true,
+			})
+		case s.isWatched(result.Name):
+			s.bus.Publish(bus.Message{
+				Type: bus.EventServiceFailed,
+				Data: bus.ServiceFailed{
+					ServiceEvent: event,
 					Error:        errors.ErrUnexpectedExit,
+					ExitCode:     code,
 				},
 				Critical: true,
 			})
-		} else {
+		default:
 			s.bus.Publish(bus.Message{
 				Type: bus.EventServiceStopped,
 				Data: bus.ServiceStopped{
-					ServiceEvent: bus.ServiceEvent{Service: svc, Tier: result.Tier},
+					ServiceEvent: event,
 					Unexpected:   true,
+					ExitCode:     code,
 				},
 				Critical: true,
 			})
@@ -473,7 +751,13 @@ func (s *service) watchForExit(id string, proc process.Process) {
 }
 
 // teeStream reads from source and writes to destination while logging
-func (s *service) teeStream(src io.Reader, dst *io.PipeWriter, serviceName, streamType string) {
+func (s *service) teeStream(src io.Reader, dst io.Writer, serviceName, streamType string) {
+	s.teeTagged(src, dst, serviceName, serviceName, streamType)
+}
+
+// teeTagged reads from source and writes to destination, logging and broadcasting lines under tag
+// when the service's log output settings include the stream
+func (s *service) teeTagged(src io.Reader, dst io.Writer, serviceName, tag, streamType string) {
 	isEnabled := s.shouldLogStream(serviceName, streamType)
 	if !isEnabled {
 		//nolint:errcheck // pipe write errors are handled by the reader
@@ -501,11 +785,11 @@ func (s *service) teeStream(src io.Reader, dst *io.PipeWriter, serviceName, stre
 			//nolint:errcheck // pipe write errors are handled by the reader
 			dst.Write([]byte{'\n'})
 
-			text := buf.String()
-			s.log.Info().Str("service", serviceName).Str("stream", streamType).Msg(text)
+			text := lastRedraw(buf.String())
+			s.log.Info().Str("service", tag).Str("stream", streamType).Msg(s.logText(text))
 
 			if s.broadcaster != nil {
-				s.broadcaster.Broadcast(serviceName, text)
+				s.broadcaster.Broadcast(tag, text)
 			}
 
 			buf.Reset()
@@ -521,6 +805,28 @@ func (s *service) teeStream(src io.Reader, dst *io.PipeWriter, serviceName, stre
 	}
 }
 
+// lastRedraw returns what a terminal would show for a line that redraws itself with carriage
+// returns, such as a progress bar
+func lastRedraw(line string) string {
+	line = strings.TrimRight(line, "\r")
+
+	if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+		return line[i+1:]
+	}
+
+	return line
+}
+
+// logText returns a service line as it is written to the log, without terminal escape codes
+// when logging as JSON
+func (s *service) logText(text string) string {
+	if s.cfg.Logging.Format == logger.JSONFormat {
+		return ansi.Strip(text)
+	}
+
+	return text
+}
+
 // shouldLogStream returns whether a service stream should be logged to console
 func (s *service) shouldLogStream(name, streamType string) bool {
 	cfg, exists := s.cfg.Services[name]
@@ -577,45 +883,7 @@ func (s *service) preFlightCheck(name string, r *config.Readiness) error {
 
 // extractAddress returns the host:port from readiness configuration
 func (s *service) extractAddress(r *config.Readiness) string {
-	if r == nil {
-		return ""
-	}
-
-	switch r.Type {
-	case config.TypeHTTP:
-		return extractFromURL(r.URL)
-	case config.TypeTCP:
-		return r.Address
-	default:
-		return ""
-	}
-}
-
-// extractFromURL extracts host:port from URL (e.g., "http://localhost:8080/health" -> "localhost:8080")
-func extractFromURL(rawURL string) string {
-	parsed, err := url.Parse(rawURL)
-	if err != nil {
-		return ""
-	}
-
-	host := parsed.Hostname()
-	if host == "" {
-		return ""
-	}
-
-	port := parsed.Port()
-	if port == "" {
-		switch parsed.Scheme {
-		case schemeHTTP:
-			port = portHTTP
-		case schemeHTTPS:
-			port = portHTTPS
-		default:
-			return ""
-		}
-	}
-
-	return net.JoinHostPort(host, port)
+	return r.Endpoint()
 }
 
 // isWatched returns true if the service has watch configuration
@@ -638,3 +906,31 @@ func drainPipe(reader io.Reader) {
 	//nolint:errcheck // intentionally draining pipe
 	io.Copy(io.Discard, reader)
 }
+
+// retryClass maps a start failure to the error class used by retry rules
+func retryClass(err error) string {
+	switch {
+	case errors.Is(err, errors.ErrPortAlreadyInUse):
+		return config.RetryOnPortInUse
+	case errors.Is(err, errors.ErrServiceDirectoryNotExist):
+		return config.RetryOnDirNotFound
+	case errors.Is(err, errors.ErrFailedToStartCommand):
+		return config.RetryOnStartFailed
+	case errors.Is(err, errors.ErrProcessExited):
+		return config.RetryOnProcessExited
+	case errors.Is(err, errors.ErrReadinessTimeout):
+		return config.RetryOnReadinessTimeout
+	default:
+		return config.RetryOnOther
+	}
+}
+
+// exitCode returns the exit code of a finished process, or -1 when it is unknown or the process was killed by a signal
+func exitCode(proc process.Process) int {
+	cmd := proc.Cmd()
+	if cmd == nil || cmd.ProcessState == nil {
+		return -1
+	}
+
+	return cmd.ProcessState.ExitCode()
+}
@@ -12,6 +12,7 @@ package runner
 import (
 	context "context"
 	bus "fuku/internal/app/bus"
+	relay "fuku/internal/app/relay"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,49 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Attach mocks base method.
+func (m *MockService) Attach(name string) (*relay.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", name)
+	ret0, _ := ret[0].(*relay.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockServiceMockRecorder) Attach(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockService)(nil).Attach), name)
+}
+
+// Environment mocks base method.
+func (m *MockService) Environment(name string) (string, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Environment", name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Environment indicates an expected call of Environment.
+func (mr *MockServiceMockRecorder) Environment(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Environment", reflect.TypeOf((*MockService)(nil).Environment), name)
+}
+
+// Relaunch mocks base method.
+func (m *MockService) Relaunch(ctx context.Context, svc bus.Service) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Relaunch", ctx, svc)
+}
+
+// Relaunch indicates an expected call of Relaunch.
+func (mr *MockServiceMockRecorder) Relaunch(ctx, svc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relaunch", reflect.TypeOf((*MockService)(nil).Relaunch), ctx, svc)
+}
+
 // Restart mocks base method.
 func (m *MockService) Restart(ctx context.Context, svc bus.Service) {
 	m.ctrl.T.Helper()
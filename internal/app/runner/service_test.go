@@ -3,6 +3,7 @@ package runner
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"fuku/internal/app/bus"
 	"fuku/internal/app/errors"
 	"fuku/internal/app/lifecycle"
+	"fuku/internal/app/preflight"
 	"fuku/internal/app/process"
 	"fuku/internal/app/readiness"
 	"fuku/internal/app/registry"
@@ -98,7 +100,42 @@ func Test_Stop_ServiceRunning(t *testing.T) {
 	mockProcess.EXPECT().Done().Return(doneChan)
 
 	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
-	mockLifecycle.EXPECT().Terminate(mockProcess, config.ShutdownTimeout).Return(nil)
+	mockLifecycle.EXPECT().Terminate(mockProcess, config.Stop{Signal: config.StopSignal, Timeout: config.ShutdownTimeout, Grace: config.ShutdownTimeout}).Return(nil)
+
+	mockRegistry := registry.NewMockRegistry(ctrl)
+	mockRegistry.EXPECT().Get("test-id-api").Return(registry.Lookup{Proc: mockProcess, Name: "api", Tier: "platform", Exists: true})
+	mockRegistry.EXPECT().Detach("test-id-api")
+	mockRegistry.EXPECT().Remove("test-id-api", mockProcess).Return(registry.RemoveResult{Removed: true})
+
+	s := &service{
+		cfg:       cfg,
+		lifecycle: mockLifecycle,
+		registry:  mockRegistry,
+		bus:       bus.NoOp(),
+		log:       mockLog,
+	}
+
+	s.Stop("test-id-api")
+}
+
+func Test_Stop_UsesServiceStopPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{Stop: &config.Stop{Signal: "SIGINT", Timeout: time.Minute, Command: "make stop"}}
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+
+	doneChan := make(chan struct{})
+	close(doneChan)
+
+	mockProcess := process.NewMockProcess(ctrl)
+	mockProcess.EXPECT().Done().Return(doneChan)
+
+	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+	mockLifecycle.EXPECT().Terminate(mockProcess, config.Stop{Signal: "SIGINT", Timeout: time.Minute, Grace: time.Minute, Command: "make stop"}).Return(nil)
 
 	mockRegistry := registry.NewMockRegistry(ctrl)
 	mockRegistry.EXPECT().Get("test-id-api").Return(registry.Lookup{Proc: mockProcess, Name: "api", Tier: "platform", Exists: true})
@@ -183,7 +220,8 @@ func Test_Restart_StoppedService(t *testing.T) {
 	mockGuard.EXPECT().Unlock("test-id-api")
 
 	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
-	mockLifecycle.EXPECT().Configure(gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
 	mockLifecycle.EXPECT().Terminate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	mockReadiness := readiness.NewMockReadiness(ctrl)
@@ -310,7 +348,8 @@ func Test_Resume_Success(t *testing.T) {
 	mockGuard.EXPECT().Unlock("test-id-api")
 
 	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
-	mockLifecycle.EXPECT().Configure(gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
 	mockLifecycle.EXPECT().Terminate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	mockReadiness := readiness.NewMockReadiness(ctrl)
@@ -401,7 +440,7 @@ func Test_DoStart_DirectoryNotExist(t *testing.T) {
 	ctx := context.Background()
 	svc := &config.Service{Dir: "/nonexistent/directory/path"}
 
-	proc, err := s.doStart(ctx, "platform", bus.Service{ID: "test-id-svc", Name: "test-service"}, svc)
+	proc, err := s.doStart(ctx, "platform", bus.Service{ID: "test-id-svc", Name: "test-service"}, svc, startAttempt{number: 1, max: 1})
 
 	require.Error(t, err)
 	assert.Nil(t, proc)
@@ -423,13 +462,56 @@ func Test_DoStart_RelativePathConversion(t *testing.T) {
 	ctx := context.Background()
 	svc := &config.Service{Dir: "nonexistent"}
 
-	proc, err := s.doStart(ctx, "platform", bus.Service{ID: "test-id-svc", Name: "test-service"}, svc)
+	proc, err := s.doStart(ctx, "platform", bus.Service{ID: "test-id-svc", Name: "test-service"}, svc, startAttempt{number: 1, max: 1})
 
 	require.Error(t, err)
 	assert.Nil(t, proc)
 	assert.ErrorIs(t, err, errors.ErrServiceDirectoryNotExist)
 }
 
+func Test_DoStart_EnvFileMissing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+
+	s := &service{
+		cfg: cfg,
+		log: mockLog,
+	}
+
+	ctx := context.Background()
+	svc := &config.Service{Dir: t.TempDir(), EnvFile: []string{"/nonexistent/.env"}}
+
+	proc, err := s.doStart(ctx, "platform", bus.Service{ID: "test-id-svc", Name: "test-service"}, svc, startAttempt{number: 1, max: 1})
+
+	require.Error(t, err)
+	assert.Nil(t, proc)
+	assert.ErrorIs(t, err, errors.ErrFailedToReadEnvFile)
+}
+
+func Test_CommandEnv_TagsProcesses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+
+	cfg := config.DefaultConfig()
+	svc := &config.Service{Dir: t.TempDir(), Env: map[string]string{"FUKU_SERVICE": "spoofed"}}
+	cfg.Services["api"] = svc
+
+	s := &service{cfg: cfg, log: mockLog}
+
+	_, env, err := s.commandEnv("api", svc)
+	require.NoError(t, err)
+
+	assert.Equal(t, preflight.Tags("api"), env[len(env)-3:])
+	assert.Contains(t, env, preflight.EnvSession+"="+preflight.Session())
+}
+
 func Test_DoStart_ValidDirectory(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -442,7 +524,8 @@ func Test_DoStart_ValidDirectory(t *testing.T) {
 	cfg := config.DefaultConfig()
 
 	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
-	mockLifecycle.EXPECT().Configure(gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
 	mockLifecycle.EXPECT().Terminate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	mockReadiness := readiness.NewMockReadiness(ctrl)
@@ -468,7 +551,7 @@ func Test_DoStart_ValidDirectory(t *testing.T) {
 
 	svc := &config.Service{Dir: tmpDir}
 
-	proc, err := s.doStart(ctx, "platform", bus.Service{ID: "test-id-svc", Name: "test-service"}, svc)
+	proc, err := s.doStart(ctx, "platform", bus.Service{ID: "test-id-svc", Name: "test-service"}, svc, startAttempt{number: 1, max: 1})
 	if err != nil {
 		assert.Contains(t, err.Error(), "failed to start command")
 
@@ -479,7 +562,7 @@ func Test_DoStart_ValidDirectory(t *testing.T) {
 	cancel()
 
 	if proc != nil {
-		_ = s.lifecycle.Terminate(proc, config.ShutdownTimeout)
+		_ = s.lifecycle.Terminate(proc, cfg.StopPolicy("test-service"))
 	}
 }
 
@@ -1267,7 +1350,8 @@ func Test_DoStart_CustomCommand(t *testing.T) {
 	cfg := config.DefaultConfig()
 
 	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
-	mockLifecycle.EXPECT().Configure(gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
 	mockLifecycle.EXPECT().Terminate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	mockReadiness := readiness.NewMockReadiness(ctrl)
@@ -1290,7 +1374,7 @@ func Test_DoStart_CustomCommand(t *testing.T) {
 
 	svc := &config.Service{Dir: tmpDir, Command: "sleep 60"}
 
-	proc, err := s.doStart(t.Context(), "platform", bus.Service{ID: "test-id-svc", Name: "test-service"}, svc)
+	proc, err := s.doStart(t.Context(), "platform", bus.Service{ID: "test-id-svc", Name: "test-service"}, svc, startAttempt{number: 1, max: 1})
 	require.NoError(t, err)
 	require.NotNil(t, proc)
 
@@ -1300,63 +1384,300 @@ func Test_DoStart_CustomCommand(t *testing.T) {
 	})
 }
 
-func Test_ExtractFromURL(t *testing.T) {
+func Test_Start_RetryPolicy(t *testing.T) {
 	tests := []struct {
 		name     string
-		url      string
-		expected string
+		retry    config.Retry
+		dir      string
+		attempts []int
+		error    error
 	}{
 		{
-			name:     "URL with explicit port",
-			url:      "http://localhost:8080/health",
-			expected: "localhost:8080",
-		},
-		{
-			name:     "URL with IP address and port",
-			url:      "http://127.0.0.1:3000/api",
-			expected: "127.0.0.1:3000",
-		},
-		{
-			name:     "HTTP URL without port defaults to 80",
-			url:      "http://localhost/health",
-			expected: "localhost:80",
-		},
-		{
-			name:     "HTTPS URL without port defaults to 443",
-			url:      "https://localhost/health",
-			expected: "localhost:443",
-		},
-		{
-			name:     "URL with 0.0.0.0",
-			url:      "http://0.0.0.0:8080/health",
-			expected: "0.0.0.0:8080",
-		},
-		{
-			name:     "IPv6 address with port",
-			url:      "http://[::1]:8080/health",
-			expected: "[::1]:8080",
-		},
-		{
-			name:     "invalid URL returns empty",
-			url:      "://invalid",
-			expected: "",
+			name:     "readiness timeout is retried up to service attempts",
+			retry:    config.Retry{Attempts: 3, Backoff: time.Millisecond},
+			attempts: []int{1, 2, 3},
+			error:    errors.ErrReadinessTimeout,
 		},
 		{
-			name:     "empty URL returns empty",
-			url:      "",
-			expected: "",
+			name:     "error rule disables retry",
+			retry:    config.Retry{Attempts: 3, Backoff: time.Millisecond, Errors: map[string]bool{config.RetryOnReadinessTimeout: false}},
+			attempts: []int{1},
+			error:    errors.ErrReadinessTimeout,
 		},
 		{
-			name:     "unknown scheme without port returns empty",
-			url:      "ftp://localhost/file",
-			expected: "",
+			name:     "missing directory is never retried",
+			retry:    config.Retry{Attempts: 3, Backoff: time.Millisecond},
+			dir:      "/nonexistent/directory/path",
+			attempts: nil,
+			error:    errors.ErrServiceDirectoryNotExist,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := extractFromURL(tt.url)
-			assert.Equal(t, tt.expected, result)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := tt.dir
+			if dir == "" {
+				dir = t.TempDir()
+			}
+
+			cfg := config.DefaultConfig()
+			cfg.Services["api"] = &config.Service{
+				Dir:       dir,
+				Command:   "sleep 60",
+				Readiness: &config.Readiness{Type: config.TypeHTTP, URL: "http://127.0.0.1:1/health"},
+			}
+			cfg.Retry = tt.retry
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Info().Return(nil).AnyTimes()
+			mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+			mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+			mockLifecycle.EXPECT().Terminate(gomock.Any(), gomock.Any()).DoAndReturn(func(proc process.Process, _ config.Stop) error {
+				return proc.Cmd().Process.Kill()
+			}).AnyTimes()
+
+			mockReadiness := readiness.NewMockReadiness(ctrl)
+			mockReadiness.EXPECT().Check(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(
+				func(_ context.Context, _ bus.Service, _ *config.Service, proc process.Process) {
+					proc.SignalReady(errors.ErrReadinessTimeout)
+				}).AnyTimes()
+
+			b := bus.NewBus(cfg, nil, nil)
+			defer b.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			msgs := b.Subscribe(ctx)
+
+			s := &service{
+				cfg:       cfg,
+				lifecycle: mockLifecycle,
+				readiness: mockReadiness,
+				bus:       b,
+				log:       mockLog,
+			}
+
+			err := s.Start(ctx, "platform", bus.Service{ID: "test-id-api", Name: "api"})
+			require.ErrorIs(t, err, errors.ErrMaxRetriesExceeded)
+			assert.ErrorIs(t, err, tt.error)
+
+			var attempts []int
+
+			for msg := range msgs {
+				if data, ok := msg.Data.(bus.ServiceStarting); ok {
+					attempts = append(attempts, data.Attempt)
+					assert.Equal(t, tt.retry.Attempts, data.MaxAttempts)
+				}
+
+				if msg.Type == bus.EventServiceFailed {
+					break
+				}
+			}
+
+			assert.Equal(t, tt.attempts, attempts)
 		})
 	}
 }
+
+func Test_Start_StopsRetryingWhenContextDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{
+		Dir:       t.TempDir(),
+		Command:   "sleep 60",
+		Readiness: &config.Readiness{Type: config.TypeHTTP, URL: "http://127.0.0.1:1/health"},
+	}
+	cfg.Retry = config.Retry{Attempts: 3, Backoff: time.Millisecond}
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+	mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+	mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+	mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLifecycle.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+	mockLifecycle.EXPECT().Terminate(gomock.Any(), gomock.Any()).DoAndReturn(func(proc process.Process, _ config.Stop) error {
+		return proc.Cmd().Process.Kill()
+	}).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockReadiness := readiness.NewMockReadiness(ctrl)
+	mockReadiness.EXPECT().Check(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(
+		func(_ context.Context, _ bus.Service, _ *config.Service, proc process.Process) {
+			cancel()
+			proc.SignalReady(errors.ErrReadinessTimeout)
+		}).Times(1)
+
+	b := bus.NewBus(cfg, nil, nil)
+	defer b.Close()
+
+	msgs := b.Subscribe(t.Context())
+
+	s := &service{
+		cfg:       cfg,
+		lifecycle: mockLifecycle,
+		readiness: mockReadiness,
+		bus:       b,
+		log:       mockLog,
+	}
+
+	err := s.Start(ctx, "platform", bus.Service{ID: "test-id-api", Name: "api"})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, errors.ErrMaxRetriesExceeded)
+
+	for {
+		select {
+		case msg := <-msgs:
+			assert.NotEqual(t, bus.EventServiceFailed, msg.Type)
+		case <-time.After(50 * time.Millisecond):
+			return
+		}
+	}
+}
+
+func Test_RetryClass(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "port in use", err: fmt.Errorf("%w: localhost:8080", errors.ErrPortAlreadyInUse), expected: config.RetryOnPortInUse},
+		{name: "directory missing", err: errors.ErrServiceDirectoryNotExist, expected: config.RetryOnDirNotFound},
+		{name: "command failed to start", err: errors.ErrFailedToStartCommand, expected: config.RetryOnStartFailed},
+		{name: "process exited", err: fmt.Errorf("readiness check failed: %w", errors.ErrProcessExited), expected: config.RetryOnProcessExited},
+		{name: "readiness timeout", err: fmt.Errorf("readiness check failed: %w", errors.ErrReadinessTimeout), expected: config.RetryOnReadinessTimeout},
+		{name: "other", err: errors.New("boom"), expected: config.RetryOnOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, retryClass(tt.err))
+		})
+	}
+}
+
+func Test_WatchForExit_RestartPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		restart  *config.Restart
+		command  string
+		crashes  int
+		release  error
+		expected bus.MessageType
+	}{
+		{name: "no policy reports unexpected stop", command: "exit 3", expected: bus.EventServiceStopped},
+		{name: "on-failure schedules a restart", restart: &config.Restart{Policy: config.RestartOnFailure}, command: "exit 3", expected: bus.EventServiceBackoff},
+		{name: "on-failure ignores clean exit", restart: &config.Restart{Policy: config.RestartOnFailure}, command: "exit 0", expected: bus.EventServiceStopped},
+		{name: "exhausted limit is a crash loop", restart: &config.Restart{Policy: config.RestartAlways, MaxRestarts: 1}, command: "exit 3", crashes: 1, expected: bus.EventServiceCrashLoop},
+		{name: "out of memory is reported as a failure", command: "exit 137", release: errors.ErrOutOfMemory, expected: bus.EventServiceFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cfg := config.DefaultConfig()
+			cfg.Services["api"] = &config.Service{Dir: t.TempDir(), Command: "sleep 0.1; " + tt.command, Restart: tt.restart}
+
+			mockLog := logger.NewMockLogger(ctrl)
+			mockLog.EXPECT().Info().Return(nil).AnyTimes()
+			mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+			mockLog.EXPECT().Error().Return(nil).AnyTimes()
+
+			mockLifecycle := lifecycle.NewMockLifecycle(ctrl)
+			mockLifecycle.EXPECT().Configure(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockLifecycle.EXPECT().Release(gomock.Any()).Return(tt.release).AnyTimes()
+
+			b := bus.NewBus(cfg, nil, nil)
+			defer b.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			msgs := b.Subscribe(ctx)
+
+			s := &service{
+				cfg:       cfg,
+				lifecycle: mockLifecycle,
+				registry:  registry.NewRegistry(),
+				bus:       b,
+				log:       mockLog,
+			}
+
+			for range tt.crashes {
+				s.restarts.decide("test-id-api", cfg.RestartPolicy("api"), 3, time.Now())
+			}
+
+			require.NoError(t, s.Start(ctx, "platform", bus.Service{ID: "test-id-api", Name: "api"}))
+
+			for msg := range msgs {
+				switch data := msg.Data.(type) {
+				case bus.ServiceStopped:
+					assert.Equal(t, tt.expected, msg.Type)
+					assert.True(t, data.Unexpected)
+				case bus.ServiceBackoff:
+					assert.Equal(t, tt.expected, msg.Type)
+					assert.Equal(t, 3, data.ExitCode)
+					assert.Equal(t, 1, data.Restarts)
+					assert.Equal(t, config.RestartBackoff, data.Delay)
+				case bus.ServiceCrashLoop:
+					assert.Equal(t, tt.expected, msg.Type)
+					assert.Equal(t, 3, data.ExitCode)
+				case bus.ServiceFailed:
+					assert.Equal(t, tt.expected, msg.Type)
+					assert.ErrorIs(t, data.Error, tt.release)
+					assert.Equal(t, 137, data.ExitCode)
+				default:
+					continue
+				}
+
+				return
+			}
+
+			t.Fatal("no exit event received")
+		})
+	}
+}
+
+func Test_Environment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+
+	dir := t.TempDir()
+
+	cfg := config.DefaultConfig()
+	cfg.Services["api"] = &config.Service{Dir: dir, Env: map[string]string{"DB_NAME": "fuku"}}
+
+	s := &service{cfg: cfg, log: mockLog}
+
+	serviceDir, env, err := s.Environment("api")
+	require.NoError(t, err)
+
+	assert.Equal(t, dir, serviceDir)
+	assert.Contains(t, env, "DB_NAME=fuku")
+	assert.Contains(t, env, "ENV_FILE="+filepath.Join(dir, ".env.development"))
+
+	for _, tag := range preflight.Tags("api") {
+		assert.NotContains(t, env, tag)
+	}
+
+	_, _, err = s.Environment("db")
+	require.ErrorIs(t, err, errors.ErrServiceNotFound)
+}
@@ -0,0 +1,39 @@
+package session
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"fuku/internal/app/registry"
+)
+
+// Module provides the session recorder and records the registry through it
+var Module = fx.Options(
+	fx.Provide(NewRecorder),
+	fx.Decorate(recordRegistry),
+	fx.Invoke(startRecorder),
+)
+
+// recordRegistry wraps the registry so additions and removals reach the session manifest
+func recordRegistry(reg registry.Registry, recorder Recorder) registry.Registry {
+	return &recordedRegistry{Registry: reg, recorder: recorder}
+}
+
+// startRecorder starts the session recorder as part of the FX lifecycle
+func startRecorder(lc fx.Lifecycle, ctx context.Context, recorder Recorder) {
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			recorder.Subscribe(ctx)
+
+			go recorder.Run(ctx)
+
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			recorder.Close()
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,223 @@
+package session
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/preflight"
+	"fuku/internal/app/process"
+	"fuku/internal/app/registry"
+	"fuku/internal/config/logger"
+)
+
+// Recorder keeps the session manifest of a running instance in step with the registry
+type Recorder interface {
+	Subscribe(ctx context.Context)
+	Run(ctx context.Context)
+	Added(tier string, svc bus.Service, proc process.Process)
+	Removed(id string)
+	Close()
+}
+
+// recorder implements the Recorder interface
+type recorder struct {
+	bus       bus.Bus
+	ch        <-chan bus.Message
+	cancelSub context.CancelFunc
+	mu        sync.Mutex
+	path      string
+	manifest  Manifest
+	names     map[string]string
+	log       logger.Logger
+}
+
+// NewRecorder creates a new session recorder
+func NewRecorder(b bus.Bus, log logger.Logger) Recorder {
+	pid := os.Getpid()
+
+	return &recorder{
+		bus: b,
+		manifest: Manifest{
+			PID:       pid,
+			Session:   preflight.Session(),
+			StartedAt: startTime(pid),
+			Services:  make(map[string]Service),
+		},
+		names: make(map[string]string),
+		log:   log.WithComponent("SESSION"),
+	}
+}
+
+// Subscribe registers the recorder as a bus subscriber, must be called before Run
+func (r *recorder) Subscribe(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	r.ch = r.bus.Subscribe(ctx)
+	r.cancelSub = cancel
+}
+
+// Run writes the manifest once the profile is resolved and records the API address and socket once they listen
+func (r *recorder) Run(ctx context.Context) {
+	defer r.cancelSub()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-r.ch:
+			if !ok {
+				return
+			}
+
+			switch data := msg.Data.(type) {
+			case bus.ProfileResolved:
+				r.begin(data.Profile)
+			case bus.APIStarted:
+				r.update(func(m *Manifest) { m.API = data.Listen })
+			case bus.RelayStarted:
+				r.update(func(m *Manifest) { m.Socket = data.Socket })
+			}
+		}
+	}
+}
+
+// Added records a process that entered the registry
+func (r *recorder) Added(tier string, svc bus.Service, proc process.Process) {
+	cmd := proc.Cmd()
+	if cmd == nil || cmd.Process == nil {
+		return
+	}
+
+	pid := cmd.Process.Pid
+
+	pgid, err := syscall.Getpgid(pid)
+	if err != nil {
+		pgid = pid
+	}
+
+	entry := Service{
+		ID:        svc.ID,
+		Name:      svc.Name,
+		Tier:      tier,
+		PID:       pid,
+		PGID:      pgid,
+		StartedAt: startTime(pid),
+		Command:   cmd.Args,
+	}
+
+	r.update(func(m *Manifest) {
+		r.names[svc.ID] = svc.Name
+		m.Services[svc.Name] = entry
+	})
+}
+
+// Removed forgets a process that left the registry
+func (r *recorder) Removed(id string) {
+	r.update(func(m *Manifest) {
+		delete(m.Services, r.names[id])
+		delete(r.names, id)
+	})
+}
+
+// Close removes the manifest as the instance shuts down
+func (r *recorder) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.path == "" {
+		return
+	}
+
+	if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+		r.log.Warn().Err(err).Msgf("Failed to remove session file %s", r.path)
+	}
+
+	r.path = ""
+}
+
+// begin handles a manifest left by an earlier instance of the profile and writes this instance's.
+// The manifest of another live instance is left alone and this instance runs without one
+func (r *recorder) begin(profile string) {
+	path, err := Path(profile)
+	if err != nil {
+		r.log.Warn().Err(err).Msg("Failed to resolve session file, continuing without it")
+
+		return
+	}
+
+	if previous, err := read(path); err == nil && previous.PID != r.manifest.PID {
+		if !previous.Stale() {
+			r.log.Warn().Msgf("Another fuku instance (PID: %d) is running profile '%s' in this project, continuing without a session file", previous.PID, previous.Profile)
+
+			return
+		}
+
+		r.cleanupStale(previous)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.path = path
+	r.manifest.Profile = profile
+
+	r.save()
+}
+
+// cleanupStale terminates the process groups a crashed instance left behind
+func (r *recorder) cleanupStale(previous *Manifest) {
+	running := previous.Running()
+	r.log.Warn().Msgf("Found stale session of fuku (PID: %d) from %s with %d running service(s)", previous.PID, previous.StartedAt.Format(time.DateTime), len(running))
+
+	for _, svc := range running {
+		r.log.Info().Msgf("Terminating leftover process group %d of service '%s'", svc.PGID, svc.Name)
+
+		if err := syscall.Kill(-svc.PGID, syscall.SIGTERM); err != nil {
+			r.log.Warn().Err(err).Msgf("Failed to terminate process group %d", svc.PGID)
+		}
+	}
+}
+
+// update applies a change to the manifest and saves it once the profile is known
+func (r *recorder) update(change func(m *Manifest)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	change(&r.manifest)
+
+	if r.path != "" {
+		r.save()
+	}
+}
+
+// save writes the manifest, must be called with the lock held
+func (r *recorder) save() {
+	if err := write(r.path, &r.manifest); err != nil {
+		r.log.Warn().Err(err).Msgf("Failed to write session file %s", r.path)
+	}
+}
+
+// recordedRegistry records processes entering and leaving the registry in the session manifest
+type recordedRegistry struct {
+	registry.Registry
+	recorder Recorder
+}
+
+// Add registers the process and records it
+func (r *recordedRegistry) Add(tier string, svc bus.Service, proc process.Process) {
+	r.Registry.Add(tier, svc, proc)
+	r.recorder.Added(tier, svc, proc)
+}
+
+// Remove unregisters the process and forgets it when it was still the registered one
+func (r *recordedRegistry) Remove(id string, proc process.Process) registry.RemoveResult {
+	result := r.Registry.Remove(id, proc)
+	if result.Removed {
+		r.recorder.Removed(id)
+	}
+
+	return result
+}
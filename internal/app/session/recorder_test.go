@@ -0,0 +1,213 @@
+package session
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"fuku/internal/app/bus"
+	"fuku/internal/app/process"
+	"fuku/internal/app/registry"
+	"fuku/internal/config"
+	"fuku/internal/config/logger"
+)
+
+func startGroup(t *testing.T) *exec.Cmd {
+	t.Helper()
+
+	cmd := exec.Command("sleep", "10")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	require.NoError(t, cmd.Start())
+
+	t.Cleanup(func() {
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		_ = cmd.Wait()
+	})
+
+	return cmd
+}
+
+func newTestRecorder(t *testing.T, ctrl *gomock.Controller, b bus.Bus) *recorder {
+	t.Helper()
+
+	mockLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().WithComponent("SESSION").Return(mockLog)
+	mockLog.EXPECT().Info().Return(nil).AnyTimes()
+	mockLog.EXPECT().Warn().Return(nil).AnyTimes()
+
+	r, ok := NewRecorder(b, mockLog).(*recorder)
+	require.True(t, ok)
+
+	return r
+}
+
+func Test_Recorder_Lifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	b := bus.NewBus(config.DefaultConfig(), nil, nil)
+	defer b.Close()
+
+	r := newTestRecorder(t, ctrl, b)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r.Subscribe(ctx)
+
+	go r.Run(ctx)
+
+	b.Publish(bus.Message{Type: bus.EventProfileResolved, Data: bus.ProfileResolved{Profile: "core"}, Critical: true})
+
+	path, err := Path("core")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	cmd := startGroup(t)
+	r.Added("platform", bus.Service{ID: "id-api", Name: "api"}, process.NewProcess(process.Params{Name: "api", Cmd: cmd}))
+
+	manifest, err := Load("core")
+	require.NoError(t, err)
+	assert.Empty(t, manifest.Socket)
+
+	b.Publish(bus.Message{Type: bus.EventAPIStarted, Data: bus.APIStarted{Listen: "127.0.0.1:9876"}, Critical: true})
+	b.Publish(bus.Message{Type: bus.EventRelayStarted, Data: bus.RelayStarted{Socket: "/tmp/fuku-test/fuku-core.sock"}, Critical: true})
+
+	require.Eventually(t, func() bool {
+		manifest, err := Load("core")
+		return err == nil && manifest.API == "127.0.0.1:9876" && manifest.Socket == "/tmp/fuku-test/fuku-core.sock"
+	}, time.Second, 10*time.Millisecond)
+
+	manifest, err = Load("core")
+	require.NoError(t, err)
+	assert.Equal(t, "core", manifest.Profile)
+	assert.Equal(t, os.Getpid(), manifest.PID)
+	assert.False(t, manifest.Stale())
+
+	api := manifest.Services["api"]
+	assert.Equal(t, "id-api", api.ID)
+	assert.Equal(t, "platform", api.Tier)
+	assert.Equal(t, cmd.Process.Pid, api.PID)
+	assert.Equal(t, cmd.Process.Pid, api.PGID)
+	assert.Equal(t, []string{"sleep", "10"}, api.Command)
+	assert.Len(t, manifest.Running(), 1)
+
+	r.Removed("id-api")
+
+	manifest, err = Load("core")
+	require.NoError(t, err)
+	assert.Empty(t, manifest.Services)
+
+	r.Close()
+	assert.NoFileExists(t, path)
+}
+
+func Test_Recorder_TerminatesStaleSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	cmd := startGroup(t)
+
+	exited := make(chan struct{})
+
+	go func() {
+		_, _ = cmd.Process.Wait()
+		close(exited)
+	}()
+
+	path, err := Path("core")
+	require.NoError(t, err)
+	require.NoError(t, write(path, &Manifest{
+		Profile: "core",
+		PID:     0,
+		Services: map[string]Service{
+			"api": {Name: "api", PID: cmd.Process.Pid, PGID: cmd.Process.Pid, StartedAt: startTime(cmd.Process.Pid)},
+		},
+	}))
+
+	r := newTestRecorder(t, ctrl, bus.NoOp())
+	r.begin("core")
+
+	select {
+	case <-exited:
+	case <-time.After(2 * time.Second):
+		t.Fatal("leftover process group was not terminated")
+	}
+
+	manifest, err := Load("core")
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), manifest.PID)
+	assert.Empty(t, manifest.Services)
+}
+
+func Test_Recorder_LeavesLiveSessionAlone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	other := startGroup(t)
+
+	path, err := Path("core")
+	require.NoError(t, err)
+
+	live := &Manifest{Profile: "core", PID: other.Process.Pid, StartedAt: startTime(other.Process.Pid), Socket: "/tmp/fuku-other/fuku-core.sock"}
+	require.NoError(t, write(path, live))
+
+	r := newTestRecorder(t, ctrl, bus.NoOp())
+	r.begin("core")
+
+	cmd := startGroup(t)
+	r.Added("platform", bus.Service{ID: "id-api", Name: "api"}, process.NewProcess(process.Params{Name: "api", Cmd: cmd}))
+
+	manifest, err := Load("core")
+	require.NoError(t, err)
+	assert.Equal(t, other.Process.Pid, manifest.PID)
+	assert.Equal(t, live.Socket, manifest.Socket)
+	assert.Empty(t, manifest.Services)
+
+	r.Close()
+	assert.FileExists(t, path)
+}
+
+func Test_RecordedRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRegistry := registry.NewMockRegistry(ctrl)
+	mockRecorder := NewMockRecorder(ctrl)
+
+	reg := recordRegistry(mockRegistry, mockRecorder)
+	svc := bus.Service{ID: "id-api", Name: "api"}
+	proc := process.NewProcess(process.Params{Name: "api"})
+
+	gomock.InOrder(
+		mockRegistry.EXPECT().Add("platform", svc, proc),
+		mockRecorder.EXPECT().Added("platform", svc, proc),
+	)
+	reg.Add("platform", svc, proc)
+
+	mockRegistry.EXPECT().Remove("id-api", proc).Return(registry.RemoveResult{Removed: true})
+	mockRecorder.EXPECT().Removed("id-api")
+	assert.True(t, reg.Remove("id-api", proc).Removed)
+
+	mockRegistry.EXPECT().Remove("id-api", proc).Return(registry.RemoveResult{})
+	assert.False(t, reg.Remove("id-api", proc).Removed)
+}
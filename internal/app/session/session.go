@@ -0,0 +1,209 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+)
+
+const (
+	stateDir = "fuku"
+	// startTolerance allows for rounding when comparing a recorded start time with the one the OS reports
+	startTolerance = time.Second
+)
+
+// Service records one process fuku started for a service
+type Service struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Tier      string    `json:"tier"`
+	PID       int       `json:"pid"`
+	PGID      int       `json:"pgid"`
+	StartedAt time.Time `json:"started_at"`
+	Command   []string  `json:"command"`
+}
+
+// Manifest describes a running fuku instance and the processes it started
+type Manifest struct {
+	Profile   string             `json:"profile"`
+	PID       int                `json:"pid"`
+	Session   string             `json:"session"`
+	StartedAt time.Time          `json:"started_at"`
+	API       string             `json:"api,omitempty"`
+	Socket    string             `json:"socket"`
+	Services  map[string]Service `json:"services"`
+}
+
+// Path returns the manifest file of a profile in the current project,
+// $XDG_STATE_HOME/fuku/<project-hash>/session-<profile>.json
+func Path(profile string) (string, error) {
+	dir, err := projectDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "session-"+profile+".json"), nil
+}
+
+// LogPath returns the log file a detached instance of a profile in the current project writes to,
+// next to its manifest
+func LogPath(profile string) (string, error) {
+	dir, err := projectDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "fuku-"+profile+".log"), nil
+}
+
+// projectDir returns the state directory of the current project
+func projectDir() (string, error) {
+	base := os.Getenv("XDG_STATE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+
+		base = filepath.Join(home, ".local", "state")
+	}
+
+	id, err := config.ProjectID()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(base, stateDir, id), nil
+}
+
+// Load reads the manifest of a profile in the current project
+func Load(profile string) (*Manifest, error) {
+	path, err := Path(profile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToReadSession, err)
+	}
+
+	return read(path)
+}
+
+// Discard removes the manifest of a profile in the current project
+func Discard(profile string) error {
+	path, err := Path(profile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Stale reports whether the instance that wrote the manifest is no longer running
+func (m *Manifest) Stale() bool {
+	return !isRunning(m.PID, m.StartedAt)
+}
+
+// Running returns the recorded services whose process is still the one fuku started, sorted by name
+func (m *Manifest) Running() []Service {
+	running := make([]Service, 0, len(m.Services))
+
+	for _, svc := range m.Services {
+		if isRunning(svc.PID, svc.StartedAt) {
+			running = append(running, svc)
+		}
+	}
+
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].Name < running[j].Name
+	})
+
+	return running
+}
+
+// read loads a manifest file
+func read(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", errors.ErrSessionNotFound, path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToReadSession, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errors.ErrFailedToReadSession, path, err)
+	}
+
+	return &manifest, nil
+}
+
+// write stores a manifest atomically so readers never see a partial file
+func write(path string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// startTime returns when a process started, or the current time when the OS does not say
+func startTime(pid int) time.Time {
+	if pid <= 0 || pid > math.MaxInt32 {
+		return time.Now()
+	}
+
+	proc, err := process.NewProcess(int32(pid)) // #nosec G115 -- PID range checked above
+	if err != nil {
+		return time.Now()
+	}
+
+	created, err := proc.CreateTime()
+	if err != nil {
+		return time.Now()
+	}
+
+	return time.UnixMilli(created)
+}
+
+// isRunning reports whether a PID is alive and still belongs to the process that started at startedAt,
+// so a recycled PID is not mistaken for it
+func isRunning(pid int, startedAt time.Time) bool {
+	if pid <= 0 || pid > math.MaxInt32 {
+		return false
+	}
+
+	proc, err := process.NewProcess(int32(pid)) // #nosec G115 -- PID range checked above
+	if err != nil {
+		return false
+	}
+
+	created, err := proc.CreateTime()
+	if err != nil {
+		return true
+	}
+
+	return time.UnixMilli(created).Sub(startedAt).Abs() <= startTolerance
+}
@@ -0,0 +1,129 @@
+package session
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+	"fuku/internal/config"
+)
+
+func Test_Path(t *testing.T) {
+	state := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", state)
+
+	project := t.TempDir()
+	t.Chdir(project)
+
+	core, err := Path("core")
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(state, "fuku"), filepath.Dir(filepath.Dir(core)))
+	assert.Equal(t, "session-core.json", filepath.Base(core))
+
+	id, err := config.ProjectID()
+	require.NoError(t, err)
+	assert.Equal(t, id, filepath.Base(filepath.Dir(core)))
+
+	backend, err := Path("backend")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Dir(core), filepath.Dir(backend))
+
+	t.Chdir(t.TempDir())
+
+	other, err := Path("core")
+	require.NoError(t, err)
+	assert.NotEqual(t, filepath.Dir(core), filepath.Dir(other))
+
+	logs, err := LogPath("core")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(filepath.Dir(other), "fuku-core.log"), logs)
+}
+
+func Test_Path_DefaultsToLocalState(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("HOME", home)
+
+	path, err := Path("default")
+	require.NoError(t, err)
+
+	assert.True(t, filepath.HasPrefix(path, filepath.Join(home, ".local", "state", "fuku")))
+}
+
+func Test_Load(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	_, err := Load("default")
+	require.ErrorIs(t, err, errors.ErrSessionNotFound)
+
+	path, err := Path("default")
+	require.NoError(t, err)
+
+	started := time.Now().Truncate(time.Millisecond).UTC()
+	manifest := &Manifest{
+		Profile:   "default",
+		PID:       42,
+		Session:   "abc",
+		StartedAt: started,
+		Socket:    "/tmp/fuku-default.sock",
+		Services: map[string]Service{
+			"api": {ID: "id-api", Name: "api", Tier: "platform", PID: 100, PGID: 100, StartedAt: started, Command: []string{"make", "run"}},
+		},
+	}
+	require.NoError(t, write(path, manifest))
+
+	loaded, err := Load("default")
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Services, loaded.Services)
+	assert.True(t, manifest.StartedAt.Equal(loaded.StartedAt))
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err = Load("default")
+	require.ErrorIs(t, err, errors.ErrFailedToReadSession)
+
+	require.NoError(t, Discard("default"))
+	require.NoError(t, Discard("default"))
+	assert.NoFileExists(t, path)
+}
+
+func Test_Manifest_Running(t *testing.T) {
+	cmd := exec.Command("sleep", "5")
+	require.NoError(t, cmd.Start())
+
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	started := startTime(cmd.Process.Pid)
+
+	manifest := &Manifest{
+		PID:       os.Getpid(),
+		StartedAt: startTime(os.Getpid()),
+		Services: map[string]Service{
+			"web":      {Name: "web", PID: cmd.Process.Pid, StartedAt: started},
+			"api":      {Name: "api", PID: cmd.Process.Pid, StartedAt: started},
+			"recycled": {Name: "recycled", PID: cmd.Process.Pid, StartedAt: started.Add(-time.Hour)},
+			"gone":     {Name: "gone", PID: 0, StartedAt: started},
+		},
+	}
+
+	running := manifest.Running()
+	require.Len(t, running, 2)
+	assert.Equal(t, "api", running[0].Name)
+	assert.Equal(t, "web", running[1].Name)
+
+	assert.False(t, manifest.Stale())
+
+	manifest.StartedAt = manifest.StartedAt.Add(-time.Hour)
+	assert.True(t, manifest.Stale())
+}
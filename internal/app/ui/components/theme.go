@@ -45,6 +45,7 @@ type Theme struct {
 	TimelineStartingStyle lipgloss.Style
 	TimelineFailedStyle   lipgloss.Style
 	TimelineStoppedStyle  lipgloss.Style
+	TimelineHookStyle     lipgloss.Style
 	TimelineEmptyStyle    lipgloss.Style
 
 	// Timeline strip styles (selected row)
@@ -52,6 +53,7 @@ type Theme struct {
 	TimelineSelectedStartingStyle lipgloss.Style
 	TimelineSelectedFailedStyle   lipgloss.Style
 	TimelineSelectedStoppedStyle  lipgloss.Style
+	TimelineSelectedHookStyle     lipgloss.Style
 	TimelineSelectedEmptyStyle    lipgloss.Style
 
 	// Shared styles (TUI tips + logs banner)
@@ -72,6 +74,7 @@ func NewTheme(isDark bool) Theme {
 	fgStatusWarning := ld(lipgloss.Color("#d97706"), lipgloss.Color("11"))
 	fgStatusError := ld(lipgloss.Color("#dc2626"), lipgloss.Color("9"))
 	fgStatusStopped := fgBorder
+	fgHook := ld(lipgloss.Color("#0284c7"), lipgloss.Color("#38bdf8"))
 	bgSelection := ld(lipgloss.Color("#d4d4d4"), lipgloss.Color("235"))
 
 	return Theme{
@@ -112,12 +115,14 @@ func NewTheme(isDark bool) Theme {
 		TimelineStartingStyle: lipgloss.NewStyle().Foreground(fgStatusWarning),
 		TimelineFailedStyle:   lipgloss.NewStyle().Foreground(fgStatusError),
 		TimelineStoppedStyle:  lipgloss.NewStyle().Foreground(fgBorder),
+		TimelineHookStyle:     lipgloss.NewStyle().Foreground(fgHook),
 		TimelineEmptyStyle:    lipgloss.NewStyle().Foreground(ld(lipgloss.Color("#b8b8b8"), lipgloss.Color("#4a4a4a"))),
 
 		TimelineSelectedRunningStyle:  lipgloss.NewStyle().Foreground(fgStatusRunning).Background(bgSelection),
 		TimelineSelectedStartingStyle: lipgloss.NewStyle().Foreground(fgStatusWarning).Background(bgSelection),
 		TimelineSelectedFailedStyle:   lipgloss.NewStyle().Foreground(fgStatusError).Background(bgSelection),
 		TimelineSelectedStoppedStyle:  lipgloss.NewStyle().Foreground(fgBorder).Background(bgSelection),
+		TimelineSelectedHookStyle:     lipgloss.NewStyle().Foreground(fgHook).Background(bgSelection),
 		TimelineSelectedEmptyStyle:    lipgloss.NewStyle().Foreground(ld(lipgloss.Color("#b8b8b8"), lipgloss.Color("#4a4a4a"))).Background(bgSelection),
 	}
 }
@@ -44,6 +44,8 @@ func renderError(err error) string {
 		return "process exited"
 	case errors.Is(err, errors.ErrReadinessTimeout):
 		return "readiness timeout"
+	case errors.Is(err, errors.ErrTierTimeout):
+		return "tier timeout"
 	case errors.Is(err, errors.ErrFailedToStartCommand):
 		return "failed to start"
 	case errors.Is(err, errors.ErrServiceNotFound):
@@ -40,6 +40,11 @@ func Test_renderError(t *testing.T) {
 			err:      errors.ErrReadinessTimeout,
 			expected: "readiness timeout",
 		},
+		{
+			name:     "tier timeout",
+			err:      fmt.Errorf("%w: tier 'foundation' exceeded 1m0s", errors.ErrTierTimeout),
+			expected: "tier timeout",
+		},
 		{
 			name:     "failed to start command",
 			err:      errors.ErrFailedToStartCommand,
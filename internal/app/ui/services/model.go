@@ -32,12 +32,15 @@ type Status = registry.Status
 
 // Status values re-exported from registry for convenience
 const (
-	StatusStarting   = registry.StatusStarting
-	StatusRunning    = registry.StatusRunning
-	StatusStopping   = registry.StatusStopping
-	StatusRestarting = registry.StatusRestarting
-	StatusFailed     = registry.StatusFailed
-	StatusStopped    = registry.StatusStopped
+	StatusStarting    = registry.StatusStarting
+	StatusRunning     = registry.StatusRunning
+	StatusStopping    = registry.StatusStopping
+	StatusRestarting  = registry.StatusRestarting
+	StatusFailed      = registry.StatusFailed
+	StatusStopped     = registry.StatusStopped
+	StatusCrashLoop   = registry.StatusCrashLoop
+	StatusCompleted   = registry.StatusCompleted
+	StatusBuildFailed = registry.StatusBuildFailed
 )
 
 // Tier represents a tier in the UI
@@ -68,6 +71,7 @@ type ServiceState struct {
 	StartupActive    bool
 	WatchAt          time.Time
 	WatchSeq         uint64
+	Hook             string
 	Blink            *components.Blink
 	Timeline         *Timeline
 }
@@ -101,6 +105,7 @@ type Model struct {
 		selected     int
 		ready        bool
 		shuttingDown bool
+		detachable   bool
 		appCPU       float64
 		appMEM       float64
 		now          time.Time
@@ -185,6 +190,15 @@ func NewModel(
 	return m
 }
 
+// Detachable returns the model for a UI attached to an already running instance,
+// where quitting detaches and leaves the services running
+func (m Model) Detachable() Model {
+	m.state.detachable = true
+	m.ui.servicesKeys.Quit.SetHelp("q", "detach")
+
+	return m
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
@@ -382,7 +396,7 @@ func (m *Model) reconcileLifecycle(service *ServiceState, snap registry.ServiceS
 	case registry.StatusRunning:
 		backfillStartupHistory(service, snap.LifecycleSeq, snap.AttemptStartedAt, snap.LifecycleAt)
 		service.StartupActive = false
-	case registry.StatusFailed, registry.StatusStopped:
+	case registry.StatusFailed, registry.StatusStopped, registry.StatusCrashLoop, registry.StatusCompleted:
 		switch {
 		case !snap.AttemptStartedAt.IsZero() && snap.AttemptStartedAt != service.AttemptStartedAt:
 			backfillStartupHistory(service, snap.LifecycleSeq, snap.AttemptStartedAt, snap.LifecycleAt)
@@ -430,7 +444,7 @@ func (m *Model) reconcileLifecycle(service *ServiceState, snap registry.ServiceS
 func (m *Model) reconcileLifecycleEffects(service *ServiceState, status registry.Status) {
 	//nolint:exhaustive // only terminal and restarting states need effect reconciliation
 	switch status {
-	case registry.StatusRunning, registry.StatusFailed:
+	case registry.StatusRunning, registry.StatusFailed, registry.StatusCrashLoop, registry.StatusCompleted, registry.StatusBuildFailed:
 		delete(m.state.restarting, service.ID)
 		m.loader.Stop(service.ID)
 	case registry.StatusStopped:
@@ -47,7 +47,7 @@ func (m *Model) updateBlinkAnimations() bool {
 
 // getUptime returns formatted uptime string for a service
 func (m *Model) getUptime(service *ServiceState) string {
-	if service.Status.IsStartable() || service.StartTime.IsZero() || m.state.now.IsZero() {
+	if (service.Status.IsStartable() && !m.isServiceMonitored(service)) || service.StartTime.IsZero() || m.state.now.IsZero() {
 		return ""
 	}
 
@@ -104,9 +104,9 @@ func (m *Model) getPID(service *ServiceState) string {
 	return ""
 }
 
-// isServiceMonitored returns true if service has valid monitoring data
+// isServiceMonitored returns true if service has valid monitoring data, which a process kept after a failed rebuild still has
 func (m *Model) isServiceMonitored(service *ServiceState) bool {
-	return service.Status == StatusRunning && service.PID != 0
+	return (service.Status == StatusRunning || service.Status == StatusBuildFailed) && service.PID != 0
 }
 
 // sampleAppStatsCmd returns a command that samples fuku process stats off the UI thread
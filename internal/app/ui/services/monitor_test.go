@@ -186,6 +186,11 @@ func Test_GetPID(t *testing.T) {
 			service: &ServiceState{Status: StatusRunning, PID: 1234},
 			want:    "1234",
 		},
+		{
+			name:    "build-failed keeps the previous process",
+			service: &ServiceState{Status: StatusBuildFailed, PID: 1234},
+			want:    "1234",
+		},
 		{
 			name:    "large PID",
 			service: &ServiceState{Status: StatusRunning, PID: 99999},
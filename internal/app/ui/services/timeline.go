@@ -12,6 +12,7 @@ const (
 	SlotStarting                     // Service is starting, restarting, or stopping
 	SlotFailed                       // Service has failed
 	SlotStopped                      // Service is stopped
+	SlotHook                         // A lifecycle hook of the service is running
 )
 
 // Timeline is a fixed-capacity ring buffer that records per-second service state samples
@@ -103,13 +104,21 @@ func (m *Model) sampleTimelines() {
 		}
 
 		if service.StartTime.IsZero() && service.Timeline.Count() == 0 &&
+			service.Hook == "" &&
 			service.Status != StatusFailed &&
+			service.Status != StatusCrashLoop &&
+			service.Status != StatusBuildFailed &&
 			service.Status != StatusStopped &&
+			service.Status != StatusCompleted &&
 			service.Status != StatusRestarting {
 			continue
 		}
 
 		slot := StatusToSlot(service.Status)
+		if service.Hook != "" {
+			slot = SlotHook
+		}
+
 		service.Timeline.Append(slot)
 
 		if slot == SlotStarting {
@@ -125,9 +134,9 @@ func StatusToSlot(status Status) TimelineSlot {
 		return SlotRunning
 	case StatusStarting, StatusRestarting, StatusStopping:
 		return SlotStarting
-	case StatusFailed:
+	case StatusFailed, StatusCrashLoop, StatusBuildFailed:
 		return SlotFailed
-	case StatusStopped:
+	case StatusStopped, StatusCompleted:
 		return SlotStopped
 	default:
 		return SlotEmpty
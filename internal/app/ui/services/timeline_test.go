@@ -177,11 +177,26 @@ func Test_StatusToSlot(t *testing.T) {
 			status: StatusFailed,
 			want:   SlotFailed,
 		},
+		{
+			name:   "crash-loop maps to SlotFailed",
+			status: StatusCrashLoop,
+			want:   SlotFailed,
+		},
+		{
+			name:   "build-failed maps to SlotFailed",
+			status: StatusBuildFailed,
+			want:   SlotFailed,
+		},
 		{
 			name:   "stopped maps to SlotStopped",
 			status: StatusStopped,
 			want:   SlotStopped,
 		},
+		{
+			name:   "completed maps to SlotStopped",
+			status: StatusCompleted,
+			want:   SlotStopped,
+		},
 		{
 			name:   "unknown status maps to SlotEmpty",
 			status: "unknown",
@@ -372,3 +387,20 @@ func Test_SampleTimelines_StartingWithZeroStartTimeSkipped(t *testing.T) {
 
 	assert.Equal(t, 0, tl.Count())
 }
+
+func Test_SampleTimelines_RunningHook(t *testing.T) {
+	tl := NewTimeline(20)
+	m := &Model{}
+	m.state.services = map[string]*ServiceState{
+		"svc": {
+			Status:   StatusStarting,
+			Hook:     "before_start",
+			Timeline: tl,
+		},
+	}
+
+	m.sampleTimelines()
+
+	assert.Equal(t, 1, tl.Count())
+	assert.Equal(t, SlotHook, tl.Slots()[0])
+}
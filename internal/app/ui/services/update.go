@@ -10,6 +10,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 
 	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
 	"fuku/internal/app/ui/components"
 )
 
@@ -136,6 +137,12 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	}
 
 	switch {
+	case key.Matches(msg, m.ui.servicesKeys.Quit) && m.state.detachable:
+		m.log.Info().Msg("TUI: Detaching, services keep running")
+		m.loader.StopAll()
+
+		return m, tea.Quit
+
 	case key.Matches(msg, m.ui.servicesKeys.Quit):
 		m.state.shuttingDown = true
 		m.loader.Start(loaderKeyShutdown, "shutting down all services…")
@@ -347,10 +354,22 @@ func (m Model) handleStopKey() (tea.Model, tea.Cmd) {
 	svc := bus.Service{ID: service.ID, Name: service.Name}
 
 	switch service.Status {
-	case StatusStopped, StatusFailed:
+	case StatusStopped, StatusFailed, StatusCrashLoop, StatusCompleted:
 		m.controller.Start(svc)
 		m.loader.Start(service.ID, fmt.Sprintf("starting %s…", service.Name))
 
+		return m, m.loader.Model.Tick
+	case StatusBuildFailed:
+		if service.PID == 0 {
+			m.controller.Start(svc)
+			m.loader.Start(service.ID, fmt.Sprintf("starting %s…", service.Name))
+
+			return m, m.loader.Model.Tick
+		}
+
+		m.controller.Stop(svc)
+		m.loader.Start(service.ID, fmt.Sprintf("stopping %s…", service.Name))
+
 		return m, m.loader.Model.Tick
 	case StatusRunning:
 		m.controller.Stop(svc)
@@ -370,7 +389,7 @@ func (m Model) handleRestartKey() (tea.Model, tea.Cmd) {
 	}
 
 	switch service.Status {
-	case StatusRunning, StatusFailed, StatusStopped:
+	case StatusRunning, StatusFailed, StatusStopped, StatusCrashLoop, StatusCompleted, StatusBuildFailed:
 		m.controller.Restart(bus.Service{ID: service.ID, Name: service.Name})
 		m.loader.Start(service.ID, fmt.Sprintf("restarting %s…", service.Name))
 
@@ -386,7 +405,7 @@ func (m Model) handleRestartFailedKey() (tea.Model, tea.Cmd) {
 
 	for _, id := range m.state.serviceIDs {
 		svc := m.state.services[id]
-		if svc == nil || svc.Status != StatusFailed {
+		if svc == nil || (svc.Status != StatusFailed && svc.Status != StatusCrashLoop && svc.Status != StatusBuildFailed) {
 			continue
 		}
 
@@ -419,6 +438,12 @@ func (m Model) handleMessage(msg bus.Message) (tea.Model, tea.Cmd) {
 		m = m.handleServiceStarting(msg)
 	case bus.EventServiceReady:
 		m = m.handleServiceReady(msg)
+	case bus.EventServiceCompleted:
+		m = m.handleServiceCompleted(msg)
+	case bus.EventServiceBuilding:
+		m = m.handleServiceBuilding(msg)
+	case bus.EventServiceBuildFailed:
+		m = m.handleServiceBuildFailed(msg)
 	case bus.EventServiceFailed:
 		m = m.handleServiceFailed(msg)
 	case bus.EventServiceStopping:
@@ -427,6 +452,14 @@ func (m Model) handleMessage(msg bus.Message) (tea.Model, tea.Cmd) {
 		m = m.handleServiceStopped(msg)
 	case bus.EventServiceRestarting:
 		m = m.handleServiceRestarting(msg)
+	case bus.EventServiceBackoff:
+		m = m.handleServiceBackoff(msg)
+	case bus.EventServiceCrashLoop:
+		m = m.handleServiceCrashLoop(msg)
+	case bus.EventHookStarted:
+		m = m.handleHookStarted(msg)
+	case bus.EventHookFinished:
+		m = m.handleHookFinished(msg)
 	case bus.EventWatchStarted:
 		m = m.handleWatchStarted(msg)
 	case bus.EventWatchStopped:
@@ -574,7 +607,10 @@ func (m Model) handleServiceStarting(msg bus.Message) Model {
 
 	delete(m.state.restarting, data.Service.ID)
 
-	if !m.loader.Has(data.Service.ID) {
+	switch {
+	case data.Attempt > 1:
+		m.loader.Start(data.Service.ID, fmt.Sprintf("starting %s (attempt %d/%d)…", data.Service.Name, data.Attempt, data.MaxAttempts))
+	case !m.loader.Has(data.Service.ID):
 		m.loader.Start(data.Service.ID, fmt.Sprintf("starting %s…", data.Service.Name))
 	}
 
@@ -624,6 +660,81 @@ func (m Model) handleServiceReady(msg bus.Message) Model {
 	return m
 }
 
+// handleServiceCompleted updates a job service when it exits successfully
+func (m Model) handleServiceCompleted(msg bus.Message) Model {
+	data, ok := msg.Data.(bus.ServiceCompleted)
+	if !ok {
+		return m
+	}
+
+	service, exists := m.state.services[data.Service.ID]
+	if !exists || msg.Seq < service.LifecycleSeq {
+		return m
+	}
+
+	if service.StartupActive {
+		backfillStartupHistory(service, msg.Seq, data.StartedAt, msg.Timestamp)
+	}
+
+	service.LifecycleSeq = msg.Seq
+	service.LifecycleAt = msg.Timestamp
+	service.Status = StatusCompleted
+	service.StartupActive = false
+	service.Tier = data.Tier
+	service.PID = 0
+	service.StartTime = time.Time{}
+	service.AttemptStartedAt = data.StartedAt
+	service.CPU = 0
+	service.MEM = 0
+	service.Error = nil
+
+	delete(m.state.restarting, data.Service.ID)
+	m.loader.Stop(data.Service.ID)
+
+	return m
+}
+
+// handleServiceBuilding shows a build in progress; the service keeps its status while the previous process serves
+func (m Model) handleServiceBuilding(msg bus.Message) Model {
+	data, ok := msg.Data.(bus.ServiceBuilding)
+	if !ok {
+		return m
+	}
+
+	if _, exists := m.state.services[data.Service.ID]; !exists {
+		return m
+	}
+
+	m.loader.Start(data.Service.ID, fmt.Sprintf("building %s…", data.Service.Name))
+
+	return m
+}
+
+// handleServiceBuildFailed marks a service whose build failed, showing the compiler error in place of its metrics
+// unless the previous process is still running
+func (m Model) handleServiceBuildFailed(msg bus.Message) Model {
+	data, ok := msg.Data.(bus.ServiceBuildFailed)
+	if !ok {
+		return m
+	}
+
+	service, exists := m.state.services[data.Service.ID]
+	if !exists || msg.Seq < service.LifecycleSeq {
+		return m
+	}
+
+	service.LifecycleSeq = msg.Seq
+	service.LifecycleAt = msg.Timestamp
+	service.Status = StatusBuildFailed
+	service.StartupActive = false
+	service.Error = data.Error
+
+	delete(m.state.restarting, data.Service.ID)
+	m.loader.Stop(data.Service.ID)
+
+	return m
+}
+
 // handleServiceFailed updates a service when it fails
 func (m Model) handleServiceFailed(msg bus.Message) Model {
 	data, ok := msg.Data.(bus.ServiceFailed)
@@ -692,6 +803,83 @@ func (m Model) handleServiceRestarting(msg bus.Message) Model {
 	return m
 }
 
+// handleServiceBackoff shows a crashed service waiting to be restarted automatically
+func (m Model) handleServiceBackoff(msg bus.Message) Model {
+	data, ok := msg.Data.(bus.ServiceBackoff)
+	if !ok {
+		return m
+	}
+
+	service, exists := m.state.services[data.Service.ID]
+	if !exists || msg.Seq < service.LifecycleSeq {
+		return m
+	}
+
+	service.LifecycleSeq = msg.Seq
+	service.LifecycleAt = msg.Timestamp
+	service.Status = StatusRestarting
+	service.StartTime = time.Time{}
+	service.PID = 0
+	service.Error = nil
+	m.state.restarting[data.Service.ID] = true
+	m.loader.Start(data.Service.ID, fmt.Sprintf("restarting %s in %s (restart %d)…", data.Service.Name, data.Delay.Round(time.Millisecond), data.Restarts))
+
+	return m
+}
+
+// handleServiceCrashLoop marks a service that kept crashing and is no longer restarted
+func (m Model) handleServiceCrashLoop(msg bus.Message) Model {
+	data, ok := msg.Data.(bus.ServiceCrashLoop)
+	if !ok {
+		return m
+	}
+
+	service, exists := m.state.services[data.Service.ID]
+	if !exists || msg.Seq < service.LifecycleSeq {
+		return m
+	}
+
+	service.LifecycleSeq = msg.Seq
+	service.LifecycleAt = msg.Timestamp
+	service.Status = StatusCrashLoop
+	service.StartTime = time.Time{}
+	service.PID = 0
+	service.Error = fmt.Errorf("%w: exited with code %d after %d restarts", errors.ErrCrashLoop, data.ExitCode, data.Restarts)
+
+	m.loader.Stop(data.Service.ID)
+	delete(m.state.restarting, data.Service.ID)
+
+	return m
+}
+
+// handleHookStarted marks a service whose lifecycle hook is running
+func (m Model) handleHookStarted(msg bus.Message) Model {
+	data, ok := msg.Data.(bus.HookStarted)
+	if !ok {
+		return m
+	}
+
+	if service, exists := m.state.services[data.Service.ID]; exists {
+		service.Hook = data.Hook
+	}
+
+	return m
+}
+
+// handleHookFinished clears the running hook of a service
+func (m Model) handleHookFinished(msg bus.Message) Model {
+	data, ok := msg.Data.(bus.HookFinished)
+	if !ok {
+		return m
+	}
+
+	if service, exists := m.state.services[data.Service.ID]; exists && service.Hook == data.Hook {
+		service.Hook = ""
+	}
+
+	return m
+}
+
 // handleServiceStopped stops the loader unless service is restarting
 func (m Model) handleServiceStopped(msg bus.Message) Model {
 	data, ok := msg.Data.(bus.ServiceStopped)
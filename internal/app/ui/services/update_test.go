@@ -14,6 +14,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"fuku/internal/app/bus"
+	"fuku/internal/app/errors"
 	"fuku/internal/app/registry"
 	"fuku/internal/app/ui/components"
 	"fuku/internal/config/logger"
@@ -411,6 +412,33 @@ func Test_HandleServiceStarting_DoesNotDuplicateLoader(t *testing.T) {
 	assert.Equal(t, 1, count, "Loader should not have duplicate entries for the same service")
 }
 
+func Test_HandleServiceStarting_ShowsRetryAttempt(t *testing.T) {
+	loader := &Loader{Model: spinner.New(), queue: make([]LoaderItem, 0)}
+	loader.Start("test-id-api", "starting api…")
+
+	service := &ServiceState{
+		Name:   "api",
+		Status: StatusStarting,
+		Blink:  components.NewBlink(),
+	}
+
+	m := Model{loader: loader}
+	m.state.services = map[string]*ServiceState{"test-id-api": service}
+	m.state.restarting = make(map[string]bool)
+
+	event := bus.Message{
+		Timestamp: time.Now(),
+		Seq:       1,
+		Type:      bus.EventServiceStarting,
+		Data:      bus.ServiceStarting{ServiceEvent: bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}, Tier: "tier1"}, PID: 1234, Attempt: 2, MaxAttempts: 3},
+	}
+
+	result := m.handleServiceStarting(event)
+
+	require.Len(t, result.loader.queue, 1)
+	assert.Equal(t, "starting api (attempt 2/3)…", result.loader.queue[0].Message)
+}
+
 func Test_HandleServiceReady(t *testing.T) {
 	loader := &Loader{Model: spinner.New(), queue: make([]LoaderItem, 0)}
 	loader.Start("test-id-api", "starting api...")
@@ -626,6 +654,209 @@ func Test_HandleServiceRestarting_InvalidData(t *testing.T) {
 	assert.Empty(t, result.state.restarting)
 }
 
+func Test_HandleServiceBackoff(t *testing.T) {
+	loader := &Loader{Model: spinner.New(), queue: make([]LoaderItem, 0)}
+	service := &ServiceState{
+		Name:   "api",
+		Status: StatusRunning,
+		PID:    1234,
+		Blink:  components.NewBlink(),
+	}
+
+	m := Model{loader: loader}
+	m.state.services = map[string]*ServiceState{"test-id-api": service}
+	m.state.restarting = make(map[string]bool)
+
+	event := bus.Message{
+		Seq:  1,
+		Type: bus.EventServiceBackoff,
+		Data: bus.ServiceBackoff{ServiceEvent: bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}}, ExitCode: 1, Restarts: 2, Delay: 2 * time.Second},
+	}
+
+	result := m.handleServiceBackoff(event)
+
+	assert.Equal(t, StatusRestarting, result.state.services["test-id-api"].Status)
+	assert.Equal(t, 0, result.state.services["test-id-api"].PID)
+	assert.True(t, result.state.restarting["test-id-api"])
+	assert.Equal(t, "restarting api in 2s (restart 2)…", result.loader.Message())
+}
+
+func Test_HandleServiceCompleted(t *testing.T) {
+	loader := &Loader{Model: spinner.New(), queue: make([]LoaderItem, 0)}
+	loader.Start("test-id-migrate", "restarting migrate…")
+
+	startedAt := time.Now().Add(-time.Second)
+	service := &ServiceState{
+		Name:      "migrate",
+		Status:    StatusStarting,
+		PID:       1234,
+		StartTime: startedAt,
+		Error:     errors.ErrJobFailed,
+		Blink:     components.NewBlink(),
+	}
+
+	m := Model{loader: loader}
+	m.state.services = map[string]*ServiceState{"test-id-migrate": service}
+	m.state.restarting = map[string]bool{"test-id-migrate": true}
+
+	event := bus.Message{
+		Seq:  1,
+		Type: bus.EventServiceCompleted,
+		Data: bus.ServiceCompleted{ServiceEvent: bus.ServiceEvent{Service: bus.Service{ID: "test-id-migrate", Name: "migrate"}, Tier: "foundation"}, StartedAt: startedAt, Duration: time.Second},
+	}
+
+	result := m.handleServiceCompleted(event)
+
+	svc := result.state.services["test-id-migrate"]
+	assert.Equal(t, StatusCompleted, svc.Status)
+	assert.Equal(t, 0, svc.PID)
+	assert.True(t, svc.StartTime.IsZero())
+	assert.NoError(t, svc.Error)
+	assert.False(t, result.state.restarting["test-id-migrate"])
+	assert.False(t, result.loader.Has("test-id-migrate"))
+}
+
+func Test_HandleServiceBuilding(t *testing.T) {
+	loader := &Loader{Model: spinner.New(), queue: make([]LoaderItem, 0)}
+
+	m := Model{loader: loader}
+	m.state.services = map[string]*ServiceState{"test-id-api": {Name: "api", Status: StatusRunning, PID: 1234}}
+
+	event := bus.Message{
+		Seq:  1,
+		Type: bus.EventServiceBuilding,
+		Data: bus.ServiceBuilding{ServiceEvent: bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}}, Command: "go build"},
+	}
+
+	result := m.handleServiceBuilding(event)
+
+	assert.Equal(t, StatusRunning, result.state.services["test-id-api"].Status)
+	assert.Equal(t, "building api…", result.loader.Message())
+}
+
+func Test_HandleServiceBuildFailed(t *testing.T) {
+	loader := &Loader{Model: spinner.New(), queue: make([]LoaderItem, 0)}
+	loader.Start("test-id-api", "building api…")
+
+	service := &ServiceState{
+		Name:   "api",
+		Status: StatusRunning,
+		PID:    1234,
+		Blink:  components.NewBlink(),
+	}
+
+	m := Model{loader: loader}
+	m.state.services = map[string]*ServiceState{"test-id-api": service}
+	m.state.restarting = map[string]bool{"test-id-api": true}
+
+	buildErr := errors.New("build failed: ./main.go:12:2: undefined: handler")
+	event := bus.Message{
+		Seq:  1,
+		Type: bus.EventServiceBuildFailed,
+		Data: bus.ServiceBuildFailed{ServiceEvent: bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}}, Output: "./main.go:12:2: undefined: handler\n", Error: buildErr},
+	}
+
+	result := m.handleServiceBuildFailed(event)
+
+	svc := result.state.services["test-id-api"]
+	assert.Equal(t, StatusBuildFailed, svc.Status)
+	assert.Equal(t, 1234, svc.PID)
+	assert.Equal(t, "build failed: ./main.go:12:2: undefined: handler", renderError(svc.Error))
+	assert.False(t, result.state.restarting["test-id-api"])
+	assert.False(t, result.loader.Has("test-id-api"))
+}
+
+func Test_HandleStopKey_BuildFailed(t *testing.T) {
+	tests := []struct {
+		name    string
+		pid     int
+		start   bool
+		message string
+	}{
+		{name: "previous process still running is stopped", pid: 1234, message: "stopping api…"},
+		{name: "nothing running is started", pid: 0, start: true, message: "starting api…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := bus.Service{ID: "id-api", Name: "api"}
+
+			mockController := NewMockController(ctrl)
+			if tt.start {
+				mockController.EXPECT().Start(svc)
+			} else {
+				mockController.EXPECT().Stop(svc)
+			}
+
+			loader := &Loader{Model: spinner.New(), queue: make([]LoaderItem, 0)}
+
+			m := Model{loader: loader, controller: mockController}
+			m.state.serviceIDs = []string{"id-api"}
+			m.state.services = map[string]*ServiceState{
+				"id-api": {ID: "id-api", Name: "api", Status: StatusBuildFailed, PID: tt.pid},
+			}
+			m.state.tiers = []Tier{{Name: "platform", Services: []string{"id-api"}}}
+			m.state.selected = 0
+
+			teaModel, cmd := m.handleStopKey()
+			result := teaModel.(Model)
+
+			assert.Equal(t, tt.message, result.loader.Message())
+			assert.NotNil(t, cmd)
+		})
+	}
+}
+
+func Test_HandleServiceCrashLoop(t *testing.T) {
+	loader := &Loader{Model: spinner.New(), queue: make([]LoaderItem, 0)}
+	loader.Start("test-id-api", "restarting api…")
+
+	service := &ServiceState{
+		Name:   "api",
+		Status: StatusRestarting,
+		Blink:  components.NewBlink(),
+	}
+
+	m := Model{loader: loader}
+	m.state.services = map[string]*ServiceState{"test-id-api": service}
+	m.state.restarting = map[string]bool{"test-id-api": true}
+
+	event := bus.Message{
+		Seq:  1,
+		Type: bus.EventServiceCrashLoop,
+		Data: bus.ServiceCrashLoop{ServiceEvent: bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}}, ExitCode: 1, Restarts: 5},
+	}
+
+	result := m.handleServiceCrashLoop(event)
+
+	assert.Equal(t, StatusCrashLoop, result.state.services["test-id-api"].Status)
+	require.ErrorIs(t, result.state.services["test-id-api"].Error, errors.ErrCrashLoop)
+	assert.Equal(t, "crash loop: exited with code 1 after 5 restarts", result.state.services["test-id-api"].Error.Error())
+	assert.False(t, result.state.restarting["test-id-api"])
+	assert.False(t, result.loader.Has("test-id-api"))
+}
+
+func Test_HandleHookStartedAndFinished(t *testing.T) {
+	service := &ServiceState{Name: "api", Status: StatusStarting}
+
+	m := Model{}
+	m.state.services = map[string]*ServiceState{"test-id-api": service}
+
+	event := bus.ServiceEvent{Service: bus.Service{ID: "test-id-api", Name: "api"}}
+
+	m = m.handleHookStarted(bus.Message{Type: bus.EventHookStarted, Data: bus.HookStarted{ServiceEvent: event, Hook: "before_start", Command: "make migrate"}})
+	assert.Equal(t, "before_start", service.Hook)
+
+	m = m.handleHookFinished(bus.Message{Type: bus.EventHookFinished, Data: bus.HookFinished{ServiceEvent: event, Hook: "after_ready"}})
+	assert.Equal(t, "before_start", service.Hook)
+
+	m.handleHookFinished(bus.Message{Type: bus.EventHookFinished, Data: bus.HookFinished{ServiceEvent: event, Hook: "before_start", Error: assert.AnError}})
+	assert.Empty(t, service.Hook)
+}
+
 func Test_HandleServiceStopped(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -896,6 +1127,30 @@ func Test_HandleKeyPress_QuitStartsGracefulShutdown(t *testing.T) {
 	assert.NotNil(t, cmd)
 }
 
+func Test_HandleKeyPress_QuitDetachesFromRunningInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Info().Return(nil)
+
+	mockController := NewMockController(ctrl)
+	loader := &Loader{Model: spinner.New(), queue: make([]LoaderItem, 0)}
+
+	m := Model{loader: loader, controller: mockController, log: mockLogger}
+	m.ui.servicesKeys = DefaultKeyMap()
+	m = m.Detachable()
+
+	assert.Equal(t, "detach", m.ui.servicesKeys.Quit.Help().Desc)
+
+	teaModel, cmd := m.handleKeyPress(toKeyMsg("q"))
+	result := teaModel.(Model)
+
+	assert.False(t, result.state.shuttingDown)
+	assert.False(t, result.loader.Active)
+	assert.NotNil(t, cmd)
+}
+
 func Test_HandleKeyPress_CtrlCForcesImmediateQuit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -3037,6 +3292,19 @@ func Test_HandleRestartFailedKey(t *testing.T) {
 			expectLoader: nil,
 			expectCmd:    false,
 		},
+		{
+			name:       "build-failed services are restarted",
+			serviceIDs: []string{"id-api", "id-web"},
+			services: map[string]*ServiceState{
+				"id-api": {ID: "id-api", Name: "api", Status: StatusBuildFailed},
+				"id-web": {ID: "id-web", Name: "web", Status: StatusRunning},
+			},
+			expectCalls: []bus.Service{
+				{ID: "id-api", Name: "api"},
+			},
+			expectLoader: []string{"id-api"},
+			expectCmd:    true,
+		},
 		{
 			name:       "mixed states - only failed restarted",
 			serviceIDs: []string{"id-api", "id-web", "id-db", "id-cache"},
@@ -319,6 +319,8 @@ func (m Model) timelineSlotStyle(slot TimelineSlot, isSelected bool) lipgloss.St
 			return m.theme.TimelineSelectedFailedStyle
 		case SlotStopped:
 			return m.theme.TimelineSelectedStoppedStyle
+		case SlotHook:
+			return m.theme.TimelineSelectedHookStyle
 		default:
 			return m.theme.TimelineSelectedEmptyStyle
 		}
@@ -333,6 +335,8 @@ func (m Model) timelineSlotStyle(slot TimelineSlot, isSelected bool) lipgloss.St
 		return m.theme.TimelineFailedStyle
 	case SlotStopped:
 		return m.theme.TimelineStoppedStyle
+	case SlotHook:
+		return m.theme.TimelineHookStyle
 	default:
 		return m.theme.TimelineEmptyStyle
 	}
@@ -362,7 +366,7 @@ func (m Model) renderServiceRow(service *ServiceState, isSelected bool) string {
 		timeline:   timelineCol,
 		status:     statusCol,
 		details:    details,
-		hasError:   service.Error != nil,
+		hasError:   m.showsError(service),
 		isSelected: isSelected,
 	}, rowWidth)
 
@@ -398,9 +402,14 @@ func (m Model) buildServiceRow(parts rowParts, rowWidth int) string {
 	return parts.name + leftFlex + parts.timeline + tail
 }
 
+// showsError returns true if the error replaces the metrics; a process kept after a failed rebuild keeps its metrics
+func (m Model) showsError(service *ServiceState) bool {
+	return service.Error != nil && !m.isServiceMonitored(service)
+}
+
 // getServiceDetails returns either error message or metrics columns
 func (m Model) getServiceDetails(service *ServiceState, isSelected bool) string {
-	if service.Error != nil {
+	if m.showsError(service) {
 		errorMsg := fmt.Sprintf("%s%s", components.ErrorPadding, renderError(service.Error))
 		if !isSelected {
 			return m.theme.ErrorStyle.Render(errorMsg)
@@ -455,11 +464,11 @@ func (m Model) getStyledAndPaddedStatus(service *ServiceState, isSelected bool)
 	var styledStatus string
 
 	switch service.Status {
-	case StatusRunning:
+	case StatusRunning, StatusCompleted:
 		styledStatus = m.theme.StatusRunningStyle.Render(statusStr)
 	case StatusStarting:
 		styledStatus = m.theme.StatusStartingStyle.Render(statusStr)
-	case StatusFailed:
+	case StatusFailed, StatusCrashLoop, StatusBuildFailed:
 		styledStatus = m.theme.StatusFailedStyle.Render(statusStr)
 	case StatusStopped:
 		styledStatus = m.theme.StatusStoppedStyle.Render(statusStr)
@@ -1241,13 +1241,14 @@ func Test_UpdateServicesContent_UsesFilteredTiers(t *testing.T) {
 func Test_RenderTimeline_AllSlotTypes(t *testing.T) {
 	theme := components.DefaultTheme()
 	m := Model{theme: theme}
-	m.ui.layout = components.TableLayout{TimelineWidth: 5}
+	m.ui.layout = components.TableLayout{TimelineWidth: 6}
 
-	tl := NewTimeline(5)
+	tl := NewTimeline(6)
 	tl.Append(SlotRunning)
 	tl.Append(SlotStarting)
 	tl.Append(SlotFailed)
 	tl.Append(SlotStopped)
+	tl.Append(SlotHook)
 	tl.Append(SlotEmpty)
 
 	service := &ServiceState{Timeline: tl}
@@ -1258,6 +1259,7 @@ func Test_RenderTimeline_AllSlotTypes(t *testing.T) {
 	assert.Contains(t, result, theme.TimelineStartingStyle.Render(components.TimelineBlock))
 	assert.Contains(t, result, theme.TimelineFailedStyle.Render(components.TimelineBlock))
 	assert.Contains(t, result, theme.TimelineStoppedStyle.Render(components.TimelineBlock))
+	assert.Contains(t, result, theme.TimelineHookStyle.Render(components.TimelineBlock))
 	assert.Contains(t, result, theme.TimelineEmptyStyle.Render(components.TimelineBlock))
 }
 
@@ -77,12 +77,21 @@ func (m *manager) Start(ctx context.Context) {
 
 // handleServiceEvent processes bus messages to start/stop watching
 func (m *manager) handleServiceEvent(ctx context.Context, msg bus.Message) {
-	//nolint:exhaustive // only handling service ready/stopped events
+	//nolint:exhaustive // only handling service ready/completed/build-failed/stopped events
 	switch msg.Type {
 	case bus.EventServiceReady:
 		if data, ok := msg.Data.(bus.ServiceReady); ok {
 			m.startWatching(ctx, bus.Service{ID: data.Service.ID, Name: data.Service.Name})
 		}
+	case bus.EventServiceCompleted:
+		if data, ok := msg.Data.(bus.ServiceCompleted); ok {
+			m.startWatching(ctx, bus.Service{ID: data.Service.ID, Name: data.Service.Name})
+		}
+	case bus.EventServiceBuildFailed:
+		// Watch after a failed first build too, so fixing the code triggers the next build
+		if data, ok := msg.Data.(bus.ServiceBuildFailed); ok {
+			m.startWatching(ctx, bus.Service{ID: data.Service.ID, Name: data.Service.Name})
+		}
 	case bus.EventServiceStopped:
 		if data, ok := msg.Data.(bus.ServiceStopped); ok {
 			m.stopWatching(data.Service.ID)
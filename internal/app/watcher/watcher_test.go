@@ -91,6 +91,59 @@ func Test_Watcher_StartsWatchingOnServiceReady(t *testing.T) {
 	assert.True(t, exists, "watcher should be registered for service")
 }
 
+func Test_Watcher_StartsWatchingOnJobCompleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := logger.NewMockLogger(ctrl)
+	componentLog := logger.NewMockLogger(ctrl)
+	mockLog.EXPECT().WithComponent(gomock.Any()).Return(componentLog).AnyTimes()
+	componentLog.EXPECT().Info().Return(nil).AnyTimes()
+	componentLog.EXPECT().Warn().Return(nil).AnyTimes()
+	componentLog.EXPECT().Error().Return(nil).AnyTimes()
+
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{
+		Services: map[string]*config.Service{
+			"test-service": {
+				Dir:  tmpDir,
+				Type: config.ServiceTypeJob,
+				Watch: &config.Watch{
+					Include: []string{"*.go", "**/*.go"},
+				},
+			},
+		},
+	}
+	cfg.Logs.Buffer = 10
+
+	b := bus.NewBus(cfg, bus.NewFormatter(logger.NewEventLogger()), nil)
+	defer b.Close()
+
+	w, err := NewWatcher(cfg, b, mockLog)
+	require.NoError(t, err)
+
+	defer w.Close()
+
+	eventCh := b.Subscribe(t.Context())
+	w.Start(t.Context())
+
+	b.Publish(bus.Message{
+		Type: bus.EventServiceCompleted,
+		Data: bus.ServiceCompleted{ServiceEvent: bus.ServiceEvent{Service: bus.Service{ID: "test-id-svc", Name: "test-service"}, Tier: "default"}},
+	})
+
+	// Wait for WatchStarted event instead of sleeping
+	waitForEvent(t, eventCh, bus.EventWatchStarted)
+
+	m := w.(*manager)
+	m.mu.RLock()
+	_, exists := m.watchers["test-id-svc"]
+	m.mu.RUnlock()
+
+	assert.True(t, exists, "watcher should be registered for service")
+}
+
 func Test_Watcher_StopsWatchingOnServiceStopped(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
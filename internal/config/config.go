@@ -1,24 +1,35 @@
 package config
 
 import (
+	"net"
+	"net/url"
 	"strings"
 	"time"
 )
 
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+	portHTTP    = "80"
+	portHTTPS   = "443"
+)
+
 // Config represents the application configuration
 type Config struct {
-	AppEnv      string
-	SentryDSN   string
-	Telemetry   bool
+	AppEnv      string              `yaml:"-"`
+	SentryDSN   string              `yaml:"-"`
+	Telemetry   bool                `yaml:"-"`
 	Services    map[string]*Service `yaml:"services"`
-	Defaults    *ServiceDefaults    `yaml:"defaults"`
+	Defaults    *ServiceDefaults    `yaml:"defaults,omitempty"`
+	Tiers       []Tier              `yaml:"tiers,omitempty" mapstructure:"-"`
 	Profiles    map[string]any      `yaml:"profiles"`
 	Logging     Logging             `yaml:"logging"`
 	Concurrency Concurrency         `yaml:"concurrency"`
 	Retry       Retry               `yaml:"retry"`
 	Logs        LogStream           `yaml:"logs"`
-	Server      Server              `yaml:"server"`
-	Version     int
+	Server      Server              `yaml:"server,omitempty"`
+	Preflight   Preflight           `yaml:"preflight,omitempty"`
+	Version     int                 `yaml:"version"`
 }
 
 // DefaultConfig returns the default configuration
@@ -36,6 +47,8 @@ func DefaultConfig() *Config {
 
 	cfg.Retry.Attempts = RetryAttempts
 	cfg.Retry.Backoff = RetryBackoff
+	cfg.Retry.MaxBackoff = RetryMaxBackoff
+	cfg.Retry.Multiplier = RetryMultiplier
 
 	cfg.Logs.Buffer = SocketLogsBufferSize
 	cfg.Logs.History = SocketLogsHistorySize
@@ -105,23 +118,87 @@ func normalizeTier(tier string) string {
 
 // Service represents a service configuration
 type Service struct {
-	Dir       string     `yaml:"dir"`
-	Command   string     `yaml:"command"`
-	Profiles  []string   `yaml:"profiles"`
-	Tier      string     `yaml:"tier"`
-	Readiness *Readiness `yaml:"readiness"`
-	Logs      *Logs      `yaml:"logs"`
-	Watch     *Watch     `yaml:"watch"`
+	Dir       string            `yaml:"dir"`
+	Type      string            `yaml:"type,omitempty"`
+	Command   string            `yaml:"command,omitempty"`
+	Build     string            `yaml:"build,omitempty"`
+	TTY       bool              `yaml:"tty,omitempty"`
+	Stdin     bool              `yaml:"stdin,omitempty"`
+	Timeout   time.Duration     `yaml:"timeout,omitempty"`
+	Profiles  []string          `yaml:"profiles,omitempty"`
+	Tier      string            `yaml:"tier,omitempty"`
+	DependsOn []string          `yaml:"depends_on,omitempty" mapstructure:"depends_on"`
+	Env       map[string]string `yaml:"env,omitempty"`
+	EnvFile   []string          `yaml:"env_file,omitempty" mapstructure:"env_file"`
+	Readiness *Readiness        `yaml:"readiness,omitempty"`
+	Retry     *ServiceRetry     `yaml:"retry,omitempty"`
+	Stop      *Stop             `yaml:"stop,omitempty"`
+	Limits    *Limits           `yaml:"limits,omitempty"`
+	Restart   *Restart          `yaml:"restart,omitempty" mapstructure:"-"`
+	Hooks     *Hooks            `yaml:"hooks,omitempty"`
+	Logs      *Logs             `yaml:"logs,omitempty"`
+	Watch     *Watch            `yaml:"watch,omitempty"`
 }
 
 // Readiness represents readiness check configuration for a service
 type Readiness struct {
 	Type     string        `yaml:"type"`
-	Address  string        `yaml:"address"`
-	URL      string        `yaml:"url"`
-	Pattern  string        `yaml:"pattern"`
-	Timeout  time.Duration `yaml:"timeout"`
-	Interval time.Duration `yaml:"interval"`
+	Address  string        `yaml:"address,omitempty"`
+	URL      string        `yaml:"url,omitempty"`
+	Pattern  string        `yaml:"pattern,omitempty"`
+	Timeout  time.Duration `yaml:"timeout,omitempty"`
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+// Endpoint returns the host:port probed by an http or tcp readiness check, or empty for other types
+func (r *Readiness) Endpoint() string {
+	if r == nil {
+		return ""
+	}
+
+	switch r.Type {
+	case TypeHTTP:
+		return extractFromURL(r.URL)
+	case TypeTCP:
+		return r.Address
+	default:
+		return ""
+	}
+}
+
+// extractFromURL extracts host:port from URL (e.g., "http://localhost:8080/health" -> "localhost:8080")
+func extractFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	host := parsed.Hostname()
+	if host == "" {
+		return ""
+	}
+
+	port := parsed.Port()
+	if port == "" {
+		switch parsed.Scheme {
+		case schemeHTTP:
+			port = portHTTP
+		case schemeHTTPS:
+			port = portHTTPS
+		default:
+			return ""
+		}
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
+// Stop represents how a service is stopped
+type Stop struct {
+	Signal  string        `yaml:"signal,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	Grace   time.Duration `yaml:"grace,omitempty"`
+	Command string        `yaml:"command,omitempty"`
 }
 
 // Logs represents per-service console logging configuration
@@ -132,15 +209,17 @@ type Logs struct {
 // Watch represents file watch configuration for hot-reload
 type Watch struct {
 	Include  []string      `yaml:"include"`
-	Ignore   []string      `yaml:"ignore"`
-	Shared   []string      `yaml:"shared"`
-	Debounce time.Duration `yaml:"debounce"`
+	Ignore   []string      `yaml:"ignore,omitempty"`
+	Shared   []string      `yaml:"shared,omitempty"`
+	Debounce time.Duration `yaml:"debounce,omitempty"`
 }
 
 // ServiceDefaults represents default configuration for services
 type ServiceDefaults struct {
-	Profiles []string `yaml:"profiles"`
-	Tier     string   `yaml:"tier"`
+	Profiles []string          `yaml:"profiles,omitempty"`
+	Tier     string            `yaml:"tier,omitempty"`
+	Env      map[string]string `yaml:"env,omitempty"`
+	EnvFile  []string          `yaml:"env_file,omitempty" mapstructure:"env_file"`
 }
 
 // Logging represents logging configuration
@@ -154,10 +233,30 @@ type Concurrency struct {
 	Workers int `yaml:"workers"`
 }
 
-// Retry represents retry settings
+// Preflight represents how leftover service processes are found before a start and on stop
+type Preflight struct {
+	MatchCwd bool `yaml:"match_cwd,omitempty" mapstructure:"match_cwd"`
+}
+
+// Retry represents the global retry settings and the policy a service ends up with
 type Retry struct {
-	Attempts int           `yaml:"attempts"`
-	Backoff  time.Duration `yaml:"backoff"`
+	Attempts   int             `yaml:"attempts,omitempty"`
+	Backoff    time.Duration   `yaml:"backoff,omitempty"`
+	MaxBackoff time.Duration   `yaml:"max_backoff,omitempty" mapstructure:"max_backoff"`
+	Multiplier float64         `yaml:"multiplier,omitempty"`
+	Jitter     float64         `yaml:"jitter,omitempty"`
+	Errors     map[string]bool `yaml:"errors,omitempty"`
+}
+
+// ServiceRetry overrides the global retry settings for a service. Unset fields inherit the global
+// value, while a field set to 0 overrides it, for example to turn off jitter or the backoff cap
+type ServiceRetry struct {
+	Attempts   *int            `yaml:"attempts,omitempty"`
+	Backoff    *time.Duration  `yaml:"backoff,omitempty"`
+	MaxBackoff *time.Duration  `yaml:"max_backoff,omitempty" mapstructure:"max_backoff"`
+	Multiplier *float64        `yaml:"multiplier,omitempty"`
+	Jitter     *float64        `yaml:"jitter,omitempty"`
+	Errors     map[string]bool `yaml:"errors,omitempty"`
 }
 
 // LogStream represents log streaming configuration
@@ -168,8 +267,8 @@ type LogStream struct {
 
 // Server represents the built-in API server configuration
 type Server struct {
-	Listen string `yaml:"listen"`
+	Listen string `yaml:"listen,omitempty"`
 	Auth   struct {
 		Token string `yaml:"token"`
-	} `yaml:"auth"`
+	} `yaml:"auth,omitempty"`
 }
@@ -255,3 +255,64 @@ func Test_NormalizeTier(t *testing.T) {
 		})
 	}
 }
+
+func Test_ExtractFromURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			name:     "URL with explicit port",
+			url:      "http://localhost:8080/health",
+			expected: "localhost:8080",
+		},
+		{
+			name:     "URL with IP address and port",
+			url:      "http://127.0.0.1:3000/api",
+			expected: "127.0.0.1:3000",
+		},
+		{
+			name:     "HTTP URL without port defaults to 80",
+			url:      "http://localhost/health",
+			expected: "localhost:80",
+		},
+		{
+			name:     "HTTPS URL without port defaults to 443",
+			url:      "https://localhost/health",
+			expected: "localhost:443",
+		},
+		{
+			name:     "URL with 0.0.0.0",
+			url:      "http://0.0.0.0:8080/health",
+			expected: "0.0.0.0:8080",
+		},
+		{
+			name:     "IPv6 address with port",
+			url:      "http://[::1]:8080/health",
+			expected: "[::1]:8080",
+		},
+		{
+			name:     "invalid URL returns empty",
+			url:      "://invalid",
+			expected: "",
+		},
+		{
+			name:     "empty URL returns empty",
+			url:      "",
+			expected: "",
+		},
+		{
+			name:     "unknown scheme without port returns empty",
+			url:      "ftp://localhost/file",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := extractFromURL(tt.url)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
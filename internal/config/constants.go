@@ -53,10 +53,51 @@ const (
 	PreFlightKillTimeout = 2 * time.Second
 )
 
+// Stop settings
+const (
+	StopSignal = "SIGTERM"
+)
+
+// Restart settings
+const (
+	RestartMaxRestarts = 5
+	RestartWindow      = time.Minute
+	RestartBackoff     = time.Second
+	RestartMaxBackoff  = 30 * time.Second
+	RestartMultiplier  = 2.0
+)
+
+// Job settings
+const (
+	JobTimeout = 10 * time.Minute
+)
+
+// Build settings
+const (
+	BuildTimeout = 10 * time.Minute
+)
+
+// Hook settings
+const (
+	HookTimeout = time.Minute
+)
+
 // Retry settings
 const (
-	RetryAttempts = 3
-	RetryBackoff  = 500 * time.Millisecond
+	RetryAttempts   = 3
+	RetryBackoff    = 500 * time.Millisecond
+	RetryMaxBackoff = 10 * time.Second
+	RetryMultiplier = 1.0
+)
+
+// Retry error classes used as keys of retry.errors
+const (
+	RetryOnPortInUse        = "port_in_use"
+	RetryOnDirNotFound      = "dir_not_found"
+	RetryOnStartFailed      = "start_failed"
+	RetryOnProcessExited    = "process_exited"
+	RetryOnReadinessTimeout = "readiness_timeout"
+	RetryOnOther            = "other"
 )
 
 // Socket configuration
@@ -70,6 +111,15 @@ const (
 	SocketLogsHistorySize = 5000
 )
 
+// Daemon settings
+const (
+	DaemonStartTimeout = 30 * time.Second
+	DaemonStopTimeout  = 2 * time.Minute
+	DaemonPollInterval = 100 * time.Millisecond
+	RemotePollInterval = time.Second
+	ServiceWaitTimeout = 2 * time.Minute
+)
+
 // Watch settings
 const (
 	WatchDebounce = 500 * time.Millisecond
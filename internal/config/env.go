@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"go.yaml.in/yaml/v3"
+
+	"fuku/internal/app/errors"
+)
+
+// envSection holds a case-preserving env map decoded directly from YAML
+type envSection struct {
+	Env map[string]string `yaml:"env"`
+}
+
+// envDocument mirrors the config sections that carry env maps
+type envDocument struct {
+	Defaults *envSection           `yaml:"defaults"`
+	Services map[string]envSection `yaml:"services"`
+}
+
+// Environ returns the configured environment for a service as KEY=VALUE pairs.
+// Layers are applied in increasing precedence:
+//
+//	defaults.env_file  (in listed order)
+//	defaults.env
+//	service env_file   (in listed order)
+//	service env        (includes values merged from the override file)
+func (c *Config) Environ(service *Service) ([]string, error) {
+	env := make(map[string]string)
+
+	if c.Defaults != nil {
+		if err := mergeEnvFiles(env, c.Defaults.EnvFile); err != nil {
+			return nil, err
+		}
+
+		maps.Copy(env, c.Defaults.Env)
+	}
+
+	if service != nil {
+		if err := mergeEnvFiles(env, service.EnvFile); err != nil {
+			return nil, err
+		}
+
+		maps.Copy(env, service.Env)
+	}
+
+	result := make([]string, 0, len(env))
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		result = append(result, key+"="+env[key])
+	}
+
+	return result, nil
+}
+
+// mergeEnvFiles reads dotenv files in order, later files overriding earlier ones
+func mergeEnvFiles(env map[string]string, paths []string) error {
+	for _, path := range paths {
+		values, err := godotenv.Read(path)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", errors.ErrFailedToReadEnvFile, path, err)
+		}
+
+		maps.Copy(env, values)
+	}
+
+	return nil
+}
+
+// applyEnvMaps replaces env maps decoded by viper with case-preserving maps read from raw YAML
+func (c *Config) applyEnvMaps(data []byte) error {
+	var doc envDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	if c.Defaults != nil && doc.Defaults != nil {
+		c.Defaults.Env = doc.Defaults.Env
+	}
+
+	for name, section := range doc.Services {
+		service, exists := c.Services[name]
+		if !exists {
+			service, exists = c.Services[strings.ToLower(name)]
+		}
+
+		if !exists || service == nil {
+			continue
+		}
+
+		service.Env = section.Env
+	}
+
+	return nil
+}
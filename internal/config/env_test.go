@@ -0,0 +1,162 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_Environ(t *testing.T) {
+	dir := t.TempDir()
+
+	defaultsFile := filepath.Join(dir, ".env.defaults")
+	require.NoError(t, os.WriteFile(defaultsFile, []byte("LEVEL=defaults-file\nSHARED=defaults-file\n"), 0644))
+
+	firstFile := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(firstFile, []byte("LEVEL=first-file\nPORT=8080\n"), 0644))
+
+	secondFile := filepath.Join(dir, ".env.local")
+	require.NoError(t, os.WriteFile(secondFile, []byte("PORT=9090\n"), 0644))
+
+	tests := []struct {
+		name     string
+		defaults *ServiceDefaults
+		service  *Service
+		expected []string
+	}{
+		{
+			name:     "no env configured",
+			defaults: nil,
+			service:  &Service{},
+			expected: []string{},
+		},
+		{
+			name:     "nil service uses defaults only",
+			defaults: &ServiceDefaults{Env: map[string]string{"A": "1"}},
+			service:  nil,
+			expected: []string{"A=1"},
+		},
+		{
+			name:     "inline env sorted by key",
+			defaults: nil,
+			service:  &Service{Env: map[string]string{"B": "2", "A": "1"}},
+			expected: []string{"A=1", "B=2"},
+		},
+		{
+			name:     "later env files override earlier ones",
+			defaults: nil,
+			service:  &Service{EnvFile: []string{firstFile, secondFile}},
+			expected: []string{"LEVEL=first-file", "PORT=9090"},
+		},
+		{
+			name: "full precedence chain",
+			defaults: &ServiceDefaults{
+				EnvFile: []string{defaultsFile},
+				Env:     map[string]string{"LEVEL": "defaults-env", "SHARED": "defaults-env", "ONLY_DEFAULTS": "yes"},
+			},
+			service: &Service{
+				EnvFile: []string{firstFile},
+				Env:     map[string]string{"PORT": "7070"},
+			},
+			expected: []string{"LEVEL=first-file", "ONLY_DEFAULTS=yes", "PORT=7070", "SHARED=defaults-env"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Defaults = tt.defaults
+
+			env, err := cfg.Environ(tt.service)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, env)
+		})
+	}
+}
+
+func Test_Environ_MissingFile(t *testing.T) {
+	cfg := DefaultConfig()
+
+	env, err := cfg.Environ(&Service{EnvFile: []string{"/nonexistent/.env"}})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrFailedToReadEnvFile)
+	assert.Nil(t, env)
+}
+
+func Test_ApplyEnvMaps_PreservesKeyCase(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults = &ServiceDefaults{Env: map[string]string{"log_level": "debug"}}
+	cfg.Services["api"] = &Service{Env: map[string]string{"database_url": "postgres://localhost"}}
+
+	data := []byte(`defaults:
+  env:
+    LOG_LEVEL: debug
+services:
+  API:
+    env:
+      DATABASE_URL: postgres://localhost
+      PORT: 8080
+`)
+
+	require.NoError(t, cfg.applyEnvMaps(data))
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, cfg.Defaults.Env)
+	assert.Equal(t, map[string]string{"DATABASE_URL": "postgres://localhost", "PORT": "8080"}, cfg.Services["api"].Env)
+}
+
+func Test_Load_ServiceEnvConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	require.NoError(t, os.WriteFile(".env.api", []byte("API_KEY=secret\n"), 0644))
+
+	base := `version: 1
+defaults:
+  env:
+    APP_ENV: development
+services:
+  api:
+    dir: ./api
+    env_file: [.env.api]
+    env:
+      DATABASE_URL: postgres://localhost/app
+      LOG_LEVEL: info
+`
+	override := `services:
+  api:
+    env:
+      LOG_LEVEL: debug
+`
+
+	require.NoError(t, os.WriteFile(ConfigFile, []byte(base), 0644))
+	require.NoError(t, os.WriteFile(OverrideConfigFile, []byte(override), 0644))
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+
+	api := cfg.Services["api"]
+	assert.Equal(t, []string{".env.api"}, api.EnvFile)
+	assert.Equal(t, map[string]string{"DATABASE_URL": "postgres://localhost/app", "LOG_LEVEL": "debug"}, api.Env)
+
+	env, err := cfg.Environ(api)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"API_KEY=secret", "APP_ENV=development", "DATABASE_URL=postgres://localhost/app", "LOG_LEVEL=debug"}, env)
+}
+
+func Test_Load_MissingEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	content := "version: 1\nservices:\n  api:\n    dir: ./api\n    env_file: [.env.missing]\n"
+	require.NoError(t, os.WriteFile(ConfigFile, []byte(content), 0644))
+
+	cfg, _, err := Load()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrInvalidConfig)
+	assert.ErrorIs(t, err, errors.ErrEnvFileNotFound)
+	assert.Nil(t, cfg)
+}
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"fuku/internal/app/errors"
+)
+
+// Keys used by service inheritance
+const (
+	extendsKey   = "extends"
+	templatesKey = "templates"
+)
+
+// extender resolves extends chains for services and templates
+type extender struct {
+	services  map[string]*yaml.Node
+	templates map[string]*yaml.Node
+	resolved  map[string]*yaml.Node
+	chain     []string
+}
+
+// resolveExtends expands every extends reference into a fully merged service definition
+// and drops the templates section, so later stages only see plain services
+func resolveExtends(data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte(extendsKey)) && !bytes.Contains(data, []byte(templatesKey)) {
+		return data, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	if err := extendDocument(&doc); err != nil {
+		return nil, err
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	return out, nil
+}
+
+// extendDocument resolves the extends references of a parsed config document in place
+func extendDocument(doc *yaml.Node) error {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	resolveAliases(doc)
+
+	root := doc.Content[0]
+
+	e := &extender{
+		services:  mappingEntries(valueOf(root, "services")),
+		templates: mappingEntries(valueOf(root, templatesKey)),
+		resolved:  make(map[string]*yaml.Node),
+	}
+
+	if services := valueOf(root, "services"); services != nil && services.Kind == yaml.MappingNode {
+		for i := 0; i < len(services.Content); i += 2 {
+			name := services.Content[i].Value
+
+			node, err := e.resolve("services", name)
+			if err != nil {
+				return err
+			}
+
+			services.Content[i+1] = node
+		}
+	}
+
+	removeKey(root, templatesKey)
+
+	return nil
+}
+
+// resolve returns the merged definition of a service or template, following its extends chain
+func (e *extender) resolve(section, name string) (*yaml.Node, error) {
+	id := section + "." + name
+
+	if node, ok := e.resolved[id]; ok {
+		return node, nil
+	}
+
+	for i, seen := range e.chain {
+		if seen == id {
+			cycle := append(slices.Clone(e.chain[i:]), id)
+
+			return nil, fmt.Errorf("%w: %s", errors.ErrExtendsCycle, strings.Join(cycle, " -> "))
+		}
+	}
+
+	node := e.services[name]
+	if section == templatesKey {
+		node = e.templates[name]
+	}
+
+	if node == nil || node.Kind != yaml.MappingNode {
+		e.resolved[id] = node
+		return node, nil
+	}
+
+	node = flattenMergeKeys(node)
+
+	parentRef := valueOf(node, extendsKey)
+	if parentRef == nil {
+		e.resolved[id] = node
+		return node, nil
+	}
+
+	if parentRef.Kind != yaml.ScalarNode || parentRef.Value == "" {
+		return nil, fmt.Errorf("%w: %s: extends must be a service or template name", errors.ErrInvalidExtends, id)
+	}
+
+	parentSection, err := e.lookup(id, parentRef.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	e.chain = append(e.chain, id)
+
+	parent, err := e.resolve(parentSection, parentRef.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	e.chain = e.chain[:len(e.chain)-1]
+
+	child := copyNode(node)
+	removeKey(child, extendsKey)
+
+	merged := mergeNodes(copyNode(parent), child)
+	if merged == nil {
+		merged = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	e.resolved[id] = merged
+
+	return merged, nil
+}
+
+// lookup finds which section defines the extends target, preferring services over templates
+func (e *extender) lookup(id, target string) (string, error) {
+	if _, ok := e.services[target]; ok {
+		return "services", nil
+	}
+
+	if _, ok := e.templates[target]; ok {
+		return templatesKey, nil
+	}
+
+	return "", fmt.Errorf("%w: %s extends unknown service or template '%s'", errors.ErrInvalidExtends, id, target)
+}
+
+// mappingEntries indexes the values of a mapping node by key
+func mappingEntries(node *yaml.Node) map[string]*yaml.Node {
+	entries := make(map[string]*yaml.Node)
+
+	if node == nil || node.Kind != yaml.MappingNode {
+		return entries
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		entries[node.Content[i].Value] = node.Content[i+1]
+	}
+
+	return entries
+}
+
+// valueOf returns the value stored under key in a mapping node, or nil
+func valueOf(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	if idx, ok := buildKeyIndex(node)[key]; ok {
+		return node.Content[idx+1]
+	}
+
+	return nil
+}
+
+// removeKey deletes key and its value from a mapping node
+func removeKey(node *yaml.Node, key string) {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
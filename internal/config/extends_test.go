@@ -0,0 +1,144 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_Load_Extends(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeConfigFile(t, ConfigFile, `version: 1
+templates:
+  go-service:
+    tier: platform
+    command: go run .
+    env:
+      LOG_LEVEL: info
+    readiness:
+      type: http
+      url: http://localhost:8080/health
+      timeout: 30s
+    watch:
+      include: ["**/*.go"]
+  go-worker:
+    extends: go-service
+    readiness: ~
+services:
+  api:
+    extends: go-service
+    dir: api
+    env:
+      PORT: "8080"
+    readiness:
+      timeout: 1m
+  api-debug:
+    extends: api
+    command: dlv debug
+  worker:
+    extends: go-worker
+    dir: worker
+`)
+	writeConfigFile(t, OverrideConfigFile, `services:
+  api:
+    dir: api-local
+`)
+
+	cfg, topology, err := Load()
+	require.NoError(t, err)
+
+	api := cfg.Services["api"]
+	assert.Equal(t, "api-local", api.Dir)
+	assert.Equal(t, "go run .", api.Command)
+	assert.Equal(t, "platform", api.Tier)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "info", "PORT": "8080"}, api.Env)
+	require.NotNil(t, api.Readiness)
+	assert.Equal(t, TypeHTTP, api.Readiness.Type)
+	assert.Equal(t, "http://localhost:8080/health", api.Readiness.URL)
+	assert.Equal(t, time.Minute, api.Readiness.Timeout)
+	assert.Equal(t, []string{"**/*.go"}, api.Watch.Include)
+
+	debug := cfg.Services["api-debug"]
+	assert.Equal(t, "api-local", debug.Dir)
+	assert.Equal(t, "dlv debug", debug.Command)
+	assert.Equal(t, time.Minute, debug.Readiness.Timeout)
+
+	worker := cfg.Services["worker"]
+	assert.Equal(t, "worker", worker.Dir)
+	assert.Equal(t, "go run .", worker.Command)
+	assert.Nil(t, worker.Readiness)
+
+	assert.NotContains(t, cfg.Services, "go-service")
+	assert.Equal(t, []string{"platform"}, topology.Order)
+}
+
+func Test_ResolveExtends_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		yaml  string
+		error error
+		msg   string
+	}{
+		{
+			name:  "unknown target",
+			yaml:  "services:\n  api:\n    extends: base\n",
+			error: errors.ErrInvalidExtends,
+			msg:   "services.api extends unknown service or template 'base'",
+		},
+		{
+			name:  "non-scalar target",
+			yaml:  "services:\n  api:\n    extends: [base]\n",
+			error: errors.ErrInvalidExtends,
+		},
+		{
+			name:  "self reference",
+			yaml:  "services:\n  api:\n    extends: api\n",
+			error: errors.ErrExtendsCycle,
+			msg:   "services.api -> services.api",
+		},
+		{
+			name:  "cycle through templates",
+			yaml:  "templates:\n  a:\n    extends: b\n  b:\n    extends: a\nservices:\n  api:\n    extends: a\n",
+			error: errors.ErrExtendsCycle,
+			msg:   "templates.a -> templates.b -> templates.a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveExtends([]byte(tt.yaml))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.error)
+
+			if tt.msg != "" {
+				assert.Contains(t, err.Error(), tt.msg)
+			}
+		})
+	}
+}
+
+func Test_ResolveExtends_Passthrough(t *testing.T) {
+	data := []byte("services:\n  api:\n    dir: api\n")
+
+	result, err := resolveExtends(data)
+	require.NoError(t, err)
+	assert.Equal(t, data, result)
+}
+
+func Test_Resolve_ExtendsSources(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeConfigFile(t, ConfigFile, "version: 1\ntemplates:\n  base:\n    command: make run\nservices:\n  api:\n    extends: base\n    dir: api\n")
+
+	resolved, err := Resolve("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "make run", resolved.Config.Services["api"].Command)
+	assert.Equal(t, "fuku.yaml:4", resolved.Source("services.api.command"))
+	assert.Equal(t, "fuku.yaml:8", resolved.Source("services.api.dir"))
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fuku/internal/app/errors"
+)
+
+// Hook stages, in the order they run during a service's lifecycle
+const (
+	HookBeforeStart = "before_start"
+	HookAfterReady  = "after_ready"
+	HookBeforeStop  = "before_stop"
+	HookAfterStop   = "after_stop"
+)
+
+// Hook error handling
+const (
+	HookOnErrorFail = "fail"
+	HookOnErrorWarn = "warn"
+)
+
+// Hooks represents commands run around a service's lifecycle
+type Hooks struct {
+	BeforeStart *Hook `yaml:"before_start,omitempty" mapstructure:"before_start"`
+	AfterReady  *Hook `yaml:"after_ready,omitempty" mapstructure:"after_ready"`
+	BeforeStop  *Hook `yaml:"before_stop,omitempty" mapstructure:"before_stop"`
+	AfterStop   *Hook `yaml:"after_stop,omitempty" mapstructure:"after_stop"`
+}
+
+// Hook represents a command run at one stage of a service's lifecycle
+type Hook struct {
+	Command string        `yaml:"command"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	OnError string        `yaml:"on_error,omitempty" mapstructure:"on_error"`
+}
+
+// HookPolicy returns the hook configured for a service stage with its defaults filled in,
+// or false when the service has no hook for that stage
+func (c *Config) HookPolicy(name, stage string) (Hook, bool) {
+	service, ok := c.Services[name]
+	if !ok || service.Hooks == nil {
+		return Hook{}, false
+	}
+
+	hook := service.Hooks.stage(stage)
+	if hook == nil {
+		return Hook{}, false
+	}
+
+	policy := *hook
+
+	if policy.Timeout == 0 {
+		policy.Timeout = HookTimeout
+	}
+
+	if policy.OnError == "" {
+		policy.OnError = HookOnErrorFail
+	}
+
+	return policy, true
+}
+
+// stage returns the hook for a stage, or nil when it is not set
+func (h *Hooks) stage(stage string) *Hook {
+	switch stage {
+	case HookBeforeStart:
+		return h.BeforeStart
+	case HookAfterReady:
+		return h.AfterReady
+	case HookBeforeStop:
+		return h.BeforeStop
+	case HookAfterStop:
+		return h.AfterStop
+	default:
+		return nil
+	}
+}
+
+// validate checks every configured hook
+func (h *Hooks) validate() error {
+	for _, stage := range []string{HookBeforeStart, HookAfterReady, HookBeforeStop, HookAfterStop} {
+		hook := h.stage(stage)
+		if hook == nil {
+			continue
+		}
+
+		if err := hook.validate(); err != nil {
+			return fmt.Errorf("hooks.%s: %w", stage, err)
+		}
+	}
+
+	return nil
+}
+
+// validate checks the hook command, timeout and error handling
+func (h *Hook) validate() error {
+	if strings.TrimSpace(h.Command) == "" {
+		return errors.ErrInvalidHookCommand
+	}
+
+	if h.Timeout < 0 {
+		return errors.ErrInvalidHookTimeout
+	}
+
+	switch h.OnError {
+	case "", HookOnErrorFail, HookOnErrorWarn:
+		return nil
+	default:
+		return errors.ErrInvalidHookOnError
+	}
+}
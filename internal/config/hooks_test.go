@@ -0,0 +1,93 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_HookPolicy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Services["api"] = &Service{Hooks: &Hooks{
+		BeforeStart: &Hook{Command: "make migrate"},
+		AfterStop:   &Hook{Command: "make flush", Timeout: 5 * time.Second, OnError: HookOnErrorWarn},
+	}}
+	cfg.Services["web"] = &Service{}
+
+	hook, ok := cfg.HookPolicy("api", HookBeforeStart)
+	assert.True(t, ok)
+	assert.Equal(t, Hook{Command: "make migrate", Timeout: HookTimeout, OnError: HookOnErrorFail}, hook)
+
+	hook, ok = cfg.HookPolicy("api", HookAfterStop)
+	assert.True(t, ok)
+	assert.Equal(t, Hook{Command: "make flush", Timeout: 5 * time.Second, OnError: HookOnErrorWarn}, hook)
+
+	_, ok = cfg.HookPolicy("api", HookAfterReady)
+	assert.False(t, ok)
+
+	_, ok = cfg.HookPolicy("web", HookBeforeStart)
+	assert.False(t, ok)
+
+	_, ok = cfg.HookPolicy("missing", HookBeforeStart)
+	assert.False(t, ok)
+}
+
+func Test_Hooks_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		hooks Hooks
+		error error
+	}{
+		{name: "empty", hooks: Hooks{}},
+		{name: "full", hooks: Hooks{
+			BeforeStart: &Hook{Command: "make migrate", Timeout: time.Minute},
+			AfterReady:  &Hook{Command: "make seed", OnError: HookOnErrorWarn},
+			BeforeStop:  &Hook{Command: "make drain", OnError: HookOnErrorFail},
+			AfterStop:   &Hook{Command: "make flush"},
+		}},
+		{name: "missing command", hooks: Hooks{AfterReady: &Hook{Command: "  "}}, error: errors.ErrInvalidHookCommand},
+		{name: "negative timeout", hooks: Hooks{BeforeStop: &Hook{Command: "true", Timeout: -time.Second}}, error: errors.ErrInvalidHookTimeout},
+		{name: "unknown on_error", hooks: Hooks{AfterStop: &Hook{Command: "true", OnError: "ignore"}}, error: errors.ErrInvalidHookOnError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hooks.validate()
+			if tt.error == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Load_Hooks(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeConfigFile(t, ConfigFile, `version: 1
+services:
+  api:
+    dir: api
+    hooks:
+      before_start:
+        command: make migrate
+        timeout: 2m
+      after_ready:
+        command: make seed
+        on_error: warn
+`)
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, &Hooks{
+		BeforeStart: &Hook{Command: "make migrate", Timeout: 2 * time.Minute},
+		AfterReady:  &Hook{Command: "make seed", OnError: HookOnErrorWarn},
+	}, cfg.Services["api"].Hooks)
+}
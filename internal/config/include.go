@@ -0,0 +1,306 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"fuku/internal/app/errors"
+)
+
+// includer tracks state while include entries are expanded into a single document
+type includer struct {
+	sources  *sourceSet
+	root     *yaml.Node
+	services *yaml.Node
+	profiles *yaml.Node
+	owners   map[string]string
+	visited  map[string]bool
+}
+
+// resolveIncludes expands the top-level include list of a config document in place.
+// Each included file contributes its services and profiles; relative dir, watch.shared
+// and env_file paths are rebased onto the included file's directory, as is the default
+// dir of a service without one. Included files may include further files relative to
+// their own location
+func resolveIncludes(path string, root *yaml.Node, sources *sourceSet) error {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	doc := root.Content[0]
+
+	patterns, found, err := takeIncludes(doc)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", errors.ErrInvalidInclude, path, err)
+	}
+
+	if !found {
+		return nil
+	}
+
+	inc := &includer{
+		sources:  sources,
+		root:     doc,
+		services: ensureMapping(doc, "services"),
+		owners:   make(map[string]string),
+		visited:  map[string]bool{filepath.Clean(path): true},
+	}
+
+	for i := 0; i < len(inc.services.Content); i += 2 {
+		inc.owners[inc.services.Content[i].Value] = path
+	}
+
+	return inc.include(filepath.Dir(path), patterns)
+}
+
+// include loads every file matched by patterns, resolved relative to dir
+func (inc *includer) include(dir string, patterns []string) error {
+	for _, pattern := range patterns {
+		full := pattern
+		if !filepath.IsAbs(full) {
+			full = filepath.Join(dir, pattern)
+		}
+
+		matches, err := filepath.Glob(full)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", errors.ErrInvalidInclude, pattern, err)
+		}
+
+		if len(matches) == 0 && !hasGlobMeta(pattern) {
+			return fmt.Errorf("%w: %s", errors.ErrIncludeNotFound, full)
+		}
+
+		for _, match := range matches {
+			if err := inc.includeFile(match); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// includeFile merges the services and profiles of a single included file
+func (inc *includer) includeFile(path string) error {
+	path = filepath.Clean(path)
+	if inc.visited[path] {
+		return nil
+	}
+
+	inc.visited[path] = true
+
+	root, err := inc.sources.read(path)
+	if err != nil {
+		return err
+	}
+
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil
+	}
+
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return fmt.Errorf("%w: %s: expected a mapping", errors.ErrInvalidInclude, path)
+	}
+
+	resolveAliases(doc)
+
+	patterns, _, err := takeIncludes(doc)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", errors.ErrInvalidInclude, path, err)
+	}
+
+	dir := filepath.Dir(path)
+	index := buildKeyIndex(doc)
+
+	if i, ok := index["services"]; ok && doc.Content[i+1].Kind == yaml.MappingNode {
+		if err := inc.addServices(path, dir, doc.Content[i+1]); err != nil {
+			return err
+		}
+	}
+
+	if i, ok := index["profiles"]; ok && doc.Content[i+1].Kind == yaml.MappingNode {
+		inc.addProfiles(doc.Content[i+1])
+	}
+
+	return inc.include(dir, patterns)
+}
+
+// addServices appends included services, rejecting names already defined elsewhere
+func (inc *includer) addServices(path, dir string, services *yaml.Node) error {
+	for i := 0; i < len(services.Content); i += 2 {
+		name := services.Content[i].Value
+
+		if owner, exists := inc.owners[name]; exists {
+			return fmt.Errorf("%w: '%s' is defined in both %s and %s", errors.ErrDuplicateService, name, owner, path)
+		}
+
+		inc.owners[name] = path
+
+		service := copyNode(flattenMergeKeys(services.Content[i+1]))
+		rebaseServicePaths(service, dir)
+		service = withDefaultDir(service, name, dir)
+
+		inc.services.Content = append(inc.services.Content, copyNode(services.Content[i]), service)
+	}
+
+	return nil
+}
+
+// addProfiles merges included profiles, concatenating lists for profiles defined more than once
+func (inc *includer) addProfiles(profiles *yaml.Node) {
+	if inc.profiles == nil {
+		inc.profiles = ensureMapping(inc.root, "profiles")
+	}
+
+	index := buildKeyIndex(inc.profiles)
+
+	for i := 0; i < len(profiles.Content); i += 2 {
+		key := copyNode(profiles.Content[i])
+		value := copyNode(profiles.Content[i+1])
+
+		j, exists := index[key.Value]
+		if !exists {
+			index[key.Value] = len(inc.profiles.Content)
+			inc.profiles.Content = append(inc.profiles.Content, key, value)
+
+			continue
+		}
+
+		if merged := mergeNodes(inc.profiles.Content[j+1], value); merged != nil {
+			inc.profiles.Content[j+1] = merged
+		}
+	}
+}
+
+// rebaseServicePaths rewrites relative path fields of a service so they resolve from dir
+func rebaseServicePaths(service *yaml.Node, dir string) {
+	if service.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i < len(service.Content); i += 2 {
+		key := service.Content[i].Value
+		value := service.Content[i+1]
+
+		switch key {
+		case "dir", "env_file":
+			rebasePaths(value, dir)
+		case "watch":
+			watch := flattenMergeKeys(value)
+			service.Content[i+1] = watch
+
+			for j := 0; j < len(watch.Content); j += 2 {
+				if watch.Content[j].Value == "shared" {
+					rebasePaths(watch.Content[j+1], dir)
+				}
+			}
+		}
+	}
+}
+
+// withDefaultDir gives a service without a dir the default one, its name, resolved from dir rather than
+// the working directory. A service that extends another is left alone, since it may inherit a dir
+func withDefaultDir(service *yaml.Node, name, dir string) *yaml.Node {
+	if isNull(service) {
+		service = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	if service.Kind != yaml.MappingNode {
+		return service
+	}
+
+	index := buildKeyIndex(service)
+	if _, ok := index["dir"]; ok {
+		return service
+	}
+
+	if _, ok := index[extendsKey]; ok {
+		return service
+	}
+
+	service.Content = append(service.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "dir"},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: filepath.Join(dir, name)},
+	)
+
+	return service
+}
+
+// rebasePaths joins dir onto a relative path scalar or each relative entry of a sequence
+func rebasePaths(node *yaml.Node, dir string) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value == "" || filepath.IsAbs(node.Value) || strings.HasPrefix(node.Value, "$") {
+			return
+		}
+
+		node.Value = filepath.Join(dir, node.Value)
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			rebasePaths(item, dir)
+		}
+	}
+}
+
+// takeIncludes removes the include key from a mapping and returns its entries
+func takeIncludes(doc *yaml.Node) ([]string, bool, error) {
+	i, ok := buildKeyIndex(doc)["include"]
+	if !ok {
+		return nil, false, nil
+	}
+
+	value := doc.Content[i+1]
+	doc.Content = append(doc.Content[:i], doc.Content[i+2:]...)
+
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if isNull(value) || value.Value == "" {
+			return nil, true, nil
+		}
+
+		return []string{value.Value}, true, nil
+	case yaml.SequenceNode:
+		patterns := make([]string, 0, len(value.Content))
+
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode || item.Value == "" {
+				return nil, true, fmt.Errorf("include entries must be non-empty paths")
+			}
+
+			patterns = append(patterns, item.Value)
+		}
+
+		return patterns, true, nil
+	default:
+		return nil, true, fmt.Errorf("include must be a path or a list of paths")
+	}
+}
+
+// ensureMapping returns the mapping stored under key, creating it when absent or null
+func ensureMapping(doc *yaml.Node, key string) *yaml.Node {
+	if i, ok := buildKeyIndex(doc)[key]; ok {
+		value := doc.Content[i+1]
+		if value.Kind == yaml.MappingNode {
+			return value
+		}
+
+		mapping := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		doc.Content[i+1] = mapping
+
+		return mapping
+	}
+
+	mapping := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, mapping)
+
+	return mapping
+}
+
+// hasGlobMeta reports whether a path contains glob metacharacters
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
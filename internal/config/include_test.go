@@ -0,0 +1,253 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"fuku/internal/app/errors"
+)
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func Test_Load_Include(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	writeConfigFile(t, ConfigFile, `version: 1
+include:
+  - services/*/fuku.service.yaml
+services:
+  db:
+    dir: ./db
+    tier: foundation
+profiles:
+  backend: [db]
+`)
+	writeConfigFile(t, "services/api/fuku.service.yaml", `services:
+  api:
+    dir: .
+    tier: platform
+    env_file: [.env.local]
+    watch:
+      include: ["*.go"]
+      shared: [../../pkg/common]
+profiles:
+  backend: [api]
+  api-only: [api]
+`)
+	writeConfigFile(t, "services/web/fuku.service.yaml", `x-base: &base
+  tier: edge
+services:
+  web:
+    <<: *base
+    dir: ./app
+`)
+	writeConfigFile(t, "services/api/.env.local", "A=1\n")
+	require.NoError(t, os.MkdirAll("services/web/app", 0755))
+
+	cfg, topology, err := Load()
+	require.NoError(t, err)
+
+	require.Contains(t, cfg.Services, "api")
+	require.Contains(t, cfg.Services, "web")
+
+	api := cfg.Services["api"]
+	assert.Equal(t, filepath.Join("services", "api"), api.Dir)
+	assert.Equal(t, []string{filepath.Join("services", "api", ".env.local")}, api.EnvFile)
+	assert.Equal(t, []string{"pkg/common"}, api.Watch.Shared)
+	assert.Equal(t, []string{"*.go"}, api.Watch.Include)
+
+	web := cfg.Services["web"]
+	assert.Equal(t, filepath.Join("services", "web", "app"), web.Dir)
+	assert.Equal(t, "edge", web.Tier)
+
+	services, err := cfg.ProfileServices("backend")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db", "api"}, services)
+
+	services, err = cfg.ProfileServices("api-only")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api"}, services)
+
+	assert.Equal(t, []string{"foundation", "platform", "edge"}, topology.Order)
+}
+
+func Test_Load_IncludeNested(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	writeConfigFile(t, ConfigFile, "version: 1\ninclude: group/fuku.group.yaml\nservices: {}\n")
+	writeConfigFile(t, "group/fuku.group.yaml", "include: [worker/fuku.service.yaml]\nservices:\n  api:\n    dir: api\n")
+	writeConfigFile(t, "group/worker/fuku.service.yaml", "services:\n  worker:\n    dir: .\n")
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("group", "api"), cfg.Services["api"].Dir)
+	assert.Equal(t, filepath.Join("group", "worker"), cfg.Services["worker"].Dir)
+}
+
+func Test_Load_IncludeDefaultDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	writeConfigFile(t, ConfigFile, `version: 1
+include: [group/fuku.group.yaml]
+services:
+  db:
+    dir: ./db
+    tier: foundation
+`)
+	writeConfigFile(t, "group/fuku.group.yaml", `services:
+  api:
+    tier: platform
+  worker:
+  web:
+    extends: db
+`)
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("group", "api"), cfg.Services["api"].Dir)
+	assert.Equal(t, filepath.Join("group", "worker"), cfg.Services["worker"].Dir)
+	assert.Equal(t, "./db", cfg.Services["web"].Dir)
+}
+
+func Test_Load_IncludeOverrideTargetsIncludedService(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	writeConfigFile(t, ConfigFile, "version: 1\ninclude: [services/*.yaml]\n")
+	writeConfigFile(t, "services/api.yaml", "services:\n  api:\n    dir: api\n    command: make run\n")
+	writeConfigFile(t, OverrideConfigFile, "services:\n  api:\n    command: make debug\n")
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("services", "api"), cfg.Services["api"].Dir)
+	assert.Equal(t, "make debug", cfg.Services["api"].Command)
+}
+
+func Test_LoadFromFile_Include(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "custom.yaml")
+	writeConfigFile(t, path, "version: 1\ninclude: [extra.yaml]\n")
+	writeConfigFile(t, filepath.Join(dir, "extra.yaml"), "services:\n  api:\n    dir: /srv/api\n")
+
+	cfg, _, err := LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "/srv/api", cfg.Services["api"].Dir)
+}
+
+func Test_ResolveIncludes_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  string
+		files map[string]string
+		error error
+		msg   string
+	}{
+		{
+			name:  "duplicate service across base and include",
+			base:  "include: [a.yaml]\nservices:\n  api:\n    dir: api\n",
+			files: map[string]string{"a.yaml": "services:\n  api:\n    dir: other\n"},
+			error: errors.ErrDuplicateService,
+			msg:   "'api' is defined in both fuku.yaml and a.yaml",
+		},
+		{
+			name: "duplicate service across included files",
+			base: "include: [\"svc/*.yaml\"]\n",
+			files: map[string]string{
+				"svc/a.yaml": "services:\n  api:\n    dir: a\n",
+				"svc/b.yaml": "services:\n  api:\n    dir: b\n",
+			},
+			error: errors.ErrDuplicateService,
+			msg:   "'api' is defined in both svc/a.yaml and svc/b.yaml",
+		},
+		{
+			name:  "missing explicit path",
+			base:  "include: [missing.yaml]\n",
+			error: errors.ErrIncludeNotFound,
+		},
+		{
+			name:  "invalid include value",
+			base:  "include:\n  a: b\n",
+			error: errors.ErrInvalidInclude,
+		},
+		{
+			name:  "invalid included yaml",
+			base:  "include: [bad.yaml]\n",
+			files: map[string]string{"bad.yaml": "services: [\n"},
+			error: errors.ErrFailedToParseConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+
+			for path, content := range tt.files {
+				writeConfigFile(t, path, content)
+			}
+
+			_, err := includeYAML(tt.base)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.error)
+
+			if tt.msg != "" {
+				assert.Contains(t, err.Error(), tt.msg)
+			}
+		})
+	}
+}
+
+func Test_ResolveIncludes_Passthrough(t *testing.T) {
+	data := "version: 1\nservices:\n  api:\n    dir: api\n"
+
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(data), &doc))
+
+	expected, err := yaml.Marshal(&doc)
+	require.NoError(t, err)
+
+	result, err := includeYAML(data)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), result)
+}
+
+func Test_ResolveIncludes_GlobWithoutMatches(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	result, err := includeYAML("include: [\"services/*/fuku.service.yaml\"]\nservices:\n  api:\n    dir: api\n")
+	require.NoError(t, err)
+	assert.NotContains(t, result, "include")
+	assert.Contains(t, result, "api")
+}
+
+// includeYAML expands the includes of a config document given as text and returns the result as text
+func includeYAML(data string) (string, error) {
+	sources := &sourceSet{}
+
+	doc, err := sources.parse(ConfigFile, []byte(data))
+	if err != nil {
+		return "", err
+	}
+
+	if err := resolveIncludes(ConfigFile, doc, sources); err != nil {
+		return "", err
+	}
+
+	out, err := yaml.Marshal(doc)
+
+	return string(out), err
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"fuku/internal/app/errors"
+)
+
+// interpolateYAML expands ${VAR}, ${VAR:-default} and ${VAR:?error} references in YAML scalar values.
+// Values are looked up in the process environment, which LoadEnv populates from the project .env files.
+// A literal dollar sign is written as $$.
+func interpolateYAML(data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte("$")) {
+		return data, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	changed, err := interpolateNode(&doc, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	if !changed {
+		return data, nil
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	return out, nil
+}
+
+// interpolateNode expands variable references in scalar values (not keys) and reports whether anything changed
+func interpolateNode(node *yaml.Node, lookup func(string) (string, bool)) (bool, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		expanded, err := expandVars(node.Value, lookup)
+		if err != nil {
+			return false, fmt.Errorf("line %d: %w", node.Line, err)
+		}
+
+		if expanded == node.Value {
+			return false, nil
+		}
+
+		node.Value = expanded
+
+		if node.Style == 0 && node.Tag == "!!str" {
+			node.Tag = ""
+		}
+
+		return true, nil
+	case yaml.MappingNode:
+		changed := false
+
+		for i := 1; i < len(node.Content); i += 2 {
+			c, err := interpolateNode(node.Content[i], lookup)
+			if err != nil {
+				return false, err
+			}
+
+			changed = changed || c
+		}
+
+		return changed, nil
+	case yaml.DocumentNode, yaml.SequenceNode:
+		changed := false
+
+		for _, child := range node.Content {
+			c, err := interpolateNode(child, lookup)
+			if err != nil {
+				return false, err
+			}
+
+			changed = changed || c
+		}
+
+		return changed, nil
+	default:
+		return false, nil
+	}
+}
+
+// expandVars replaces every ${...} reference in s using lookup
+func expandVars(s string, lookup func(string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+
+			continue
+		}
+
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("%w: unterminated reference in %q", errors.ErrInvalidInterpolation, s)
+			}
+
+			value, err := resolveVar(s[i+2:i+2+end], lookup)
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(value)
+			i += end + 2
+		default:
+			b.WriteByte('$')
+		}
+	}
+
+	return b.String(), nil
+}
+
+// resolveVar evaluates a single reference body such as VAR, VAR:-default or VAR:?message
+func resolveVar(expr string, lookup func(string) (string, bool)) (string, error) {
+	name, op, arg := expr, "", ""
+
+	if idx := strings.Index(expr, ":"); idx >= 0 && idx+1 < len(expr) {
+		name, op, arg = expr[:idx], expr[idx:idx+2], expr[idx+2:]
+	}
+
+	if name == "" {
+		return "", fmt.Errorf("%w: empty variable name in ${%s}", errors.ErrInvalidInterpolation, expr)
+	}
+
+	value, ok := lookup(name)
+
+	switch op {
+	case "":
+		return value, nil
+	case ":-":
+		if !ok || value == "" {
+			return arg, nil
+		}
+
+		return value, nil
+	case ":?":
+		if !ok || value == "" {
+			if arg == "" {
+				return "", fmt.Errorf("%w: %s", errors.ErrMissingVariable, name)
+			}
+
+			return "", fmt.Errorf("%w: %s: %s", errors.ErrMissingVariable, name, arg)
+		}
+
+		return value, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported modifier in ${%s}", errors.ErrInvalidInterpolation, expr)
+	}
+}
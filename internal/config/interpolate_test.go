@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_ExpandVars(t *testing.T) {
+	env := map[string]string{
+		"PORT":  "8080",
+		"HOST":  "localhost",
+		"EMPTY": "",
+	}
+
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		error    error
+	}{
+		{name: "no references", input: "make run", expected: "make run"},
+		{name: "plain reference", input: "http://${HOST}:${PORT}/health", expected: "http://localhost:8080/health"},
+		{name: "unset reference expands to empty", input: "x${MISSING}y", expected: "xy"},
+		{name: "default used when unset", input: "${MISSING:-3000}", expected: "3000"},
+		{name: "default used when empty", input: "${EMPTY:-3000}", expected: "3000"},
+		{name: "default ignored when set", input: "${PORT:-3000}", expected: "8080"},
+		{name: "default may contain colons", input: "${MISSING:-localhost:9000}", expected: "localhost:9000"},
+		{name: "required set", input: "${PORT:?port is required}", expected: "8080"},
+		{name: "required unset", input: "${MISSING:?port is required}", error: errors.ErrMissingVariable},
+		{name: "required empty without message", input: "${EMPTY:?}", error: errors.ErrMissingVariable},
+		{name: "escaped dollar", input: "echo $${HOME} $$PATH", expected: "echo ${HOME} $PATH"},
+		{name: "bare dollar kept", input: "echo $HOME $", expected: "echo $HOME $"},
+		{name: "unterminated reference", input: "${PORT", error: errors.ErrInvalidInterpolation},
+		{name: "empty name", input: "${:-x}", error: errors.ErrInvalidInterpolation},
+		{name: "unsupported modifier", input: "${PORT:+x}", error: errors.ErrInvalidInterpolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := expandVars(tt.input, lookup)
+
+			if tt.error != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.error)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_InterpolateYAML(t *testing.T) {
+	t.Setenv("FUKU_TEST_PORT", "8080")
+	t.Setenv("FUKU_TEST_NAME", "api")
+
+	t.Run("no references returns input unchanged", func(t *testing.T) {
+		data := []byte("services:\n  api:\n    dir: ./api\n")
+
+		result, err := interpolateYAML(data)
+		require.NoError(t, err)
+		assert.Equal(t, data, result)
+	})
+
+	t.Run("values are expanded but keys are not", func(t *testing.T) {
+		data := []byte("services:\n  ${FUKU_TEST_NAME}:\n    dir: ./${FUKU_TEST_NAME}\n")
+
+		result, err := interpolateYAML(data)
+		require.NoError(t, err)
+		assert.Contains(t, string(result), "${FUKU_TEST_NAME}:")
+		assert.Contains(t, string(result), "dir: ./api")
+	})
+
+	t.Run("plain scalars are re-typed while quoted scalars stay strings", func(t *testing.T) {
+		data := []byte("a: ${FUKU_TEST_PORT}\nb: \"${FUKU_TEST_PORT}\"\n")
+
+		result, err := interpolateYAML(data)
+		require.NoError(t, err)
+		assert.Contains(t, string(result), "a: 8080")
+		assert.Contains(t, string(result), "b: \"8080\"")
+	})
+
+	t.Run("error reports line number", func(t *testing.T) {
+		data := []byte("a: 1\nb: ${FUKU_TEST_UNSET_VAR:?must be set}\n")
+
+		_, err := interpolateYAML(data)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errors.ErrMissingVariable)
+		assert.Contains(t, err.Error(), "line 2")
+		assert.Contains(t, err.Error(), "must be set")
+	})
+}
+
+func Test_Load_Interpolation(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	base := `version: 1
+services:
+  api:
+    dir: ./api
+    command: go run . --port ${FUKU_TEST_API_PORT:-3000}
+    readiness:
+      type: http
+      url: http://localhost:${FUKU_TEST_API_PORT:-3000}/health
+`
+	override := `services:
+  api:
+    dir: ${FUKU_TEST_API_DIR}
+`
+
+	require.NoError(t, os.WriteFile(ConfigFile, []byte(base), 0644))
+	require.NoError(t, os.WriteFile(OverrideConfigFile, []byte(override), 0644))
+	require.NoError(t, os.WriteFile(".env", []byte("FUKU_TEST_API_PORT=4000\nFUKU_TEST_API_DIR=./backend\n"), 0644))
+
+	t.Cleanup(func() {
+		os.Unsetenv("FUKU_TEST_API_PORT")
+		os.Unsetenv("FUKU_TEST_API_DIR")
+	})
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+
+	api := cfg.Services["api"]
+	assert.Equal(t, "./backend", api.Dir)
+	assert.Equal(t, "go run . --port 4000", api.Command)
+	assert.Equal(t, "http://localhost:4000/health", api.Readiness.URL)
+}
+
+func Test_Load_InterpolationRequiredMissing(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	content := "version: 1\nservices:\n  api:\n    dir: ./api\nserver:\n  listen: 127.0.0.1:9876\n  auth:\n    token: ${FUKU_TEST_TOKEN_UNSET:?set FUKU_TEST_TOKEN_UNSET}\n"
+	require.NoError(t, os.WriteFile(ConfigFile, []byte(content), 0644))
+
+	_, _, err := Load()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrMissingVariable)
+}
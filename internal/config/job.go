@@ -0,0 +1,57 @@
+package config
+
+import (
+	"time"
+
+	"fuku/internal/app/errors"
+)
+
+// Service types
+const (
+	ServiceTypeService = "service"
+	ServiceTypeJob     = "job"
+)
+
+// IsJob reports whether the service is a one-shot job that must exit successfully rather than keep running
+func (s *Service) IsJob() bool {
+	return s.Type == ServiceTypeJob
+}
+
+// JobTimeout returns how long a job may run before it is killed and marked failed
+func (s *Service) JobTimeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+
+	return JobTimeout
+}
+
+// validateType checks the service type and the settings that only make sense for long-running services
+func (s *Service) validateType() error {
+	switch s.Type {
+	case "", ServiceTypeService:
+		if s.Timeout != 0 {
+			return errors.ErrJobTimeoutOnService
+		}
+
+		return nil
+	case ServiceTypeJob:
+	default:
+		return errors.ErrInvalidServiceType
+	}
+
+	if s.Timeout < 0 {
+		return errors.ErrInvalidJobTimeout
+	}
+
+	if s.Readiness != nil {
+		return errors.ErrJobReadiness
+	}
+
+	// only a policy that restarts is rejected; without one a job keeps the default of never
+	if s.Restart != nil && (s.Restart.Policy == RestartOnFailure || s.Restart.Policy == RestartAlways) {
+		return errors.ErrJobRestart
+	}
+
+	return nil
+}
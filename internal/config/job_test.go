@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_Service_JobTimeout(t *testing.T) {
+	assert.Equal(t, JobTimeout, (&Service{Type: ServiceTypeJob}).JobTimeout())
+	assert.Equal(t, time.Minute, (&Service{Type: ServiceTypeJob, Timeout: time.Minute}).JobTimeout())
+}
+
+func Test_Service_ValidateType(t *testing.T) {
+	tests := []struct {
+		name    string
+		service Service
+		error   error
+	}{
+		{name: "default", service: Service{}},
+		{name: "explicit service", service: Service{Type: ServiceTypeService}},
+		{name: "job", service: Service{Type: ServiceTypeJob, Timeout: time.Minute}},
+		{name: "job with restart never", service: Service{Type: ServiceTypeJob, Restart: &Restart{Policy: RestartNever}}},
+		{name: "job with restart settings but no policy", service: Service{Type: ServiceTypeJob, Restart: &Restart{MaxRestarts: 3}}},
+		{name: "unknown type", service: Service{Type: "cron"}, error: errors.ErrInvalidServiceType},
+		{name: "timeout on service", service: Service{Timeout: time.Minute}, error: errors.ErrJobTimeoutOnService},
+		{name: "negative job timeout", service: Service{Type: ServiceTypeJob, Timeout: -time.Second}, error: errors.ErrInvalidJobTimeout},
+		{name: "job with readiness", service: Service{Type: ServiceTypeJob, Readiness: &Readiness{Type: TypeLog, Pattern: "done"}}, error: errors.ErrJobReadiness},
+		{name: "job with restart", service: Service{Type: ServiceTypeJob, Restart: &Restart{Policy: RestartOnFailure}}, error: errors.ErrJobRestart},
+		{name: "job with restart always", service: Service{Type: ServiceTypeJob, Restart: &Restart{Policy: RestartAlways, MaxRestarts: 3}}, error: errors.ErrJobRestart},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.service.validateType()
+			if tt.error == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Load_Job(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeConfigFile(t, ConfigFile, `version: 1
+services:
+  migrate:
+    type: job
+    command: make migrate
+    timeout: 5m
+`)
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+
+	assert.True(t, cfg.Services["migrate"].IsJob())
+	assert.Equal(t, 5*time.Minute, cfg.Services["migrate"].JobTimeout())
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"fuku/internal/app/errors"
+)
+
+// Nice value bounds
+const (
+	NiceMin = -20
+	NiceMax = 19
+)
+
+// memoryUnits maps size suffixes to their multiplier in bytes
+var memoryUnits = map[string]int64{
+	"":  1,
+	"K": 1 << 10,
+	"M": 1 << 20,
+	"G": 1 << 30,
+	"T": 1 << 40,
+}
+
+// Limits represents resource limits applied to a service's process group. Memory, CPU and pids
+// are enforced through a delegated cgroup v2 only, nofile and nice everywhere
+type Limits struct {
+	Memory string  `yaml:"memory,omitempty"`
+	CPU    float64 `yaml:"cpu,omitempty"`
+	PIDs   int     `yaml:"pids,omitempty"`
+	NoFile uint64  `yaml:"nofile,omitempty"`
+	Nice   int     `yaml:"nice,omitempty"`
+}
+
+// MemoryBytes returns the memory limit in bytes, or 0 when no memory limit is set
+func (l *Limits) MemoryBytes() int64 {
+	bytes, _ := parseMemory(l.Memory)
+
+	return bytes
+}
+
+// validate checks every limit value
+func (l *Limits) validate() error {
+	if _, err := parseMemory(l.Memory); err != nil {
+		return err
+	}
+
+	if l.CPU < 0 {
+		return errors.ErrInvalidCPULimit
+	}
+
+	if l.PIDs < 0 {
+		return errors.ErrInvalidPIDsLimit
+	}
+
+	if l.Nice < NiceMin || l.Nice > NiceMax {
+		return errors.ErrInvalidNiceValue
+	}
+
+	return nil
+}
+
+// parseMemory parses a size such as 512M, 2G or 1.5GB into bytes, treating an empty size as no limit
+func parseMemory(size string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(size))
+	if value == "" {
+		return 0, nil
+	}
+
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "B"), "I")
+
+	number := strings.TrimRight(value, "KMGT")
+
+	multiplier, ok := memoryUnits[value[len(number):]]
+	if !ok {
+		return 0, errors.ErrInvalidMemoryLimit
+	}
+
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil || amount <= 0 {
+		return 0, errors.ErrInvalidMemoryLimit
+	}
+
+	return int64(amount * float64(multiplier)), nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_ParseMemory(t *testing.T) {
+	tests := []struct {
+		size     string
+		expected int64
+		error    error
+	}{
+		{size: "", expected: 0},
+		{size: "1024", expected: 1024},
+		{size: "512M", expected: 512 << 20},
+		{size: "512mb", expected: 512 << 20},
+		{size: "2G", expected: 2 << 30},
+		{size: "1.5GiB", expected: 3 << 29},
+		{size: "64k", expected: 64 << 10},
+		{size: "2X", error: errors.ErrInvalidMemoryLimit},
+		{size: "G", error: errors.ErrInvalidMemoryLimit},
+		{size: "-1G", error: errors.ErrInvalidMemoryLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			bytes, err := parseMemory(tt.size)
+			if tt.error != nil {
+				assert.ErrorIs(t, err, tt.error)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, bytes)
+		})
+	}
+}
+
+func Test_Limits_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		error  error
+	}{
+		{name: "empty", limits: Limits{}},
+		{name: "full", limits: Limits{Memory: "2G", CPU: 1.5, PIDs: 256, NoFile: 4096, Nice: 10}},
+		{name: "invalid memory", limits: Limits{Memory: "lots"}, error: errors.ErrInvalidMemoryLimit},
+		{name: "negative cpu", limits: Limits{CPU: -1}, error: errors.ErrInvalidCPULimit},
+		{name: "negative pids", limits: Limits{PIDs: -1}, error: errors.ErrInvalidPIDsLimit},
+		{name: "nice too low", limits: Limits{Nice: -21}, error: errors.ErrInvalidNiceValue},
+		{name: "nice too high", limits: Limits{Nice: 20}, error: errors.ErrInvalidNiceValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.validate()
+			if tt.error == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Load_Limits(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeConfigFile(t, ConfigFile, `version: 1
+services:
+  api:
+    dir: api
+    limits:
+      memory: 512M
+      cpu: 1.5
+      pids: 128
+      nofile: 4096
+      nice: 5
+`)
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+
+	limits := cfg.Services["api"].Limits
+	assert.Equal(t, &Limits{Memory: "512M", CPU: 1.5, PIDs: 128, NoFile: 4096, Nice: 5}, limits)
+	assert.Equal(t, int64(512<<20), limits.MemoryBytes())
+}
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// shellBuiltins lists command words that are not looked up on PATH
+var shellBuiltins = map[string]bool{
+	".": true, ":": true, "cd": true, "eval": true, "exec": true, "export": true,
+	"if": true, "for": true, "while": true, "source": true, "set": true, "true": true,
+	"false": true, "test": true, "[": true, "trap": true, "ulimit": true, "umask": true,
+}
+
+// Lint runs static checks against the environment (files, PATH, ports) for every service, along with
+// the profile and tier declarations, and returns findings. `fuku config validate` reports them as errors
+func (c *Config) Lint() []string {
+	findings := c.LintServices(slices.Sorted(maps.Keys(c.Services)))
+	findings = append(findings, c.lintProfiles()...)
+	findings = append(findings, c.lintTiers()...)
+
+	return findings
+}
+
+// LintServices runs the environment checks for the given services only. Startup logs its findings
+// for the services of the selected profile as warnings, so services that never start stay quiet
+func (c *Config) LintServices(names []string) []string {
+	var (
+		findings []string
+		defined  []string
+	)
+
+	for _, name := range names {
+		service, ok := c.Services[name]
+		if !ok {
+			continue
+		}
+
+		defined = append(defined, name)
+		findings = append(findings, service.lint(name)...)
+	}
+
+	return append(findings, c.lintEndpoints(defined)...)
+}
+
+// lint checks that a service's directory, command binary and shared watch paths exist
+func (s *Service) lint(name string) []string {
+	var findings []string
+
+	dirExists := true
+
+	if !pathExists(s.Dir) {
+		dirExists = false
+
+		findings = append(findings, fmt.Sprintf("service %s: dir '%s' does not exist", name, s.Dir))
+	}
+
+	// a relative path cannot be checked without its dir, while a bare name is looked up on PATH
+	binary := commandBinary(s.Command)
+	relative := strings.Contains(binary, "/") && !filepath.IsAbs(binary)
+
+	if binary != "" && (dirExists || !relative) && !binaryExists(binary, s.Dir) {
+		findings = append(findings, fmt.Sprintf("service %s: command '%s' not found", name, binary))
+	}
+
+	if s.Watch != nil {
+		for _, shared := range s.Watch.Shared {
+			if !pathExists(shared) {
+				findings = append(findings, fmt.Sprintf("service %s: watch.shared path '%s' does not exist", name, shared))
+			}
+		}
+	}
+
+	return findings
+}
+
+// lintEndpoints flags services whose readiness checks target the same host:port
+func (c *Config) lintEndpoints(names []string) []string {
+	owners := make(map[string][]string)
+
+	var keys []string
+
+	for _, name := range names {
+		endpoint := c.Services[name].Readiness.Endpoint()
+		if endpoint == "" {
+			continue
+		}
+
+		key := endpointKey(endpoint)
+		if _, seen := owners[key]; !seen {
+			keys = append(keys, key)
+		}
+
+		owners[key] = append(owners[key], name)
+	}
+
+	var findings []string
+
+	for _, key := range keys {
+		if services := owners[key]; len(services) > 1 {
+			findings = append(findings, fmt.Sprintf("services %s all use readiness address %s", strings.Join(services, ", "), key))
+		}
+	}
+
+	return findings
+}
+
+// lintProfiles flags profile entries that reference services which are not defined
+func (c *Config) lintProfiles() []string {
+	var findings []string
+
+	for _, profile := range slices.Sorted(maps.Keys(c.Profiles)) {
+		listed, _, err := c.listedProfileServices(profile)
+		if err != nil {
+			continue
+		}
+
+		for _, name := range listed {
+			if _, exists := c.Services[name]; !exists {
+				findings = append(findings, fmt.Sprintf("profile '%s' references unknown service '%s'", profile, name))
+			}
+		}
+	}
+
+	return findings
+}
+
+// lintTiers flags declared tiers and the defaults tier when no service uses them
+func (c *Config) lintTiers() []string {
+	used := map[string]bool{Default: false}
+
+	for _, service := range c.Services {
+		tier := service.Tier
+		if tier == "" {
+			tier = Default
+		}
+
+		used[tier] = true
+	}
+
+	candidates := make([]string, 0, len(c.Tiers)+1)
+	for _, tier := range c.Tiers {
+		candidates = append(candidates, tier.Name)
+	}
+
+	if c.Defaults != nil && c.Defaults.Tier != "" && !slices.Contains(candidates, c.Defaults.Tier) {
+		candidates = append(candidates, c.Defaults.Tier)
+	}
+
+	var findings []string
+
+	for _, name := range candidates {
+		if name != "" && !used[name] {
+			findings = append(findings, fmt.Sprintf("tier '%s' is not used by any service", name))
+		}
+	}
+
+	return findings
+}
+
+// endpointKey normalizes loopback and wildcard hosts so equivalent local addresses collide
+func endpointKey(endpoint string) string {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint
+	}
+
+	if host == "localhost" || host == "" {
+		return net.JoinHostPort("localhost", port)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsUnspecified()) {
+		return net.JoinHostPort("localhost", port)
+	}
+
+	return endpoint
+}
+
+// commandBinary returns the program a shell command runs, skipping env assignments,
+// or an empty string when it cannot be determined statically
+func commandBinary(command string) string {
+	if strings.TrimSpace(command) == "" {
+		return "make"
+	}
+
+	for _, field := range strings.Fields(command) {
+		if strings.Contains(field, "=") && !strings.HasPrefix(field, "=") {
+			continue
+		}
+
+		if strings.ContainsAny(field, "$`'\"(){};|&<>*?") || shellBuiltins[field] {
+			return ""
+		}
+
+		return field
+	}
+
+	return ""
+}
+
+// binaryExists reports whether binary is on PATH, or exists relative to dir when it contains a slash
+func binaryExists(binary, dir string) bool {
+	if strings.Contains(binary, "/") {
+		if !filepath.IsAbs(binary) {
+			binary = filepath.Join(dir, binary)
+		}
+
+		return pathExists(binary)
+	}
+
+	_, err := exec.LookPath(binary)
+
+	return err == nil
+}
+
+// pathExists reports whether a file or directory exists at path
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
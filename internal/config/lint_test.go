@@ -0,0 +1,175 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Lint(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      func() *Config
+		expected []string
+	}{
+		{
+			name: "clean config",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Services["api"] = &Service{Dir: "api", Command: "sh -c 'go run .'"}
+
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "missing dir still looks up bare command on PATH",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Services["api"] = &Service{Dir: "missing", Command: "does-not-exist-binary"}
+
+				return cfg
+			},
+			expected: []string{"service api: dir 'missing' does not exist", "service api: command 'does-not-exist-binary' not found"},
+		},
+		{
+			name: "missing dir skips relative command lookup",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Services["api"] = &Service{Dir: "missing", Command: "./run.sh"}
+
+				return cfg
+			},
+			expected: []string{"service api: dir 'missing' does not exist"},
+		},
+		{
+			name: "command not on PATH",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Services["api"] = &Service{Dir: "api", Command: "PORT=8080 fuku-lint-missing-binary --flag"}
+
+				return cfg
+			},
+			expected: []string{"service api: command 'fuku-lint-missing-binary' not found"},
+		},
+		{
+			name: "relative command resolved against service dir",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Services["api"] = &Service{Dir: "api", Command: "./run.sh"}
+				cfg.Services["web"] = &Service{Dir: "api", Command: "./missing.sh"}
+
+				return cfg
+			},
+			expected: []string{"service web: command './missing.sh' not found"},
+		},
+		{
+			name: "missing shared watch path",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Services["api"] = &Service{Dir: "api", Command: "sh", Watch: &Watch{Shared: []string{"pkg", "lib"}}}
+
+				return cfg
+			},
+			expected: []string{"service api: watch.shared path 'lib' does not exist"},
+		},
+		{
+			name: "readiness endpoints collide across loopback spellings",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Services["api"] = &Service{Dir: "api", Command: "sh", Readiness: &Readiness{Type: TypeHTTP, URL: "http://localhost:8080/health"}}
+				cfg.Services["web"] = &Service{Dir: "api", Command: "sh", Readiness: &Readiness{Type: TypeTCP, Address: "127.0.0.1:8080"}}
+				cfg.Services["db"] = &Service{Dir: "api", Command: "sh", Readiness: &Readiness{Type: TypeTCP, Address: "localhost:5432"}}
+
+				return cfg
+			},
+			expected: []string{"services api, web all use readiness address localhost:8080"},
+		},
+		{
+			name: "profile references unknown service",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Services["api"] = &Service{Dir: "api", Command: "sh"}
+				cfg.Profiles["backend"] = []any{"api", "db"}
+
+				return cfg
+			},
+			expected: []string{"profile 'backend' references unknown service 'db'"},
+		},
+		{
+			name: "default tier used by no service",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Defaults = &ServiceDefaults{Tier: "platform"}
+				cfg.Services["api"] = &Service{Dir: "api", Command: "sh", Tier: "edge"}
+
+				return cfg
+			},
+			expected: []string{"tier 'platform' is not used by any service"},
+		},
+		{
+			name: "declared tier used by no service",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Tiers = []Tier{{Name: "foundation"}, {Name: "edge"}}
+				cfg.Services["api"] = &Service{Dir: "api", Command: "sh", Tier: "edge"}
+
+				return cfg
+			},
+			expected: []string{"tier 'foundation' is not used by any service"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+
+			require.NoError(t, os.MkdirAll("api", 0755))
+			require.NoError(t, os.MkdirAll("pkg", 0755))
+			require.NoError(t, os.WriteFile("api/run.sh", []byte("#!/bin/sh\n"), 0755))
+
+			assert.Equal(t, tt.expected, tt.cfg().Lint())
+		})
+	}
+}
+
+func Test_LintServices(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	require.NoError(t, os.MkdirAll("api", 0755))
+
+	cfg := DefaultConfig()
+	cfg.Tiers = []Tier{{Name: "foundation"}}
+	cfg.Profiles["backend"] = []any{"api", "db"}
+	cfg.Services["api"] = &Service{Dir: "api", Command: "sh", Readiness: &Readiness{Type: TypeTCP, Address: "localhost:8080"}}
+	cfg.Services["web"] = &Service{Dir: "api", Command: "sh", Readiness: &Readiness{Type: TypeTCP, Address: "localhost:8080"}}
+	cfg.Services["worker"] = &Service{Dir: "missing", Command: "sh"}
+
+	assert.Empty(t, cfg.LintServices([]string{"api", "db"}))
+	assert.Equal(t, []string{"services api, web all use readiness address localhost:8080"}, cfg.LintServices([]string{"api", "web"}))
+	assert.Equal(t, []string{"service worker: dir 'missing' does not exist"}, cfg.LintServices([]string{"worker"}))
+}
+
+func Test_CommandBinary(t *testing.T) {
+	tests := []struct {
+		command  string
+		expected string
+	}{
+		{command: "", expected: "make"},
+		{command: "go run ./cmd", expected: "go"},
+		{command: "GO_ENV=dev PORT=1 air -c .air.toml", expected: "air"},
+		{command: "./bin/server --port 80", expected: "./bin/server"},
+		{command: "cd web && npm start", expected: ""},
+		{command: "$HOME/bin/tool", expected: ""},
+		{command: "'quoted binary'", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			assert.Equal(t, tt.expected, commandBinary(tt.command))
+		})
+	}
+}
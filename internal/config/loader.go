@@ -8,6 +8,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
 
 	"fuku/internal/app/errors"
 )
@@ -25,12 +26,7 @@ func Load() (*Config, *Topology, error) {
 		return cfg, DefaultTopology(), nil
 	}
 
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, nil, fmt.Errorf("%w: %w", errors.ErrFailedToReadConfig, err)
-	}
-
-	data, err = applyOverride(filePath, data)
+	data, err := composeConfig(filePath, true)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -47,9 +43,9 @@ func LoadFromFile(path string) (*Config, *Topology, error) {
 		return nil, nil, err
 	}
 
-	data, err := os.ReadFile(filePath)
+	data, err := composeConfig(filePath, false)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%w: %w", errors.ErrFailedToReadConfig, err)
+		return nil, nil, err
 	}
 
 	return parseConfig(cfg, data)
@@ -109,28 +105,83 @@ func resolveDefaultConfig() (string, error) {
 	return "", nil
 }
 
-// applyOverride resolves an override file next to basePath and merges it into data
-func applyOverride(basePath string, data []byte) ([]byte, error) {
+// readSource reads a config source file as-is
+func readSource(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToReadConfig, err)
+	}
+
+	return data, nil
+}
+
+// composeConfig reads a config file, expands its includes and optionally merges the override file
+func composeConfig(path string, withOverride bool) ([]byte, error) {
+	doc, err := composeDocument(path, withOverride, &sourceSet{})
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Kind == 0 {
+		return nil, nil
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	return out, nil
+}
+
+// composeDocument builds the composed YAML document of a config file, registering every file read in sources
+func composeDocument(path string, withOverride bool, sources *sourceSet) (*yaml.Node, error) {
+	doc, err := sources.read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveIncludes(path, doc, sources); err != nil {
+		return nil, err
+	}
+
+	if !withOverride {
+		return doc, nil
+	}
+
+	return applyOverride(path, doc, sources)
+}
+
+// applyOverride resolves an override file next to basePath and merges it into doc
+func applyOverride(basePath string, doc *yaml.Node, sources *sourceSet) (*yaml.Node, error) {
 	overridePath, err := resolveOverrideFile(basePath)
 	if err != nil {
 		return nil, err
 	}
 
 	if overridePath == "" {
-		return data, nil
+		return doc, nil
 	}
 
-	overrideData, err := os.ReadFile(overridePath)
+	data, err := readSource(overridePath)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToReadConfig, err)
+		return nil, err
 	}
 
-	merged, err := mergeYAML(data, overrideData)
+	overrideDoc, err := parseOverride(sources, overridePath, doc, data)
 	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errors.ErrFailedToParseConfig, overridePath, err)
+	}
+
+	if err := resolveIncludes(overridePath, overrideDoc, sources); err != nil {
 		return nil, err
 	}
 
-	return merged, nil
+	if merged := mergeDocuments(doc, overrideDoc); merged != nil {
+		return merged, nil
+	}
+
+	return doc, nil
 }
 
 // resolveOverrideFile finds an override config file in the same directory as the base config
@@ -183,6 +234,16 @@ func fileExists(path string) (bool, error) {
 
 // parseConfig runs the config pipeline on raw YAML bytes
 func parseConfig(cfg *Config, data []byte) (*Config, *Topology, error) {
+	data, err := interpolateYAML(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err = resolveExtends(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	topology, err := parseTierOrder(data)
 	if err != nil {
 		return nil, nil, errors.ErrFailedToParseConfig
@@ -199,6 +260,18 @@ func parseConfig(cfg *Config, data []byte) (*Config, *Topology, error) {
 		return nil, nil, errors.ErrFailedToParseConfig
 	}
 
+	if err := cfg.applyEnvMaps(data); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	if err := cfg.applyTiers(data); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	if err := cfg.applyRestarts(data); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
 	cfg.ApplyDefaults()
 	cfg.normalizeTiers()
 
@@ -331,6 +331,32 @@ retry:
 	}
 }
 
+func Test_Load_ServiceRetryZeroValues(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	data := `version: 1
+retry:
+  attempts: 3
+  max_backoff: 5s
+  jitter: 0.1
+services:
+  api:
+    dir: .
+    retry:
+      max_backoff: 0s
+      jitter: 0
+`
+	require.NoError(t, os.WriteFile("fuku.yaml", []byte(data), 0644))
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+
+	policy := cfg.RetryPolicy("api")
+	assert.Equal(t, 3, policy.Attempts)
+	assert.Equal(t, time.Duration(0), policy.MaxBackoff)
+	assert.Zero(t, policy.Jitter)
+}
+
 func Test_Load_LogsConfig(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -1162,3 +1188,15 @@ func Test_ResolveExplicitConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_Load_DependsOn(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	content := "version: 1\nservices:\n  db:\n    dir: ./db\n  api:\n    dir: ./api\n    depends_on: [db]\n"
+	require.NoError(t, os.WriteFile(ConfigFile, []byte(content), 0644))
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db"}, cfg.Services["api"].DependsOn)
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"slices"
 
@@ -11,45 +12,52 @@ import (
 
 // mergeYAML deep-merges override YAML bytes on top of base YAML bytes and returns the merged result
 func mergeYAML(base, override []byte) ([]byte, error) {
-	var baseDoc yaml.Node
-	if err := yaml.Unmarshal(base, &baseDoc); err != nil {
+	sources := &sourceSet{}
+
+	baseDoc, err := sources.parse("base", base)
+	if err != nil {
 		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
 	}
 
-	overDoc, err := parseOverride(&baseDoc, override)
+	overDoc, err := parseOverride(sources, "override", baseDoc, override)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
 	}
 
-	if overDoc.Kind != yaml.DocumentNode || len(overDoc.Content) == 0 {
+	merged := mergeDocuments(baseDoc, overDoc)
+	if merged == nil {
 		return base, nil
 	}
 
-	if baseDoc.Kind != yaml.DocumentNode || len(baseDoc.Content) == 0 {
-		resolveAliases(overDoc.Content[0])
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
 
-		out, err := yaml.Marshal(&overDoc)
-		if err != nil {
-			return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
-		}
+	return out, nil
+}
 
-		return out, nil
+// mergeDocuments deep-merges the override document on top of base and returns the result,
+// or nil when the override leaves base unchanged
+func mergeDocuments(base, override *yaml.Node) *yaml.Node {
+	if override.Kind != yaml.DocumentNode || len(override.Content) == 0 {
+		return nil
 	}
 
-	merged := mergeNodes(baseDoc.Content[0], overDoc.Content[0])
+	if base.Kind != yaml.DocumentNode || len(base.Content) == 0 {
+		resolveAliases(override.Content[0])
+		return override
+	}
+
+	merged := mergeNodes(base.Content[0], override.Content[0])
 	if merged == nil {
-		return base, nil
+		return nil
 	}
 
 	resolveAliases(merged)
-	baseDoc.Content[0] = merged
+	base.Content[0] = merged
 
-	out, err := yaml.Marshal(&baseDoc)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
-	}
-
-	return out, nil
+	return base
 }
 
 // mergeNodes recursively merges two yaml.Node trees
@@ -400,35 +408,66 @@ func buildKeyIndex(m *yaml.Node) map[string]int {
 	return index
 }
 
-// parseOverride parses override YAML, falling back to injecting base anchor definitions if standalone parsing fails
-func parseOverride(baseDoc *yaml.Node, override []byte) (yaml.Node, error) {
-	var overDoc yaml.Node
-
-	err := yaml.Unmarshal(override, &overDoc)
+// parseOverride parses override YAML, falling back to injecting base anchor definitions if standalone parsing fails.
+// The injected definitions are dropped again after parsing so they are not merged onto themselves
+func parseOverride(sources *sourceSet, path string, baseDoc *yaml.Node, override []byte) (*yaml.Node, error) {
+	overDoc, err := sources.parse(path, override)
 	if err == nil {
 		return overDoc, nil
 	}
 
-	anchorDefs := extractAnchorDefs(baseDoc)
-	if len(anchorDefs) == 0 {
-		return yaml.Node{}, err
+	anchors := extractAnchorDefs(baseDoc)
+	if anchors == nil {
+		return nil, err
+	}
+
+	defs, marshalErr := yaml.Marshal(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{anchors}})
+	if marshalErr != nil {
+		return nil, err
 	}
 
-	combined := make([]byte, 0, len(anchorDefs)+1+len(override))
-	combined = append(combined, anchorDefs...)
+	combined := make([]byte, 0, len(defs)+1+len(override))
+	combined = append(combined, defs...)
 	combined = append(combined, '\n')
 	combined = append(combined, override...)
 
 	var combinedDoc yaml.Node
 	if err := yaml.Unmarshal(combined, &combinedDoc); err != nil {
-		return yaml.Node{}, err
+		return nil, err
 	}
 
-	return combinedDoc, nil
+	if doc := combinedDoc.Content[0]; doc.Kind == yaml.MappingNode && len(doc.Content) >= len(anchors.Content) {
+		injected := make(map[string]*yaml.Node)
+		collectAnchors(&yaml.Node{Kind: yaml.MappingNode, Content: doc.Content[:len(anchors.Content)]}, injected)
+
+		defined := make(map[string]*yaml.Node)
+		collectAnchors(baseDoc, defined)
+
+		doc.Content = doc.Content[len(anchors.Content):]
+		relinkAliases(doc, injected, defined)
+	}
+
+	shiftLines(&combinedDoc, -(bytes.Count(defs, []byte("\n")) + 1))
+	sources.add(path, &combinedDoc, bytes.Count(override, []byte("\n"))+1)
+
+	return &combinedDoc, nil
 }
 
-// extractAnchorDefs marshals top-level entries from the base document that contain anchor definitions
-func extractAnchorDefs(baseDoc *yaml.Node) []byte {
+// relinkAliases points aliases at injected anchor copies back to the base nodes they were copied from
+func relinkAliases(node *yaml.Node, injected, defined map[string]*yaml.Node) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil && injected[node.Value] == node.Alias {
+		if target, ok := defined[node.Value]; ok {
+			node.Alias = target
+		}
+	}
+
+	for _, child := range node.Content {
+		relinkAliases(child, injected, defined)
+	}
+}
+
+// extractAnchorDefs collects the top-level entries of the base document that contain anchor definitions
+func extractAnchorDefs(baseDoc *yaml.Node) *yaml.Node {
 	if baseDoc.Kind != yaml.DocumentNode || len(baseDoc.Content) == 0 {
 		return nil
 	}
@@ -453,17 +492,7 @@ func extractAnchorDefs(baseDoc *yaml.Node) []byte {
 		return nil
 	}
 
-	wrapper := &yaml.Node{
-		Kind:    yaml.DocumentNode,
-		Content: []*yaml.Node{anchorMap},
-	}
-
-	out, err := yaml.Marshal(wrapper)
-	if err != nil {
-		return nil
-	}
-
-	return out
+	return anchorMap
 }
 
 // hasAnchors reports whether a node or any of its descendants has a YAML anchor
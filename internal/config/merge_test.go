@@ -29,6 +29,18 @@ func Test_MergeYAML(t *testing.T) {
 			override: "services:\n  api:\n    command: dlv debug ./cmd/main.go\n",
 			expected: "services:\n  api:\n    dir: services/api\n    tier: foundation\n    command: dlv debug ./cmd/main.go\n",
 		},
+		{
+			name:     "env map override wins per key",
+			base:     "services:\n  api:\n    env:\n      LOG_LEVEL: info\n      PORT: \"8080\"\n",
+			override: "services:\n  api:\n    env:\n      LOG_LEVEL: debug\n      DEBUG: \"1\"\n",
+			expected: "services:\n  api:\n    env:\n      LOG_LEVEL: debug\n      PORT: \"8080\"\n      DEBUG: \"1\"\n",
+		},
+		{
+			name:     "env_file lists concatenate in load order",
+			base:     "services:\n  api:\n    env_file:\n      - .env\n",
+			override: "services:\n  api:\n    env_file:\n      - .env.local\n",
+			expected: "services:\n  api:\n    env_file:\n      - .env\n      - .env.local\n",
+		},
 		{
 			name:     "array concatenation without deduplication",
 			base:     "services:\n  api:\n    watch:\n      include:\n        - '*.go'\n",
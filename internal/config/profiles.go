@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"fuku/internal/app/errors"
+)
+
+// ProfileServices returns the services in a profile, merging the top-level profiles
+// map with every service that declares membership through its own profiles field
+func (c *Config) ProfileServices(profile string) ([]string, error) {
+	listed, defined, err := c.listedProfileServices(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := c.declaredProfileServices(profile)
+
+	if !defined && len(declared) == 0 {
+		return nil, fmt.Errorf("%w: %s", errors.ErrProfileNotFound, profile)
+	}
+
+	seen := make(map[string]bool, len(listed)+len(declared))
+	result := make([]string, 0, len(listed)+len(declared))
+
+	for _, name := range slices.Concat(listed, declared) {
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+
+	return result, nil
+}
+
+// listedProfileServices returns the services listed for a profile in the top-level profiles map
+func (c *Config) listedProfileServices(profile string) ([]string, bool, error) {
+	profileConfig, exists := c.Profiles[profile]
+	if !exists {
+		return nil, false, nil
+	}
+
+	switch v := profileConfig.(type) {
+	case string:
+		if v == "*" {
+			return slices.Collect(maps.Keys(c.Services)), true, nil
+		}
+
+		return []string{v}, true, nil
+	case []any:
+		services := make([]string, 0, len(v))
+
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, true, fmt.Errorf("%w: profile '%s' contains non-string entry", errors.ErrUnsupportedProfileFormat, profile)
+			}
+
+			services = append(services, str)
+		}
+
+		return services, true, nil
+	default:
+		return nil, true, fmt.Errorf("%w: %s", errors.ErrUnsupportedProfileFormat, profile)
+	}
+}
+
+// declaredProfileServices returns the sorted services whose profiles field includes the profile
+func (c *Config) declaredProfileServices(profile string) []string {
+	var services []string
+
+	for name, service := range c.Services {
+		if slices.Contains(service.Profiles, profile) {
+			services = append(services, name)
+		}
+	}
+
+	slices.Sort(services)
+
+	return services
+}
+
+// profileWarnings reports profiles whose top-level list and service-side declarations disagree
+func (c *Config) profileWarnings() []string {
+	names := make(map[string]bool, len(c.Profiles))
+
+	for name := range c.Profiles {
+		names[name] = true
+	}
+
+	for _, service := range c.Services {
+		for _, name := range service.Profiles {
+			names[name] = true
+		}
+	}
+
+	var warnings []string
+
+	for _, profile := range slices.Sorted(maps.Keys(names)) {
+		if c.Profiles[profile] == "*" {
+			continue
+		}
+
+		listed, defined, err := c.listedProfileServices(profile)
+		if err != nil || !defined {
+			continue
+		}
+
+		declared := c.declaredProfileServices(profile)
+		if len(declared) == 0 {
+			continue
+		}
+
+		listed = slices.Sorted(slices.Values(listed))
+		listed = slices.Compact(listed)
+
+		if slices.Equal(listed, declared) {
+			continue
+		}
+
+		members, err := c.ProfileServices(profile)
+		if err != nil {
+			continue
+		}
+
+		slices.Sort(members)
+
+		warnings = append(warnings, fmt.Sprintf(
+			"profile '%s' is listed as [%s] but services declare [%s]; using [%s]",
+			profile, strings.Join(listed, ", "), strings.Join(declared, ", "), strings.Join(members, ", "),
+		))
+	}
+
+	return warnings
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_ProfileServices(t *testing.T) {
+	tests := []struct {
+		name        string
+		services    map[string]*Service
+		profiles    map[string]any
+		profile     string
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:        "profile not defined anywhere",
+			services:    map[string]*Service{"api": {}},
+			profiles:    map[string]any{},
+			profile:     "backend",
+			expectedErr: errors.ErrProfileNotFound,
+		},
+		{
+			name:     "top-level list only",
+			services: map[string]*Service{"api": {}, "web": {}},
+			profiles: map[string]any{"backend": []any{"api"}},
+			profile:  "backend",
+			expected: []string{"api"},
+		},
+		{
+			name:     "service declarations only",
+			services: map[string]*Service{"api": {Profiles: []string{"backend"}}, "db": {Profiles: []string{"backend"}}, "web": {}},
+			profiles: map[string]any{},
+			profile:  "backend",
+			expected: []string{"api", "db"},
+		},
+		{
+			name:     "both sources are merged without duplicates",
+			services: map[string]*Service{"api": {Profiles: []string{"backend"}}, "db": {Profiles: []string{"backend"}}, "web": {}},
+			profiles: map[string]any{"backend": []any{"web", "api"}},
+			profile:  "backend",
+			expected: []string{"web", "api", "db"},
+		},
+		{
+			name:     "single string entry",
+			services: map[string]*Service{"api": {}},
+			profiles: map[string]any{"single": "api"},
+			profile:  "single",
+			expected: []string{"api"},
+		},
+		{
+			name:        "non-string entry",
+			services:    map[string]*Service{"api": {}},
+			profiles:    map[string]any{"invalid": []any{"api", 1}},
+			profile:     "invalid",
+			expectedErr: errors.ErrUnsupportedProfileFormat,
+		},
+		{
+			name:        "unsupported format",
+			services:    map[string]*Service{"api": {}},
+			profiles:    map[string]any{"bad": 1},
+			profile:     "bad",
+			expectedErr: errors.ErrUnsupportedProfileFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Services: tt.services, Profiles: tt.profiles}
+
+			services, err := cfg.ProfileServices(tt.profile)
+
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, services)
+		})
+	}
+}
+
+func Test_ProfileServices_Wildcard(t *testing.T) {
+	cfg := &Config{
+		Services: map[string]*Service{"api": {}, "web": {}},
+		Profiles: map[string]any{"all": "*"},
+	}
+
+	services, err := cfg.ProfileServices("all")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"api", "web"}, services)
+}
+
+func Test_Warnings_ProfileDisagreement(t *testing.T) {
+	tests := []struct {
+		name     string
+		services map[string]*Service
+		profiles map[string]any
+		expected []string
+	}{
+		{
+			name:     "no declarations",
+			services: map[string]*Service{"api": {}, "web": {}},
+			profiles: map[string]any{"default": "*", "backend": []any{"api"}},
+			expected: nil,
+		},
+		{
+			name:     "wildcard profile never disagrees",
+			services: map[string]*Service{"api": {Profiles: []string{"default"}}, "web": {}},
+			profiles: map[string]any{"default": "*"},
+			expected: nil,
+		},
+		{
+			name:     "matching sources",
+			services: map[string]*Service{"api": {Profiles: []string{"backend"}}, "web": {}},
+			profiles: map[string]any{"backend": []any{"api"}},
+			expected: nil,
+		},
+		{
+			name:     "declared only is not a disagreement",
+			services: map[string]*Service{"api": {Profiles: []string{"backend"}}},
+			profiles: map[string]any{},
+			expected: nil,
+		},
+		{
+			name:     "sources disagree",
+			services: map[string]*Service{"api": {}, "db": {Profiles: []string{"backend"}}},
+			profiles: map[string]any{"backend": []any{"api"}},
+			expected: []string{"profile 'backend' is listed as [api] but services declare [db]; using [api, db]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Services: tt.services, Profiles: tt.profiles}
+
+			assert.Equal(t, tt.expected, cfg.Warnings())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+)
+
+// projectIDSize is the number of hex characters of the project root hash used as the project ID
+const projectIDSize = 16
+
+// ProjectID identifies the current project by a hash of its root directory, so per-project state
+// such as session files and sockets of different projects never collide
+func ProjectID() (string, error) {
+	root, err := ProjectRoot()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(root))
+
+	return hex.EncodeToString(sum[:])[:projectIDSize], nil
+}
+
+// ProjectRoot returns the nearest directory from the working directory upwards that holds a config
+// file, so commands run from a subdirectory find the instance started at the root. Without a config
+// file anywhere above, the working directory is the root
+func ProjectRoot() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for dir := cwd; ; dir = filepath.Dir(dir) {
+		for _, name := range []string{ConfigFile, ConfigFileAlt} {
+			if exists, _ := fileExists(filepath.Join(dir, name)); exists {
+				return dir, nil
+			}
+		}
+
+		if filepath.Dir(dir) == dir {
+			return cwd, nil
+		}
+	}
+}
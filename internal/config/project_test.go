@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProjectRoot(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	nested := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ConfigFileAlt), []byte("version: 1\n"), 0600))
+
+	t.Chdir(root)
+
+	atRoot, err := ProjectRoot()
+	require.NoError(t, err)
+	assert.Equal(t, root, atRoot)
+
+	rootID, err := ProjectID()
+	require.NoError(t, err)
+
+	t.Chdir(nested)
+
+	fromNested, err := ProjectRoot()
+	require.NoError(t, err)
+	assert.Equal(t, root, fromNested)
+
+	nestedID, err := ProjectID()
+	require.NoError(t, err)
+	assert.Equal(t, rootID, nestedID)
+}
+
+func Test_ProjectRoot_NoConfigFile(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	t.Chdir(dir)
+
+	root, err := ProjectRoot()
+	require.NoError(t, err)
+	assert.Equal(t, dir, root)
+}
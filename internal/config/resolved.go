@@ -0,0 +1,250 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"fuku/internal/app/errors"
+)
+
+// SourceDefault is reported for values that come from built-in defaults rather than a config file
+const SourceDefault = "default"
+
+// RedactedValue replaces secrets in printed configuration
+const RedactedValue = "<redacted>"
+
+// Resolved is a fully loaded configuration together with the origin of each value
+type Resolved struct {
+	Config   *Config
+	Topology *Topology
+	sources  map[string]string
+}
+
+// Resolve loads configuration the same way as Load (or LoadFromFile when path is set)
+// while recording the file and line every value came from
+func Resolve(path string) (*Resolved, error) {
+	cfg := initConfig()
+
+	filePath, withOverride := path, path == ""
+
+	var err error
+
+	if withOverride {
+		filePath, err = resolveDefaultConfig()
+	} else {
+		filePath, err = resolveExplicitConfig(path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if filePath == "" {
+		return &Resolved{Config: cfg, Topology: DefaultTopology(), sources: map[string]string{}}, nil
+	}
+
+	files := &sourceSet{}
+
+	doc, err := composeDocument(filePath, withOverride, files)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if doc.Kind != 0 {
+		if data, err = yaml.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+		}
+	}
+
+	cfg, topology, err := parseConfig(cfg, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := extendDocument(doc); err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string]string)
+	collectSources(doc, "", files, sources)
+
+	return &Resolved{Config: cfg, Topology: topology, sources: sources}, nil
+}
+
+// Source returns the file:line a dotted config path was read from, or SourceDefault
+func (r *Resolved) Source(path string) string {
+	path = strings.ToLower(path)
+
+	if source, ok := r.sources[path]; ok {
+		return source
+	}
+
+	if rest, ok := strings.CutPrefix(path, "services."); ok {
+		if _, field, found := strings.Cut(rest, "."); found {
+			if source, ok := r.sources["defaults."+field]; ok {
+				return source + " (defaults)"
+			}
+		}
+	}
+
+	return SourceDefault
+}
+
+// RedactSecrets masks secret values such as the server auth token so the config can be printed safely
+func (r *Resolved) RedactSecrets() {
+	if r.Config.Server.Auth.Token != "" {
+		r.Config.Server.Auth.Token = RedactedValue
+	}
+}
+
+// YAML renders the resolved configuration, annotating each value with its source when explain is set
+func (r *Resolved) YAML(explain bool) ([]byte, error) {
+	node, err := r.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	if explain {
+		walkValues(node, "", func(path string, leaf *yaml.Node) {
+			leaf.LineComment = r.Source(path)
+		})
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(node); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// JSON renders the resolved configuration, wrapping it with a path-to-source map when explain is set
+func (r *Resolved) JSON(explain bool) ([]byte, error) {
+	node, err := r.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	if explain {
+		sources := make(map[string]string)
+
+		walkValues(node, "", func(path string, _ *yaml.Node) {
+			sources[path] = r.Source(path)
+		})
+
+		value = map[string]any{"config": value, "sources": sources}
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// encode converts the resolved config into a YAML node tree
+func (r *Resolved) encode() (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(r.Config); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrFailedToParseConfig, err)
+	}
+
+	return &node, nil
+}
+
+// walkValues calls fn for every scalar value in node with its dotted path
+func walkValues(node *yaml.Node, path string, fn func(path string, leaf *yaml.Node)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			walkValues(child, path, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			walkValues(node.Content[i+1], joinPath(path, node.Content[i].Value), fn)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			walkValues(child, path+"["+strconv.Itoa(i)+"]", fn)
+		}
+	case yaml.ScalarNode:
+		fn(path, node)
+	}
+}
+
+// collectSources records the file and line each scalar under node was read from, keyed by its dotted path
+func collectSources(node *yaml.Node, path string, files *sourceSet, sources map[string]string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			collectSources(child, path, files, sources)
+		}
+	case yaml.MappingNode:
+		node = flattenMergeKeys(node)
+
+		for i := 0; i < len(node.Content); i += 2 {
+			collectSources(node.Content[i+1], joinPath(path, strings.ToLower(node.Content[i].Value)), files, sources)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			collectSources(child, path+"["+strconv.Itoa(i)+"]", files, sources)
+		}
+	case yaml.ScalarNode:
+		if source, ok := files.position(node.Line); ok {
+			sources[path] = source
+
+			if field := shorthandField(path); field != "" {
+				sources[joinPath(path, field)] = source
+			}
+		}
+	}
+}
+
+// shorthandField returns the field a bare scalar sets when it stands in for a mapping, as in
+// restart: on-failure and tiers: [foundation], or an empty string for any other path
+func shorthandField(path string) string {
+	parts := strings.Split(path, ".")
+
+	switch {
+	case len(parts) == 1 && strings.HasPrefix(path, "tiers["):
+		return "name"
+	case len(parts) == 3 && parts[0] == "services" && parts[2] == "restart":
+		return "policy"
+	default:
+		return ""
+	}
+}
+
+// joinPath appends a key to a dotted config path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
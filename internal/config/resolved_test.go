@@ -0,0 +1,215 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Resolve_Sources(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	base := `version: 1
+x-readiness: &readiness
+  type: log
+  pattern: ready
+defaults:
+  tier: platform
+include: [extra.yaml]
+services:
+  api:
+    dir: .
+    command: make run
+    readiness:
+      <<: *readiness
+      timeout: 10s
+    watch:
+      include: ["*.go", "*.mod"]
+`
+	override := "services:\n  api:\n    command: make debug\n    watch:\n      include: [\"*.templ\"]\n"
+	extra := "services:\n  worker:\n    dir: .\n"
+
+	require.NoError(t, os.WriteFile(ConfigFile, []byte(base), 0644))
+	require.NoError(t, os.WriteFile(OverrideConfigFile, []byte(override), 0644))
+	require.NoError(t, os.WriteFile("extra.yaml", []byte(extra), 0644))
+
+	resolved, err := Resolve("")
+	require.NoError(t, err)
+	require.Contains(t, resolved.Config.Services, "worker")
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "services.api.command", expected: "fuku.override.yaml:3"},
+		{path: "services.api.dir", expected: "fuku.yaml:10"},
+		{path: "services.api.readiness.type", expected: "fuku.yaml:3"},
+		{path: "services.api.readiness.timeout", expected: "fuku.yaml:14"},
+		{path: "services.api.watch.include[1]", expected: "fuku.yaml:16"},
+		{path: "services.api.watch.include[2]", expected: "fuku.override.yaml:5"},
+		{path: "services.api.tier", expected: "fuku.yaml:6 (defaults)"},
+		{path: "services.worker.dir", expected: "extra.yaml:3"},
+		{path: "concurrency.workers", expected: SourceDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolved.Source(tt.path))
+		})
+	}
+}
+
+func Test_Resolve_SourcesThroughExtendsAndAnchors(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	base := `version: 1
+x-watch: &watch
+  include: ["*.go"]
+templates:
+  go:
+    command: go run .
+services:
+  api:
+    extends: go
+    dir: .
+`
+	override := "services:\n  api:\n    watch: *watch\n"
+
+	require.NoError(t, os.WriteFile(ConfigFile, []byte(base), 0644))
+	require.NoError(t, os.WriteFile(OverrideConfigFile, []byte(override), 0644))
+
+	resolved, err := Resolve("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "fuku.yaml:6", resolved.Source("services.api.command"))
+	assert.Equal(t, "fuku.yaml:10", resolved.Source("services.api.dir"))
+	assert.Equal(t, "fuku.yaml:3", resolved.Source("services.api.watch.include[0]"))
+}
+
+func Test_Resolve_SourcesOfShorthandForms(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	base := `version: 1
+tiers: [foundation, {name: edge, timeout: 30s}]
+services:
+  db:
+    dir: .
+    tier: foundation
+    restart: on-failure
+  api:
+    dir: .
+    tier: edge
+    restart:
+      policy: always
+`
+	require.NoError(t, os.WriteFile(ConfigFile, []byte(base), 0644))
+
+	resolved, err := Resolve("")
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "tiers[0].name", expected: "fuku.yaml:2"},
+		{path: "tiers[1].name", expected: "fuku.yaml:2"},
+		{path: "services.db.restart.policy", expected: "fuku.yaml:7"},
+		{path: "services.api.restart.policy", expected: "fuku.yaml:12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolved.Source(tt.path))
+		})
+	}
+
+	out, err := resolved.YAML(true)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "policy: on-failure # fuku.yaml:7")
+	assert.Contains(t, string(out), "name: foundation # fuku.yaml:2")
+}
+
+func Test_Resolved_RedactSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		expected string
+	}{
+		{name: "token is redacted", token: "s3cr3t", expected: RedactedValue},
+		{name: "empty token stays empty", token: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Server.Auth.Token = tt.token
+
+			resolved := &Resolved{Config: cfg}
+			resolved.RedactSecrets()
+
+			assert.Equal(t, tt.expected, resolved.Config.Server.Auth.Token)
+		})
+	}
+}
+
+func Test_Resolve_ExplicitFileSkipsOverride(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	require.NoError(t, os.WriteFile(ConfigFile, []byte("version: 1\nservices:\n  api:\n    dir: .\n    command: make run\n"), 0644))
+	require.NoError(t, os.WriteFile(OverrideConfigFile, []byte("services:\n  api:\n    command: make debug\n"), 0644))
+
+	resolved, err := Resolve(ConfigFile)
+	require.NoError(t, err)
+	assert.Equal(t, "make run", resolved.Config.Services["api"].Command)
+	assert.Equal(t, "fuku.yaml:5", resolved.Source("services.api.command"))
+}
+
+func Test_Resolve_NoConfigFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	resolved, err := Resolve("")
+	require.NoError(t, err)
+	assert.Empty(t, resolved.Config.Services)
+	assert.Equal(t, SourceDefault, resolved.Source("logging.level"))
+}
+
+func Test_Resolved_Output(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	require.NoError(t, os.WriteFile(ConfigFile, []byte("version: 1\nservices:\n  api:\n    dir: .\n    env:\n      API_KEY: secret\n"), 0644))
+
+	resolved, err := Resolve("")
+	require.NoError(t, err)
+
+	t.Run("yaml", func(t *testing.T) {
+		out, err := resolved.YAML(false)
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "API_KEY: secret\n")
+		assert.NotContains(t, string(out), "#")
+		assert.NotContains(t, string(out), "sentrydsn")
+	})
+
+	t.Run("yaml explain", func(t *testing.T) {
+		out, err := resolved.YAML(true)
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "API_KEY: secret # fuku.yaml:6")
+		assert.Contains(t, string(out), "level: info # default")
+	})
+
+	t.Run("json explain", func(t *testing.T) {
+		out, err := resolved.JSON(true)
+		require.NoError(t, err)
+
+		var decoded struct {
+			Config  map[string]any    `json:"config"`
+			Sources map[string]string `json:"sources"`
+		}
+
+		require.NoError(t, json.Unmarshal(out, &decoded))
+		assert.Contains(t, decoded.Config, "services")
+		assert.Equal(t, "fuku.yaml:4", decoded.Sources["services.api.dir"])
+	})
+}
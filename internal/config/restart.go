@@ -0,0 +1,143 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"fuku/internal/app/errors"
+)
+
+// Restart policies
+const (
+	RestartNever     = "never"
+	RestartOnFailure = "on-failure"
+	RestartAlways    = "always"
+)
+
+// Restart represents how a service is restarted after it exits unexpectedly
+type Restart struct {
+	Policy      string        `yaml:"policy,omitempty"`
+	MaxRestarts int           `yaml:"max_restarts,omitempty"`
+	Window      time.Duration `yaml:"window,omitempty"`
+	Backoff     time.Duration `yaml:"backoff,omitempty"`
+	MaxBackoff  time.Duration `yaml:"max_backoff,omitempty"`
+}
+
+// UnmarshalYAML accepts either a bare policy name or a mapping with restart settings
+func (r *Restart) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		r.Policy = node.Value
+
+		return nil
+	}
+
+	type plain Restart
+
+	return node.Decode((*plain)(r))
+}
+
+// restartSection holds the restart settings of a service decoded directly from YAML
+type restartSection struct {
+	Restart *Restart `yaml:"restart"`
+}
+
+// restartDocument mirrors the config sections that carry restart settings
+type restartDocument struct {
+	Services map[string]restartSection `yaml:"services"`
+}
+
+// applyRestarts decodes per-service restart settings from raw YAML, since the short
+// form (restart: on-failure) cannot be decoded by viper into a struct
+func (c *Config) applyRestarts(data []byte) error {
+	var doc restartDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	for name, section := range doc.Services {
+		service, exists := c.Services[name]
+		if !exists {
+			service, exists = c.Services[strings.ToLower(name)]
+		}
+
+		if !exists || service == nil {
+			continue
+		}
+
+		service.Restart = section.Restart
+	}
+
+	return nil
+}
+
+// RestartPolicy returns the restart settings for a service, filling in the defaults
+func (c *Config) RestartPolicy(name string) Restart {
+	var policy Restart
+
+	if service, ok := c.Services[name]; ok && service.Restart != nil {
+		policy = *service.Restart
+	}
+
+	if policy.Policy == "" {
+		policy.Policy = RestartNever
+	}
+
+	if policy.MaxRestarts == 0 {
+		policy.MaxRestarts = RestartMaxRestarts
+	}
+
+	if policy.Window == 0 {
+		policy.Window = RestartWindow
+	}
+
+	if policy.Backoff == 0 {
+		policy.Backoff = RestartBackoff
+	}
+
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = RestartMaxBackoff
+	}
+
+	return policy
+}
+
+// Restarts reports whether a process that exited with the given code should be restarted
+func (r Restart) Restarts(exitCode int) bool {
+	switch r.Policy {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return exitCode != 0
+	default:
+		return false
+	}
+}
+
+// Delay returns the wait before the given restart within the window (1 for the first),
+// doubling each time up to max_backoff
+func (r Restart) Delay(restart int) time.Duration {
+	retry := Retry{Backoff: r.Backoff, MaxBackoff: r.MaxBackoff, Multiplier: RestartMultiplier}
+
+	return retry.Delay(restart)
+}
+
+// validate checks the restart policy, limit, window and backoff
+func (r *Restart) validate() error {
+	switch r.Policy {
+	case "", RestartNever, RestartOnFailure, RestartAlways:
+	default:
+		return errors.ErrInvalidRestartPolicy
+	}
+
+	if r.MaxRestarts < 0 {
+		return errors.ErrInvalidMaxRestarts
+	}
+
+	if r.Window < 0 || r.Backoff < 0 || r.MaxBackoff < 0 {
+		return errors.ErrInvalidRestartWindow
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_RestartPolicy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Services["api"] = &Service{}
+	cfg.Services["web"] = &Service{Restart: &Restart{Policy: RestartOnFailure}}
+	cfg.Services["worker"] = &Service{Restart: &Restart{Policy: RestartAlways, MaxRestarts: 2, Window: time.Hour, Backoff: 2 * time.Second, MaxBackoff: time.Minute}}
+
+	defaults := Restart{
+		Policy:      RestartNever,
+		MaxRestarts: RestartMaxRestarts,
+		Window:      RestartWindow,
+		Backoff:     RestartBackoff,
+		MaxBackoff:  RestartMaxBackoff,
+	}
+
+	onFailure := defaults
+	onFailure.Policy = RestartOnFailure
+
+	assert.Equal(t, defaults, cfg.RestartPolicy("api"))
+	assert.Equal(t, onFailure, cfg.RestartPolicy("web"))
+	assert.Equal(t, *cfg.Services["worker"].Restart, cfg.RestartPolicy("worker"))
+	assert.Equal(t, defaults, cfg.RestartPolicy("missing"))
+}
+
+func Test_Restart_Restarts(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		exitCode int
+		expected bool
+	}{
+		{name: "never on failure", policy: RestartNever, exitCode: 1, expected: false},
+		{name: "on-failure on failure", policy: RestartOnFailure, exitCode: 1, expected: true},
+		{name: "on-failure on signal", policy: RestartOnFailure, exitCode: -1, expected: true},
+		{name: "on-failure on clean exit", policy: RestartOnFailure, exitCode: 0, expected: false},
+		{name: "always on clean exit", policy: RestartAlways, exitCode: 0, expected: true},
+		{name: "always on failure", policy: RestartAlways, exitCode: 2, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Restart{Policy: tt.policy}.Restarts(tt.exitCode))
+		})
+	}
+}
+
+func Test_Restart_Delay(t *testing.T) {
+	policy := Restart{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.Delay(1))
+	assert.Equal(t, 2*time.Second, policy.Delay(2))
+	assert.Equal(t, 4*time.Second, policy.Delay(3))
+	assert.Equal(t, 5*time.Second, policy.Delay(4))
+}
+
+func Test_Restart_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		restart Restart
+		error   error
+	}{
+		{name: "empty", restart: Restart{}},
+		{name: "full", restart: Restart{Policy: RestartAlways, MaxRestarts: 3, Window: time.Minute, Backoff: time.Second, MaxBackoff: time.Minute}},
+		{name: "unknown policy", restart: Restart{Policy: "sometimes"}, error: errors.ErrInvalidRestartPolicy},
+		{name: "negative max restarts", restart: Restart{MaxRestarts: -1}, error: errors.ErrInvalidMaxRestarts},
+		{name: "negative window", restart: Restart{Window: -time.Second}, error: errors.ErrInvalidRestartWindow},
+		{name: "negative backoff", restart: Restart{Backoff: -time.Second}, error: errors.ErrInvalidRestartWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.restart.validate()
+			if tt.error == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Load_Restart(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeConfigFile(t, ConfigFile, `version: 1
+services:
+  api:
+    dir: api
+    restart: on-failure
+  worker:
+    dir: worker
+    restart:
+      policy: always
+      max_restarts: 3
+      window: 2m
+      backoff: 2s
+      max_backoff: 1m
+  web:
+    dir: web
+`)
+
+	cfg, _, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, &Restart{Policy: RestartOnFailure}, cfg.Services["api"].Restart)
+	assert.Equal(t, &Restart{Policy: RestartAlways, MaxRestarts: 3, Window: 2 * time.Minute, Backoff: 2 * time.Second, MaxBackoff: time.Minute}, cfg.Services["worker"].Restart)
+	assert.Nil(t, cfg.Services["web"].Restart)
+}
+
+func Test_Load_InvalidRestart(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeConfigFile(t, ConfigFile, `version: 1
+services:
+  api:
+    dir: api
+    restart: sometimes
+`)
+
+	_, _, err := Load()
+	require.ErrorIs(t, err, errors.ErrInvalidRestartPolicy)
+}
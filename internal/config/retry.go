@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"fuku/internal/app/errors"
+)
+
+// retryClasses lists the known error classes and whether each is retried by default
+var retryClasses = map[string]bool{
+	RetryOnPortInUse:        false,
+	RetryOnDirNotFound:      false,
+	RetryOnStartFailed:      true,
+	RetryOnProcessExited:    true,
+	RetryOnReadinessTimeout: true,
+	RetryOnOther:            true,
+}
+
+// RetryPolicy returns the retry settings for a service, with per-service values layered over the global ones
+func (c *Config) RetryPolicy(name string) Retry {
+	policy := c.Retry
+	policy.Errors = maps.Clone(c.Retry.Errors)
+
+	service, ok := c.Services[name]
+	if !ok || service.Retry == nil {
+		return policy
+	}
+
+	service.Retry.apply(&policy)
+
+	return policy
+}
+
+// apply layers the fields set on the override over policy
+func (r *ServiceRetry) apply(policy *Retry) {
+	if r.Attempts != nil {
+		policy.Attempts = *r.Attempts
+	}
+
+	if r.Backoff != nil {
+		policy.Backoff = *r.Backoff
+	}
+
+	if r.MaxBackoff != nil {
+		policy.MaxBackoff = *r.MaxBackoff
+	}
+
+	if r.Multiplier != nil {
+		policy.Multiplier = *r.Multiplier
+	}
+
+	if r.Jitter != nil {
+		policy.Jitter = *r.Jitter
+	}
+
+	if len(r.Errors) > 0 {
+		if policy.Errors == nil {
+			policy.Errors = make(map[string]bool, len(r.Errors))
+		}
+
+		maps.Copy(policy.Errors, r.Errors)
+	}
+}
+
+// Retries reports whether a failure of the given error class should be retried
+func (r Retry) Retries(class string) bool {
+	if retry, ok := r.Errors[class]; ok {
+		return retry
+	}
+
+	if retry, ok := retryClasses[class]; ok {
+		return retry
+	}
+
+	return retryClasses[RetryOnOther]
+}
+
+// Delay returns the wait before the given retry (1 for the first retry), growing by the multiplier
+// up to max_backoff and randomized by up to ±jitter of the delay
+func (r Retry) Delay(retry int) time.Duration {
+	multiplier := max(r.Multiplier, 1)
+
+	delay := float64(r.Backoff) * math.Pow(multiplier, float64(max(retry-1, 0)))
+	if r.MaxBackoff > 0 {
+		delay = min(delay, float64(r.MaxBackoff))
+	}
+
+	if r.Jitter > 0 {
+		delay += delay * r.Jitter * (2*rand.Float64() - 1) //nolint:gosec // jitter does not need a secure source
+	}
+
+	return time.Duration(delay)
+}
+
+// validate checks retry settings, where attempts must be at least 1
+func (r *Retry) validate() error {
+	if r.Attempts < 1 {
+		return errors.ErrInvalidRetryAttempts
+	}
+
+	if r.Backoff < 0 || r.MaxBackoff < 0 {
+		return errors.ErrInvalidRetryBackoff
+	}
+
+	if r.Multiplier != 0 && r.Multiplier < 1 {
+		return errors.ErrInvalidRetryMultiplier
+	}
+
+	if r.Jitter < 0 || r.Jitter > 1 {
+		return errors.ErrInvalidRetryJitter
+	}
+
+	for class := range r.Errors {
+		if _, ok := retryClasses[class]; !ok {
+			known := slices.Sorted(maps.Keys(retryClasses))
+			return fmt.Errorf("%w '%s' (must be one of %s)", errors.ErrInvalidRetryErrorClass, class, strings.Join(known, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validate checks the fields set on the override with the same rules as the global settings
+func (r *ServiceRetry) validate() error {
+	retry := Retry{Attempts: 1}
+	r.apply(&retry)
+
+	return retry.validate()
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_RetryPolicy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Retry.Jitter = 0.1
+	cfg.Retry.Errors = map[string]bool{RetryOnProcessExited: false}
+	cfg.Services["api"] = &Service{}
+	cfg.Services["web"] = &Service{Retry: &ServiceRetry{
+		Attempts: ptr(5),
+		Jitter:   ptr(0.2),
+		Errors:   map[string]bool{RetryOnPortInUse: true},
+	}}
+	cfg.Services["db"] = &Service{Retry: &ServiceRetry{
+		Backoff:    ptr(time.Duration(0)),
+		MaxBackoff: ptr(time.Duration(0)),
+		Jitter:     ptr(0.0),
+	}}
+
+	assert.Equal(t, Retry{
+		Attempts:   RetryAttempts,
+		Backoff:    RetryBackoff,
+		MaxBackoff: RetryMaxBackoff,
+		Multiplier: RetryMultiplier,
+		Jitter:     0.1,
+		Errors:     map[string]bool{RetryOnProcessExited: false},
+	}, cfg.RetryPolicy("api"))
+
+	assert.Equal(t, Retry{
+		Attempts:   5,
+		Backoff:    RetryBackoff,
+		MaxBackoff: RetryMaxBackoff,
+		Multiplier: RetryMultiplier,
+		Jitter:     0.2,
+		Errors:     map[string]bool{RetryOnProcessExited: false, RetryOnPortInUse: true},
+	}, cfg.RetryPolicy("web"))
+
+	assert.Equal(t, Retry{
+		Attempts:   RetryAttempts,
+		Multiplier: RetryMultiplier,
+		Errors:     map[string]bool{RetryOnProcessExited: false},
+	}, cfg.RetryPolicy("db"), "explicit zero values override the global ones")
+
+	assert.Equal(t, map[string]bool{RetryOnProcessExited: false}, cfg.Retry.Errors, "global rules must not be modified")
+}
+
+func Test_Retry_DefaultBackoffIsConstant(t *testing.T) {
+	policy := DefaultConfig().RetryPolicy("api")
+
+	assert.Equal(t, RetryBackoff, policy.Delay(1))
+	assert.Equal(t, RetryBackoff, policy.Delay(3))
+}
+
+func Test_Retry_Retries(t *testing.T) {
+	retry := Retry{Errors: map[string]bool{RetryOnReadinessTimeout: false, RetryOnPortInUse: true}}
+
+	assert.False(t, retry.Retries(RetryOnReadinessTimeout))
+	assert.True(t, retry.Retries(RetryOnPortInUse))
+	assert.False(t, retry.Retries(RetryOnDirNotFound))
+	assert.True(t, retry.Retries(RetryOnProcessExited))
+	assert.True(t, retry.Retries("unknown"))
+}
+
+func Test_Retry_Delay(t *testing.T) {
+	retry := Retry{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 3}
+
+	assert.Equal(t, 100*time.Millisecond, retry.Delay(1))
+	assert.Equal(t, 300*time.Millisecond, retry.Delay(2))
+	assert.Equal(t, 900*time.Millisecond, retry.Delay(3))
+	assert.Equal(t, time.Second, retry.Delay(4))
+
+	fixed := Retry{Backoff: 100 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, fixed.Delay(5))
+
+	jittered := Retry{Backoff: time.Second, Multiplier: 1, Jitter: 0.5}
+	for range 20 {
+		delay := jittered.Delay(1)
+		assert.GreaterOrEqual(t, delay, 500*time.Millisecond)
+		assert.LessOrEqual(t, delay, 1500*time.Millisecond)
+	}
+}
+
+func Test_Retry_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		retry Retry
+		error error
+	}{
+		{name: "valid", retry: Retry{Attempts: 3, Backoff: time.Second, Multiplier: 2, Jitter: 0.1}},
+		{name: "missing attempts", retry: Retry{}, error: errors.ErrInvalidRetryAttempts},
+		{name: "negative attempts", retry: Retry{Attempts: -1}, error: errors.ErrInvalidRetryAttempts},
+		{name: "negative max backoff", retry: Retry{Attempts: 1, MaxBackoff: -time.Second}, error: errors.ErrInvalidRetryBackoff},
+		{name: "multiplier below one", retry: Retry{Attempts: 1, Multiplier: 0.5}, error: errors.ErrInvalidRetryMultiplier},
+		{name: "jitter above one", retry: Retry{Attempts: 1, Jitter: 1.5}, error: errors.ErrInvalidRetryJitter},
+		{name: "unknown error class", retry: Retry{Attempts: 1, Errors: map[string]bool{"timeout": false}}, error: errors.ErrInvalidRetryErrorClass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.retry.validate()
+			if tt.error == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_ServiceRetry_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		retry ServiceRetry
+		error error
+	}{
+		{name: "empty override", retry: ServiceRetry{}},
+		{name: "explicit zero values", retry: ServiceRetry{Backoff: ptr(time.Duration(0)), MaxBackoff: ptr(time.Duration(0)), Multiplier: ptr(0.0), Jitter: ptr(0.0)}},
+		{name: "zero attempts", retry: ServiceRetry{Attempts: ptr(0)}, error: errors.ErrInvalidRetryAttempts},
+		{name: "negative backoff", retry: ServiceRetry{Backoff: ptr(-time.Second)}, error: errors.ErrInvalidRetryBackoff},
+		{name: "multiplier below one", retry: ServiceRetry{Multiplier: ptr(0.5)}, error: errors.ErrInvalidRetryMultiplier},
+		{name: "jitter above one", retry: ServiceRetry{Jitter: ptr(1.5)}, error: errors.ErrInvalidRetryJitter},
+		{name: "unknown error class", retry: ServiceRetry{Errors: map[string]bool{"timeout": false}}, error: errors.ErrInvalidRetryErrorClass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.retry.validate()
+			if tt.error == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"go.yaml.in/yaml/v3"
+
+	"fuku/internal/app/errors"
+)
+
+// sourceSet parses config files into YAML documents and gives each file its own range of
+// line numbers, like go/token.FileSet, so merged and copied nodes keep pointing at their origin
+type sourceSet struct {
+	files []sourceFile
+	next  int
+}
+
+// sourceFile is a parsed config file and the offset added to its node lines
+type sourceFile struct {
+	path string
+	base int
+}
+
+// read loads and parses a config file
+func (s *sourceSet) read(path string) (*yaml.Node, error) {
+	data, err := readSource(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.parse(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errors.ErrFailedToParseConfig, path, err)
+	}
+
+	return doc, nil
+}
+
+// parse decodes data read from path and moves its node lines into a range of their own
+func (s *sourceSet) parse(path string, data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	s.add(path, &doc, bytes.Count(data, []byte("\n"))+1)
+
+	return &doc, nil
+}
+
+// add registers a parsed document spanning the given number of lines
+func (s *sourceSet) add(path string, doc *yaml.Node, lines int) {
+	s.files = append(s.files, sourceFile{path: path, base: s.next})
+	shiftLines(doc, s.next)
+	s.next += lines + 1
+}
+
+// position returns the file:line a node line was read from
+func (s *sourceSet) position(line int) (string, bool) {
+	if line <= 0 {
+		return "", false
+	}
+
+	for i := len(s.files) - 1; i >= 0; i-- {
+		if file := s.files[i]; line > file.base {
+			return file.path + ":" + strconv.Itoa(line-file.base), true
+		}
+	}
+
+	return "", false
+}
+
+// shiftLines adds offset to the line of node and all its descendants
+func shiftLines(node *yaml.Node, offset int) {
+	if node.Line > 0 {
+		node.Line += offset
+	}
+
+	for _, child := range node.Content {
+		shiftLines(child, offset)
+	}
+}
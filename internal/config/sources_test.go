@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SourceSet_Position(t *testing.T) {
+	sources := &sourceSet{}
+
+	first, err := sources.parse("fuku.yaml", []byte("version: 1\nservices:\n  api:\n    dir: api\n"))
+	require.NoError(t, err)
+
+	second, err := sources.parse("extra.yaml", []byte("services:\n  worker:\n    dir: worker\n"))
+	require.NoError(t, err)
+
+	firstDir := valueOf(valueOf(valueOf(first.Content[0], "services"), "api"), "dir")
+	secondDir := valueOf(valueOf(valueOf(second.Content[0], "services"), "worker"), "dir")
+
+	tests := []struct {
+		name     string
+		line     int
+		expected string
+		found    bool
+	}{
+		{name: "first file", line: firstDir.Line, expected: "fuku.yaml:4", found: true},
+		{name: "second file", line: secondDir.Line, expected: "extra.yaml:3", found: true},
+		{name: "copied node keeps its origin", line: copyNode(secondDir).Line, expected: "extra.yaml:3", found: true},
+		{name: "synthetic node", line: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, found := sources.position(tt.line)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, source)
+		})
+	}
+}
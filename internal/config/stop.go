@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"syscall"
+
+	"fuku/internal/app/errors"
+)
+
+// stopSignals lists the signals a service may be stopped with
+var stopSignals = map[string]syscall.Signal{
+	"SIGTERM": syscall.SIGTERM,
+	"SIGINT":  syscall.SIGINT,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGUSR1": syscall.SIGUSR1,
+	"SIGUSR2": syscall.SIGUSR2,
+	"SIGKILL": syscall.SIGKILL,
+}
+
+// StopPolicy returns how a service is stopped, filling in the default signal, timeout and grace period.
+// Timeout bounds the stop command, and grace is how long the process has after the stop signal
+// before it is killed, which defaults to the timeout
+func (c *Config) StopPolicy(name string) Stop {
+	var policy Stop
+
+	if service, ok := c.Services[name]; ok && service.Stop != nil {
+		policy = *service.Stop
+	}
+
+	if policy.Signal == "" {
+		policy.Signal = StopSignal
+	}
+
+	if policy.Timeout == 0 {
+		policy.Timeout = ShutdownTimeout
+	}
+
+	if policy.Grace == 0 {
+		policy.Grace = policy.Timeout
+	}
+
+	return policy
+}
+
+// ParseSignal converts a signal name such as SIGINT, sigint or INT into a signal
+func ParseSignal(name string) (syscall.Signal, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+
+	sig, ok := stopSignals[name]
+
+	return sig, ok
+}
+
+// validate checks the stop signal, timeout, grace period and command
+func (s *Stop) validate() error {
+	if s.Signal != "" {
+		if _, ok := ParseSignal(s.Signal); !ok {
+			known := slices.Sorted(maps.Keys(stopSignals))
+			return fmt.Errorf("%w '%s' (must be one of %s)", errors.ErrInvalidStopSignal, s.Signal, strings.Join(known, ", "))
+		}
+	}
+
+	if s.Timeout < 0 {
+		return errors.ErrInvalidStopTimeout
+	}
+
+	if s.Grace < 0 {
+		return errors.ErrInvalidStopGrace
+	}
+
+	if s.Command != "" && strings.TrimSpace(s.Command) == "" {
+		return errors.ErrInvalidStopCommand
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_StopPolicy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Services["api"] = &Service{}
+	cfg.Services["web"] = &Service{Stop: &Stop{Signal: "SIGINT"}}
+	cfg.Services["db"] = &Service{Stop: &Stop{Timeout: time.Minute, Command: "pg_ctl stop"}}
+	cfg.Services["compose"] = &Service{Stop: &Stop{Timeout: time.Minute, Grace: 10 * time.Second, Command: "docker compose down"}}
+
+	assert.Equal(t, Stop{Signal: StopSignal, Timeout: ShutdownTimeout, Grace: ShutdownTimeout}, cfg.StopPolicy("api"))
+	assert.Equal(t, Stop{Signal: "SIGINT", Timeout: ShutdownTimeout, Grace: ShutdownTimeout}, cfg.StopPolicy("web"))
+	assert.Equal(t, Stop{Signal: StopSignal, Timeout: time.Minute, Grace: time.Minute, Command: "pg_ctl stop"}, cfg.StopPolicy("db"))
+	assert.Equal(t, Stop{Signal: StopSignal, Timeout: time.Minute, Grace: 10 * time.Second, Command: "docker compose down"}, cfg.StopPolicy("compose"))
+	assert.Equal(t, Stop{Signal: StopSignal, Timeout: ShutdownTimeout, Grace: ShutdownTimeout}, cfg.StopPolicy("missing"))
+}
+
+func Test_ParseSignal(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected syscall.Signal
+		ok       bool
+	}{
+		{name: "SIGINT", expected: syscall.SIGINT, ok: true},
+		{name: "sigterm", expected: syscall.SIGTERM, ok: true},
+		{name: " QUIT ", expected: syscall.SIGQUIT, ok: true},
+		{name: "SIGSTOP", ok: false},
+		{name: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, ok := ParseSignal(tt.name)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, sig)
+		})
+	}
+}
+
+func Test_Stop_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		stop  Stop
+		error error
+	}{
+		{name: "empty", stop: Stop{}},
+		{name: "full", stop: Stop{Signal: "int", Timeout: 30 * time.Second, Command: "docker compose down"}},
+		{name: "unknown signal", stop: Stop{Signal: "SIGSTOP"}, error: errors.ErrInvalidStopSignal},
+		{name: "negative timeout", stop: Stop{Timeout: -time.Second}, error: errors.ErrInvalidStopTimeout},
+		{name: "negative grace", stop: Stop{Grace: -time.Second}, error: errors.ErrInvalidStopGrace},
+		{name: "whitespace command", stop: Stop{Command: "  "}, error: errors.ErrInvalidStopCommand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.stop.validate()
+			if tt.error == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"fuku/internal/app/errors"
+)
+
+// Tier failure policies
+const (
+	OnFailureContinue       = "continue"
+	OnFailureAbort          = "abort"
+	OnFailureSkipDependents = "skip-dependents"
+)
+
+// Tier represents an entry of the top-level tiers list with its startup policy
+type Tier struct {
+	Name        string        `yaml:"name"`
+	Timeout     time.Duration `yaml:"timeout,omitempty"`
+	Concurrency int           `yaml:"concurrency,omitempty"`
+	OnFailure   string        `yaml:"on_failure,omitempty"`
+}
+
+// UnmarshalYAML accepts either a bare tier name or a mapping with policy settings
+func (t *Tier) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		t.Name = node.Value
+
+		return nil
+	}
+
+	type plain Tier
+
+	return node.Decode((*plain)(t))
+}
+
+// tiersSection holds the tiers list decoded directly from YAML
+type tiersSection struct {
+	Tiers []Tier `yaml:"tiers"`
+}
+
+// TierPolicy returns the declared policy for a tier, or the default policy when it is not declared
+func (c *Config) TierPolicy(name string) Tier {
+	policy := Tier{Name: name}
+
+	for _, tier := range c.Tiers {
+		if tier.Name == name {
+			policy = tier
+		}
+	}
+
+	if policy.OnFailure == "" {
+		policy.OnFailure = OnFailureContinue
+	}
+
+	return policy
+}
+
+// applyTiers decodes the tiers list from raw YAML, merging repeated entries so later
+// definitions (such as those from the override file) replace earlier ones in place
+func (c *Config) applyTiers(data []byte) error {
+	tiers, err := decodeTiers(data)
+	if err != nil {
+		return err
+	}
+
+	c.Tiers = tiers
+
+	return nil
+}
+
+// decodeTiers reads and normalizes the tiers list from raw YAML
+func decodeTiers(data []byte) ([]Tier, error) {
+	var section tiersSection
+	if err := yaml.Unmarshal(data, &section); err != nil {
+		return nil, err
+	}
+
+	var tiers []Tier
+
+	index := make(map[string]int)
+
+	for _, tier := range section.Tiers {
+		tier.Name = normalizeTier(tier.Name)
+
+		if i, exists := index[tier.Name]; exists && tier.Name != "" {
+			tiers[i] = tier
+
+			continue
+		}
+
+		index[tier.Name] = len(tiers)
+		tiers = append(tiers, tier)
+	}
+
+	return tiers, nil
+}
+
+// validateTiers checks tier policies and that services only use declared tiers
+func (c *Config) validateTiers() error {
+	if len(c.Tiers) == 0 {
+		return nil
+	}
+
+	declared := make(map[string]bool, len(c.Tiers))
+
+	for i, tier := range c.Tiers {
+		if tier.Name == "" {
+			return fmt.Errorf("%w: tiers[%d]: name is required", errors.ErrInvalidTier, i)
+		}
+
+		switch tier.OnFailure {
+		case "", OnFailureContinue, OnFailureAbort, OnFailureSkipDependents:
+		default:
+			return fmt.Errorf("%w: tier '%s': on_failure must be one of %s, %s, %s", errors.ErrInvalidTier, tier.Name, OnFailureContinue, OnFailureAbort, OnFailureSkipDependents)
+		}
+
+		if tier.Concurrency < 0 {
+			return fmt.Errorf("%w: tier '%s': concurrency must not be negative", errors.ErrInvalidTier, tier.Name)
+		}
+
+		if tier.Timeout < 0 {
+			return fmt.Errorf("%w: tier '%s': timeout must not be negative", errors.ErrInvalidTier, tier.Name)
+		}
+
+		declared[tier.Name] = true
+	}
+
+	for name, service := range c.Services {
+		if service.Tier != "" && !declared[service.Tier] {
+			return fmt.Errorf("%w: service %s uses tier '%s'", errors.ErrUndeclaredTier, name, service.Tier)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fuku/internal/app/errors"
+)
+
+func Test_DecodeTiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		expected []Tier
+	}{
+		{
+			name:     "no tiers block",
+			yaml:     "services: {}\n",
+			expected: nil,
+		},
+		{
+			name: "scalar and mapping entries",
+			yaml: `tiers:
+  - Foundation
+  - name: platform
+    timeout: 2m
+    concurrency: 2
+    on_failure: abort
+`,
+			expected: []Tier{
+				{Name: "foundation"},
+				{Name: "platform", Timeout: 2 * time.Minute, Concurrency: 2, OnFailure: OnFailureAbort},
+			},
+		},
+		{
+			name: "later entry replaces earlier one in place",
+			yaml: `tiers:
+  - name: foundation
+    timeout: 10s
+  - edge
+  - name: foundation
+    on_failure: skip-dependents
+`,
+			expected: []Tier{
+				{Name: "foundation", OnFailure: OnFailureSkipDependents},
+				{Name: "edge"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tiers, err := decodeTiers([]byte(tt.yaml))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tiers)
+		})
+	}
+}
+
+func Test_TierPolicy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Tiers = []Tier{{Name: "foundation", Timeout: time.Minute, OnFailure: OnFailureAbort}, {Name: "edge"}}
+
+	assert.Equal(t, Tier{Name: "foundation", Timeout: time.Minute, OnFailure: OnFailureAbort}, cfg.TierPolicy("foundation"))
+	assert.Equal(t, Tier{Name: "edge", OnFailure: OnFailureContinue}, cfg.TierPolicy("edge"))
+	assert.Equal(t, Tier{Name: "other", OnFailure: OnFailureContinue}, cfg.TierPolicy("other"))
+}
+
+func Test_ValidateTiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		tiers    []Tier
+		services map[string]*Service
+		error    error
+	}{
+		{
+			name:     "no tiers declared",
+			services: map[string]*Service{"api": {Tier: "anything"}},
+		},
+		{
+			name:     "valid tiers",
+			tiers:    []Tier{{Name: "foundation", Timeout: time.Second, Concurrency: 1, OnFailure: OnFailureAbort}, {Name: "edge"}},
+			services: map[string]*Service{"db": {Tier: "foundation"}, "web": {Tier: "edge"}, "tool": {}},
+		},
+		{
+			name:  "missing name",
+			tiers: []Tier{{Name: ""}},
+			error: errors.ErrInvalidTier,
+		},
+		{
+			name:  "unknown on_failure",
+			tiers: []Tier{{Name: "foundation", OnFailure: "retry"}},
+			error: errors.ErrInvalidTier,
+		},
+		{
+			name:  "negative concurrency",
+			tiers: []Tier{{Name: "foundation", Concurrency: -1}},
+			error: errors.ErrInvalidTier,
+		},
+		{
+			name:  "negative timeout",
+			tiers: []Tier{{Name: "foundation", Timeout: -time.Second}},
+			error: errors.ErrInvalidTier,
+		},
+		{
+			name:     "service uses undeclared tier",
+			tiers:    []Tier{{Name: "foundation"}},
+			services: map[string]*Service{"web": {Tier: "edge"}},
+			error:    errors.ErrUndeclaredTier,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Tiers: tt.tiers, Services: tt.services}
+
+			err := cfg.validateTiers()
+			if tt.error == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Load_Tiers(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeConfigFile(t, ConfigFile, `version: 1
+tiers:
+  - foundation
+  - name: edge
+    on_failure: abort
+services:
+  web:
+    dir: web
+    tier: edge
+  db:
+    dir: db
+    tier: foundation
+`)
+	writeConfigFile(t, OverrideConfigFile, `tiers:
+  - name: foundation
+    concurrency: 1
+`)
+
+	cfg, topology, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, []Tier{{Name: "foundation", Concurrency: 1}, {Name: "edge", OnFailure: OnFailureAbort}}, cfg.Tiers)
+	assert.Equal(t, []string{"foundation", "edge"}, topology.Order)
+}
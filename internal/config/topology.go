@@ -22,13 +22,20 @@ func DefaultTopology() *Topology {
 	}
 }
 
-// parseTierOrder reads YAML config bytes and extracts tier ordering
+// parseTierOrder reads YAML config bytes and extracts tier ordering.
+// Tiers listed in the top-level tiers block come first in their declared order;
+// otherwise tiers are ordered by first appearance among services.
 func parseTierOrder(data []byte) (*Topology, error) {
 	var root yaml.Node
 	if err := yaml.Unmarshal(data, &root); err != nil {
 		return nil, err
 	}
 
+	declared, err := decodeTiers(data)
+	if err != nil {
+		return nil, err
+	}
+
 	topology := &Topology{
 		Order:        []string{},
 		TierServices: make(map[string][]string),
@@ -37,6 +44,13 @@ func parseTierOrder(data []byte) (*Topology, error) {
 	tierSeen := make(map[string]bool)
 	hasDefaultServices := false
 
+	for _, tier := range declared {
+		if tier.Name != "" {
+			tierSeen[tier.Name] = true
+			topology.Order = append(topology.Order, tier.Name)
+		}
+	}
+
 	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
 		return topology, nil
 	}
@@ -115,7 +129,7 @@ func parseTierOrder(data []byte) (*Topology, error) {
 		}
 	}
 
-	if hasDefaultServices {
+	if hasDefaultServices && !tierSeen[Default] {
 		topology.Order = append(topology.Order, Default)
 	}
 
@@ -164,6 +164,36 @@ defaults:
 			expectedTierOrder: []string{"platform"},
 			expectedServices:  map[string][]string{"platform": {"api"}},
 		},
+		{
+			name: "declared tiers set order regardless of service order",
+			yaml: `tiers:
+  - foundation
+  - name: platform
+    timeout: 30s
+  - edge
+services:
+  web:
+    tier: edge
+  api:
+    tier: platform
+  db:
+    tier: foundation
+  worker:
+    dir: ./worker`,
+			expectedTierOrder: []string{"foundation", "platform", "edge", "default"},
+			expectedServices:  map[string][]string{"foundation": {"db"}, "platform": {"api"}, "edge": {"web"}, "default": {"worker"}},
+		},
+		{
+			name: "declared default tier keeps its position",
+			yaml: `tiers: [default, edge]
+services:
+  web:
+    tier: edge
+  api:
+    dir: ./api`,
+			expectedTierOrder: []string{"default", "edge"},
+			expectedServices:  map[string][]string{"default": {"api"}, "edge": {"web"}},
+		},
 	}
 
 	for _, tt := range tests {
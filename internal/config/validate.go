@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"net"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -27,7 +29,21 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.validateTiers(); err != nil {
+		return err
+	}
+
+	if c.Defaults != nil {
+		if err := validateEnvFiles(c.Defaults.EnvFile); err != nil {
+			return fmt.Errorf("defaults: %w", err)
+		}
+	}
+
 	for name, service := range c.Services {
+		if err := service.validateType(); err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+
 		if err := service.validateCommand(); err != nil {
 			return fmt.Errorf("service %s: %w", name, err)
 		}
@@ -40,9 +56,120 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("service %s: %w", name, err)
 		}
 
+		if service.Stop != nil {
+			if err := service.Stop.validate(); err != nil {
+				return fmt.Errorf("service %s: %w", name, err)
+			}
+		}
+
+		if service.Limits != nil {
+			if err := service.Limits.validate(); err != nil {
+				return fmt.Errorf("service %s: %w", name, err)
+			}
+		}
+
+		if service.Hooks != nil {
+			if err := service.Hooks.validate(); err != nil {
+				return fmt.Errorf("service %s: %w", name, err)
+			}
+		}
+
+		if service.Restart != nil {
+			if err := service.Restart.validate(); err != nil {
+				return fmt.Errorf("service %s: %w", name, err)
+			}
+		}
+
+		if service.Retry != nil {
+			if err := service.Retry.validate(); err != nil {
+				return fmt.Errorf("service %s: %w", name, err)
+			}
+		}
+
 		if err := service.validateWatch(); err != nil {
 			return fmt.Errorf("service %s: %w", name, err)
 		}
+
+		if err := validateEnvFiles(service.EnvFile); err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+	}
+
+	return c.validateDependencies()
+}
+
+// Check runs static checks that go beyond Validate and returns every problem found
+func (c *Config) Check() []error {
+	var problems []error
+
+	for _, profile := range slices.Sorted(maps.Keys(c.Profiles)) {
+		if _, err := c.ProfileServices(profile); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	return problems
+}
+
+// Warnings returns non-fatal configuration issues that should be surfaced at startup
+func (c *Config) Warnings() []string {
+	return c.profileWarnings()
+}
+
+// validateDependencies checks that depends_on references exist and form an acyclic graph
+func (c *Config) validateDependencies() error {
+	names := slices.Sorted(maps.Keys(c.Services))
+
+	for _, name := range names {
+		for _, dep := range c.Services[name].DependsOn {
+			if _, exists := c.Services[dep]; !exists {
+				return fmt.Errorf("service %s: %w: '%s'", name, errors.ErrUnknownDependency, dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(names))
+
+	var path []string
+
+	var visit func(name string) error
+
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, name)
+			cycle := append(slices.Clone(path[start:]), name)
+
+			return fmt.Errorf("%w: %s", errors.ErrDependencyCycle, strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+
+		for _, dep := range c.Services[name].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -59,15 +186,7 @@ func (c *Config) validateConcurrency() error {
 
 // validateRetry validates retry settings
 func (c *Config) validateRetry() error {
-	if c.Retry.Attempts <= 0 {
-		return errors.ErrInvalidRetryAttempts
-	}
-
-	if c.Retry.Backoff < 0 {
-		return errors.ErrInvalidRetryBackoff
-	}
-
-	return nil
+	return c.Retry.validate()
 }
 
 // validateLogs validates logs settings
@@ -119,12 +238,16 @@ func isLoopback(host string) bool {
 	return host == LoopbackHostname || host == LoopbackIPv6Hostname
 }
 
-// validateCommand validates the command configuration
+// validateCommand validates the command and build configuration
 func (s *Service) validateCommand() error {
 	if s.Command != "" && strings.TrimSpace(s.Command) == "" {
 		return errors.ErrInvalidCommand
 	}
 
+	if s.Build != "" && strings.TrimSpace(s.Build) == "" {
+		return errors.ErrInvalidBuildCommand
+	}
+
 	return nil
 }
 
@@ -195,3 +318,19 @@ func (s *Service) validateWatch() error {
 
 	return nil
 }
+
+// validateEnvFiles checks that every referenced env file exists
+func validateEnvFiles(paths []string) error {
+	for _, path := range paths {
+		exists, err := fileExists(path)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", errors.ErrFailedToReadEnvFile, path, err)
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: %s", errors.ErrEnvFileNotFound, path)
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -414,6 +416,12 @@ func Test_ValidateCommand(t *testing.T) {
 	}
 }
 
+func Test_ValidateBuild(t *testing.T) {
+	assert.NoError(t, (&Service{}).validateCommand())
+	assert.NoError(t, (&Service{Build: "go build -o bin/api ."}).validateCommand())
+	assert.ErrorIs(t, (&Service{Build: "  "}).validateCommand(), errors.ErrInvalidBuildCommand)
+}
+
 func Test_ValidateReadiness(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -655,3 +663,114 @@ func Test_ValidateWatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(existing, []byte("A=1\n"), 0644))
+
+	tests := []struct {
+		name        string
+		paths       []string
+		expectedErr error
+	}{
+		{
+			name:        "no env files",
+			paths:       nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "existing env file",
+			paths:       []string{existing},
+			expectedErr: nil,
+		},
+		{
+			name:        "missing env file",
+			paths:       []string{existing, filepath.Join(dir, ".env.missing")},
+			expectedErr: errors.ErrEnvFileNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEnvFiles(tt.paths)
+
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name        string
+		services    map[string]*Service
+		expectedErr error
+		errorMsg    string
+	}{
+		{
+			name: "no dependencies",
+			services: map[string]*Service{
+				"api": {},
+				"db":  {},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "valid dependency chain",
+			services: map[string]*Service{
+				"db":   {},
+				"auth": {DependsOn: []string{"db"}},
+				"api":  {DependsOn: []string{"auth", "db"}},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "unknown dependency",
+			services: map[string]*Service{
+				"api": {DependsOn: []string{"missing"}},
+			},
+			expectedErr: errors.ErrUnknownDependency,
+			errorMsg:    "service api: depends_on references unknown service: 'missing'",
+		},
+		{
+			name: "self dependency",
+			services: map[string]*Service{
+				"api": {DependsOn: []string{"api"}},
+			},
+			expectedErr: errors.ErrDependencyCycle,
+			errorMsg:    "dependency cycle detected: api -> api",
+		},
+		{
+			name: "indirect cycle",
+			services: map[string]*Service{
+				"api":  {DependsOn: []string{"auth"}},
+				"auth": {DependsOn: []string{"db"}},
+				"db":   {DependsOn: []string{"api"}},
+			},
+			expectedErr: errors.ErrDependencyCycle,
+			errorMsg:    "dependency cycle detected: api -> auth -> db -> api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Services = tt.services
+
+			err := cfg.Validate()
+
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Equal(t, tt.errorMsg, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}